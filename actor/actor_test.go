@@ -33,7 +33,7 @@ func TestActor_CT_Breaking(t *testing.T) {
 	}
 
 	// 建立賽事管理
-	tableManager := pokertable.NewManager()
+	tableManager := pokercompetition.NewTableManager()
 	tableManagerBackend := pokercompetition.NewNativeTableManagerBackend(tableManager)
 	manager := pokercompetition.NewManager(tableManagerBackend)
 	tableOptions := pokertable.NewTableEngineOptions()
@@ -107,6 +107,7 @@ func TestActor_CT_Breaking(t *testing.T) {
 
 		// Initializing bot runner
 		bot := NewBotRunner(p.PlayerID)
+		bot.SetCompetitionMeta(competition.Meta)
 		bot.OnTableAutoJoinActionRequested(func(competitionID, tableID, playerID string) {
 			go func(pID string) {
 				assert.Nil(t, tableEngine.PlayerJoin(pID), fmt.Sprintf("%s join table failed", pID))
@@ -197,7 +198,7 @@ func TestActor_CT_Normal(t *testing.T) {
 	}
 
 	// 建立賽事管理
-	tableManager := pokertable.NewManager()
+	tableManager := pokercompetition.NewTableManager()
 	tableManagerBackend := pokercompetition.NewNativeTableManagerBackend(tableManager)
 	manager := pokercompetition.NewManager(tableManagerBackend)
 	tableOptions := pokertable.NewTableEngineOptions()
@@ -271,6 +272,7 @@ func TestActor_CT_Normal(t *testing.T) {
 
 		// Initializing bot runner
 		bot := NewBotRunner(p.PlayerID)
+		bot.SetCompetitionMeta(competition.Meta)
 		bot.OnTableAutoJoinActionRequested(func(competitionID, tableID, playerID string) {
 			go func(pID string) {
 				assert.Nil(t, tableEngine.PlayerJoin(pID), fmt.Sprintf("%s join table failed", pID))
@@ -380,7 +382,7 @@ func TestActor_CT_Normal(t *testing.T) {
 // 	qm := pokercompetition.NewNativeQueueManager()
 // 	err := qm.Connect()
 // 	assert.Nil(t, err, "queue manager connect error")
-// 	tableManager := pokertable.NewManager()
+// 	tableManager := pokercompetition.NewTableManager()
 // 	tableManagerBackend := pokercompetition.NewNativeTableManagerBackend(tableManager)
 // 	manager := pokercompetition.NewManager(tableManagerBackend, qm)
 // 	tableOptions := pokertable.NewTableEngineOptions()
@@ -4,6 +4,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/weedbox/pokercompetition"
 	"github.com/weedbox/pokerface"
 	"github.com/weedbox/pokertable"
 	"github.com/weedbox/timebank"
@@ -43,6 +44,7 @@ type botRunner struct {
 	actions                        Actions
 	playerID                       string
 	isHumanized                    bool
+	bettingStructure               pokercompetition.CompetitionBettingStructure
 	curGameID                      string
 	lastGameStateTime              int64
 	timebank                       *timebank.TimeBank
@@ -55,6 +57,7 @@ func NewBotRunner(playerID string) *botRunner {
 	return &botRunner{
 		playerID:                       playerID,
 		timebank:                       timebank.NewTimeBank(),
+		bettingStructure:               pokercompetition.CompetitionBettingStructure_NoLimit,
 		onTableGameWagerActionUpdated:  func(string, string, int, string, string, int64) {},
 		onTableAutoJoinActionRequested: func(string, string, string) {},
 	}
@@ -69,6 +72,17 @@ func (br *botRunner) Humanized(enabled bool) {
 	br.isHumanized = enabled
 }
 
+func (br *botRunner) SetBettingStructure(structure pokercompetition.CompetitionBettingStructure) {
+	br.bettingStructure = structure
+}
+
+// SetCompetitionMeta 依賽事設定套用下注結構
+func (br *botRunner) SetCompetitionMeta(meta pokercompetition.CompetitionMeta) {
+	if meta.BettingStructure != "" {
+		br.SetBettingStructure(meta.BettingStructure)
+	}
+}
+
 func (br *botRunner) OnTableGameWagerActionUpdated(fn TableGameWagerActionUpdatedFunc) error {
 	br.onTableGameWagerActionUpdated = fn
 	return nil
@@ -292,13 +306,13 @@ func (br *botRunner) requestAI(gs *pokerface.GameState, playerIdx int) error {
 	switch action {
 	case "bet":
 
-		minBet := gs.Status.MiniBet
+		betRange := pokercompetition.GetBetRange(br.bettingStructure, gs, playerIdx)
 
-		if player.InitialStackSize <= minBet {
-			return br.actions.Bet(player.InitialStackSize)
+		if betRange.Max <= betRange.Min {
+			return br.actions.Bet(betRange.Max)
 		}
 
-		chips = rand.Int63n(player.InitialStackSize-minBet) + minBet
+		chips = rand.Int63n(betRange.Max-betRange.Min) + betRange.Min
 
 		err := br.actions.Bet(chips)
 		if err != nil {
@@ -309,8 +323,9 @@ func (br *botRunner) requestAI(gs *pokerface.GameState, playerIdx int) error {
 		return nil
 	case "raise":
 
-		maxChipLevel := player.InitialStackSize
-		minChipLevel := gs.Status.CurrentWager + gs.Status.PreviousRaiseSize
+		raiseRange := pokercompetition.GetRaiseRange(br.bettingStructure, gs, playerIdx)
+		maxChipLevel := raiseRange.Max
+		minChipLevel := raiseRange.Min
 
 		if maxChipLevel <= minChipLevel {
 			err := br.actions.Raise(maxChipLevel)
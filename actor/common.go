@@ -22,8 +22,8 @@ func NewCTCompetitionSetting_Breaking() pokercompetition.CompetitionSetting {
 		},
 		{
 			Level:      2,
-			SB:         10,
-			BB:         20,
+			SB:         15,
+			BB:         30,
 			Ante:       0,
 			Duration:   2,
 			AllowAddon: false,
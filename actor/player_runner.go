@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/weedbox/pokercompetition"
 	"github.com/weedbox/pokerface"
 	"github.com/weedbox/pokertable"
 	"github.com/weedbox/timebank"
@@ -26,6 +27,7 @@ type PlayerRunner struct {
 	curGameID                     string
 	lastGameStateTime             int64
 	tableInfo                     *pokertable.Table
+	bettingStructure              pokercompetition.CompetitionBettingStructure
 	timebank                      *timebank.TimeBank
 	onAutoModeUpdated             func(string, bool) // tableID(string), isOn(bool)
 	onTableStateUpdated           func(*pokertable.Table)
@@ -42,6 +44,7 @@ func NewPlayerRunner(playerID string) *PlayerRunner {
 	return &PlayerRunner{
 		playerID:                      playerID,
 		timebank:                      timebank.NewTimeBank(),
+		bettingStructure:              pokercompetition.CompetitionBettingStructure_NoLimit,
 		status:                        PlayerStatus_Running,
 		suspendThreshold:              2,
 		onAutoModeUpdated:             func(string, bool) {},
@@ -56,6 +59,17 @@ func (pr *PlayerRunner) SetActor(a Actor) {
 	pr.actions = NewActions(a, pr.playerID)
 }
 
+func (pr *PlayerRunner) SetBettingStructure(structure pokercompetition.CompetitionBettingStructure) {
+	pr.bettingStructure = structure
+}
+
+// SetCompetitionMeta 依賽事設定套用下注結構
+func (pr *PlayerRunner) SetCompetitionMeta(meta pokercompetition.CompetitionMeta) {
+	if meta.BettingStructure != "" {
+		pr.SetBettingStructure(meta.BettingStructure)
+	}
+}
+
 func (pr *PlayerRunner) SetEventSubscribed(isEventSubscribed bool) {
 	pr.isEventSubscribed = isEventSubscribed
 }
@@ -342,6 +356,10 @@ func (pr *PlayerRunner) Check() error {
 }
 
 func (pr *PlayerRunner) Bet(chips int64) error {
+	// 依下注結構限制下注籌碼量
+	if gs, gamePlayerIdx, ok := pr.currentGamePlayer(); ok {
+		chips = pokercompetition.GetBetRange(pr.bettingStructure, gs, gamePlayerIdx).Clamp(chips)
+	}
 
 	err := pr.actions.Bet(chips)
 	if err != nil {
@@ -410,6 +428,10 @@ func (pr *PlayerRunner) Allin() error {
 }
 
 func (pr *PlayerRunner) Raise(chipLevel int64) error {
+	// 依下注結構限制加注籌碼級別
+	if gs, gamePlayerIdx, ok := pr.currentGamePlayer(); ok {
+		chipLevel = pokercompetition.GetRaiseRange(pr.bettingStructure, gs, gamePlayerIdx).Clamp(chipLevel)
+	}
 
 	err := pr.actions.Raise(chipLevel)
 	if err != nil {
@@ -442,6 +464,19 @@ func (pr *PlayerRunner) IsAutoMode() (string, bool) {
 	return tableID, isOn
 }
 
+func (pr *PlayerRunner) currentGamePlayer() (*pokerface.GameState, int, bool) {
+	if pr.tableInfo == nil || pr.tableInfo.State.GameState == nil {
+		return nil, -1, false
+	}
+
+	gamePlayerIdx := pr.tableInfo.FindGamePlayerIdx(pr.playerID)
+	if gamePlayerIdx < 0 || gamePlayerIdx >= len(pr.tableInfo.State.GameState.Players) {
+		return nil, -1, false
+	}
+
+	return pr.tableInfo.State.GameState, gamePlayerIdx, true
+}
+
 func (pr *PlayerRunner) updateWagerAction(action string, chips int64) {
 	tableID := ""
 	gameCount := 0
@@ -0,0 +1,219 @@
+package pokercompetition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thoas/go-funk"
+)
+
+type AddonPackage struct {
+	Name        string           `json:"name"`         // 方案名稱
+	RedeemChips int64            `json:"redeem_chips"` // 兌換籌碼數
+	Price       int64            `json:"price"`        // 價格
+	Unit        int              `json:"unit"`         // 計入增購發數
+	Eligibility AddonEligibility `json:"eligibility"`  // 購買資格
+}
+
+type AddonEligibility struct {
+	NeverReBought bool  `json:"never_re_bought"` // 僅限從未補碼的玩家
+	MaxChips      int64 `json:"max_chips"`       // 籌碼小於等於此值才可購買 (0 表示不限制)
+}
+
+// IsEligible 玩家是否符合方案購買資格
+func (p AddonPackage) IsEligible(cp *CompetitionPlayer) bool {
+	if p.Eligibility.NeverReBought && cp.ReBuyTimes > 0 {
+		return false
+	}
+	if p.Eligibility.MaxChips > 0 && cp.Chips > p.Eligibility.MaxChips {
+		return false
+	}
+	return true
+}
+
+type AddonOffer struct {
+	CompetitionID   string         `json:"competition_id"`    // 賽事 ID
+	PlayerID        string         `json:"player_id"`         // 玩家 ID
+	BlindLevelIndex int            `json:"blind_level_index"` // 可增購的盲注等級索引值
+	Packages        []AddonPackage `json:"packages"`          // 玩家可購買的方案
+	Deadline        int64          `json:"deadline"`          // 增購截止時間 (Seconds, 依手數計算的等級為 UnsetValue)
+}
+
+type pendingAddon struct {
+	tableID    string
+	joinPlayer JoinPlayer
+}
+
+/*
+findAddonPackage 驗證增購籌碼並取得對應方案
+  - 有設定方案時: 依方案名稱 (未指定時依籌碼數) 找出玩家可購買的方案
+  - 沒有設定方案時: 籌碼數需在 RedeemChips 列表中 (列表為空表示不限制)
+*/
+func (c Competition) findAddonPackage(cp *CompetitionPlayer, joinPlayer JoinPlayer) (*AddonPackage, error) {
+	setting := c.Meta.AddonSetting
+	if len(setting.Packages) == 0 {
+		if len(setting.RedeemChips) > 0 && !funk.ContainsInt64(setting.RedeemChips, joinPlayer.RedeemChips) {
+			return nil, ErrCompetitionInvalidAddonPackage
+		}
+		return nil, nil
+	}
+
+	isFound := false
+	for idx, p := range setting.Packages {
+		if joinPlayer.AddonPackage != "" && p.Name != joinPlayer.AddonPackage {
+			continue
+		}
+		if joinPlayer.AddonPackage == "" && p.RedeemChips != joinPlayer.RedeemChips {
+			continue
+		}
+		isFound = true
+		if p.IsEligible(cp) {
+			return &setting.Packages[idx], nil
+		}
+	}
+
+	if !isFound {
+		return nil, ErrCompetitionInvalidAddonPackage
+	}
+	return nil, ErrCompetitionAddonNotEligible
+}
+
+/*
+offerAddons 發送增購邀請給所有符合資格的玩家
+  - 適用時機: 盲注等級變更為可增購等級 (ex: 中場休息)
+  - 同一等級只發送一次
+*/
+func (ce *competitionEngine) offerAddons() {
+	levelIndex := ce.competition.State.BlindState.CurrentLevelIndex
+	if levelIndex < 0 || levelIndex == ce.addonOfferedLevelIndex || !ce.competition.CurrentBlindLevel().AllowAddon {
+		return
+	}
+	ce.addonOfferedLevelIndex = levelIndex
+
+	deadline := int64(UnsetValue)
+	if levelIndex < len(ce.competition.State.BlindState.EndAts) && ce.competition.State.BlindState.EndAts[levelIndex] > 0 {
+		deadline = ce.competition.State.BlindState.EndAts[levelIndex]
+	}
+
+	offeredCount := 0
+	for _, cp := range ce.competition.State.Players {
+		if cp.Chips <= 0 || cp.AddonTimes >= ce.competition.Meta.AddonSetting.MaxTime {
+			continue
+		}
+
+		packages := make([]AddonPackage, 0)
+		for _, p := range ce.competition.Meta.AddonSetting.Packages {
+			if p.IsEligible(cp) {
+				packages = append(packages, p)
+			}
+		}
+		if len(ce.competition.Meta.AddonSetting.Packages) > 0 && len(packages) == 0 {
+			continue
+		}
+
+		offer := AddonOffer{
+			CompetitionID:   ce.competition.ID,
+			PlayerID:        cp.PlayerID,
+			BlindLevelIndex: levelIndex,
+			Packages:        packages,
+			Deadline:        deadline,
+		}
+		ce.emitTypedEvent(EventType_AddonOffered, offer)
+		offeredCount++
+	}
+
+	if offeredCount > 0 {
+		ce.emitEvent(fmt.Sprintf("Addon Offered -> %d Players", offeredCount), "")
+		ce.emitCompetitionStateEvent(CompetitionStateEvent_AddonOffered)
+	}
+}
+
+/*
+handlePendingAddons 處理本桌等待中的增購
+  - 適用時機: 每手結算後 (兩手之間)
+*/
+func (ce *competitionEngine) handlePendingAddons(ctx context.Context, tableID string) {
+	ce.mu.RLock()
+	playerIDs := make([]string, 0)
+	for playerID, addon := range ce.pendingAddons {
+		if addon.tableID == tableID {
+			playerIDs = append(playerIDs, playerID)
+		}
+	}
+	ce.mu.RUnlock()
+
+	for _, playerID := range playerIDs {
+		ce.applyPendingAddon(ctx, playerID)
+	}
+}
+
+/*
+applyPendingAddon 套用玩家增購 (籌碼、增購次數與統計一次更新)
+  - 牌局進行中的桌次等到該手結算後再處理
+  - 桌次加入籌碼失敗時還原籌碼、增購次數與統計
+*/
+func (ce *competitionEngine) applyPendingAddon(ctx context.Context, playerID string) {
+	ce.mu.Lock()
+	addon, exist := ce.pendingAddons[playerID]
+	if !exist {
+		ce.mu.Unlock()
+		return
+	}
+
+	cp := ce.competition.findPlayer(playerID)
+	if cp == nil || cp.Chips <= 0 {
+		delete(ce.pendingAddons, playerID)
+		ce.mu.Unlock()
+		ce.emitErrorEvent("Apply Pending Addon", playerID, ErrCompetitionAddonRejected)
+		return
+	}
+
+	if ce.isTableGameInProgress(addon.tableID) {
+		ce.mu.Unlock()
+		return
+	}
+	delete(ce.pendingAddons, playerID)
+	ce.mu.Unlock()
+
+	joinPlayer := addon.joinPlayer
+	isModeCTorMTT := ce.competition.IsModeCTorMTT()
+	previousTableID := cp.CurrentTableID
+	err := ce.redeemChips(ctx, chipRedemption{
+		playerID: playerID,
+		tableID:  addon.tableID,
+		chips:    joinPlayer.RedeemChips,
+		apply: func(cp *CompetitionPlayer) {
+			cp.CurrentTableID = addon.tableID
+			cp.AddonTimes++
+			cp.TotalRedeemChips += joinPlayer.RedeemChips
+			if isModeCTorMTT {
+				ce.competition.State.Statistic.TotalAddonCount += joinPlayer.Unit
+			}
+		},
+		revert: func(cp *CompetitionPlayer) {
+			cp.CurrentTableID = previousTableID
+			cp.AddonTimes--
+			cp.TotalRedeemChips -= joinPlayer.RedeemChips
+			if isModeCTorMTT {
+				ce.competition.State.Statistic.TotalAddonCount -= joinPlayer.Unit
+			}
+		},
+	})
+	if err != nil {
+		ce.emitErrorEvent("Apply Pending Addon -> PlayerRedeemChips", playerID, err)
+		return
+	}
+
+	// emit events
+	ce.emitEvent("PlayerAddon", playerID)
+	ce.emitPlayerEvent("PlayerAddon", cp)
+	ce.emitCompetitionStateEvent(CompetitionStateEvent_CompetitionStatisticUpdated)
+	ce.emitTypedEvent(EventType_PlayerAddedOn, PlayerAddedOnPayload{
+		PlayerID:    playerID,
+		TableID:     addon.tableID,
+		Package:     joinPlayer.AddonPackage,
+		RedeemChips: joinPlayer.RedeemChips,
+		AddonTimes:  cp.AddonTimes,
+		Chips:       cp.Chips,
+	})
+}
@@ -0,0 +1,251 @@
+package pokercompetition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokertable"
+)
+
+// addonTestBackend 記錄 PlayerRedeemChips 的呼叫，其他操作不應被呼叫
+type addonTestBackend struct {
+	TableManagerBackend
+	redeemed []pokertable.JoinPlayer
+}
+
+func (b *addonTestBackend) PlayerRedeemChips(tableID string, joinPlayer pokertable.JoinPlayer) error {
+	b.redeemed = append(b.redeemed, joinPlayer)
+	return nil
+}
+
+/*
+newAddonTestEngine 建立桌次 t1 位於可增購等級 (盲注等級 index 1) 的 MTT 賽事
+  - p1: 從未補碼，籌碼 1000
+  - p2: 補碼過一次，籌碼 5000
+  - p3: 已沒有籌碼
+  - 方案 single (1000 籌碼，籌碼 2000 以下可購買)、double (2000 籌碼，僅限從未補碼)
+*/
+func newAddonTestEngine() (*competitionEngine, *addonTestBackend) {
+	p1 := newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_Playing)
+	p2 := newTestCompetitionPlayer("p2", 5000, CompetitionPlayerStatus_Playing)
+	p2.ReBuyTimes = 1
+	p3 := newTestCompetitionPlayer("p3", 0, CompetitionPlayerStatus_ReBuyWaiting)
+
+	ce := newTestCompetitionEngine(p1, p2, p3)
+	ce.competition.Meta.Blind.Levels = []BlindLevel{
+		{Level: 1, SB: 10, BB: 20, Duration: 60},
+		{Level: -1, Duration: 60, AllowAddon: true},
+		{Level: 2, SB: 20, BB: 40, Duration: 60},
+	}
+	ce.competition.Meta.AddonSetting = AddonSetting{
+		MaxTime: 1,
+		Packages: []AddonPackage{
+			{Name: "single", RedeemChips: 1000, Unit: 1, Eligibility: AddonEligibility{MaxChips: 2000}},
+			{Name: "double", RedeemChips: 2000, Unit: 2, Eligibility: AddonEligibility{NeverReBought: true}},
+		},
+	}
+	ce.competition.State.Status = CompetitionStateStatus_DelayedBuyIn
+	ce.competition.State.BlindState = &BlindState{CurrentLevelIndex: 1, EndAts: []int64{100, 200, 300}}
+	addTestTable(ce, "t1", pokertable.TableStateStatus_TableGameStandby, p1, p2, p3)
+
+	backend := &addonTestBackend{}
+	ce.tableManagerBackend = backend
+	return ce, backend
+}
+
+func Test_Addon_IsEligible(t *testing.T) {
+	testCases := []struct {
+		name        string
+		eligibility AddonEligibility
+		player      CompetitionPlayer
+		expected    bool
+	}{
+		{name: "no restriction", eligibility: AddonEligibility{}, player: CompetitionPlayer{Chips: 10000, ReBuyTimes: 2}, expected: true},
+		{name: "never re-bought", eligibility: AddonEligibility{NeverReBought: true}, player: CompetitionPlayer{Chips: 1000}, expected: true},
+		{name: "re-bought", eligibility: AddonEligibility{NeverReBought: true}, player: CompetitionPlayer{Chips: 1000, ReBuyTimes: 1}, expected: false},
+		{name: "at max chips", eligibility: AddonEligibility{MaxChips: 2000}, player: CompetitionPlayer{Chips: 2000}, expected: true},
+		{name: "over max chips", eligibility: AddonEligibility{MaxChips: 2000}, player: CompetitionPlayer{Chips: 2001}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := AddonPackage{Eligibility: tc.eligibility}
+			assert.Equal(t, tc.expected, p.IsEligible(&tc.player))
+		})
+	}
+}
+
+func Test_Addon_FindAddonPackage(t *testing.T) {
+	testCases := []struct {
+		name       string
+		playerID   string
+		joinPlayer JoinPlayer
+		expected   string
+		err        error
+	}{
+		{name: "by package name", playerID: "p1", joinPlayer: JoinPlayer{AddonPackage: "double"}, expected: "double"},
+		{name: "by redeem chips", playerID: "p1", joinPlayer: JoinPlayer{RedeemChips: 1000}, expected: "single"},
+		{name: "unknown package", playerID: "p1", joinPlayer: JoinPlayer{AddonPackage: "triple"}, err: ErrCompetitionInvalidAddonPackage},
+		{name: "unknown redeem chips", playerID: "p1", joinPlayer: JoinPlayer{RedeemChips: 1500}, err: ErrCompetitionInvalidAddonPackage},
+		{name: "re-bought player", playerID: "p2", joinPlayer: JoinPlayer{AddonPackage: "double"}, err: ErrCompetitionAddonNotEligible},
+		{name: "too many chips", playerID: "p2", joinPlayer: JoinPlayer{RedeemChips: 1000}, err: ErrCompetitionAddonNotEligible},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ce, _ := newAddonTestEngine()
+			p, err := ce.competition.findAddonPackage(ce.competition.findPlayer(tc.playerID), tc.joinPlayer)
+			assert.ErrorIs(t, err, tc.err)
+			if tc.err == nil && assert.NotNil(t, p) {
+				assert.Equal(t, tc.expected, p.Name)
+			}
+		})
+	}
+}
+
+func Test_Addon_FindAddonPackageWithoutPackages(t *testing.T) {
+	ce, _ := newAddonTestEngine()
+	ce.competition.Meta.AddonSetting.Packages = nil
+	cp := ce.competition.findPlayer("p1")
+
+	// 沒有設定可兌換籌碼時不限制
+	p, err := ce.competition.findAddonPackage(cp, JoinPlayer{RedeemChips: 1234})
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+
+	ce.competition.Meta.AddonSetting.RedeemChips = []int64{1000, 2000}
+	_, err = ce.competition.findAddonPackage(cp, JoinPlayer{RedeemChips: 2000})
+	assert.NoError(t, err)
+	_, err = ce.competition.findAddonPackage(cp, JoinPlayer{RedeemChips: 1234})
+	assert.ErrorIs(t, err, ErrCompetitionInvalidAddonPackage)
+}
+
+func Test_Addon_OfferAddons(t *testing.T) {
+	ce, _ := newAddonTestEngine()
+	offers := make([]AddonOffer, 0)
+	ce.OnCompetitionAddonOffered(func(offer AddonOffer) {
+		offers = append(offers, offer)
+	})
+
+	ce.offerAddons()
+
+	// p2 籌碼過多且補碼過，沒有可購買方案；p3 沒有籌碼
+	if assert.Len(t, offers, 1) {
+		offer := offers[0]
+		assert.Equal(t, "c1", offer.CompetitionID)
+		assert.Equal(t, "p1", offer.PlayerID)
+		assert.Equal(t, 1, offer.BlindLevelIndex)
+		assert.Equal(t, int64(200), offer.Deadline)
+		assert.Equal(t, []string{"single", "double"}, []string{offer.Packages[0].Name, offer.Packages[1].Name})
+	}
+
+	// 同一等級只發送一次
+	ce.offerAddons()
+	assert.Len(t, offers, 1)
+
+	// 不可增購的等級不發送
+	ce.competition.State.BlindState.CurrentLevelIndex = 2
+	ce.offerAddons()
+	assert.Len(t, offers, 1)
+}
+
+func Test_Addon_OfferAddonsSkipsMaxTime(t *testing.T) {
+	ce, _ := newAddonTestEngine()
+	ce.competition.findPlayer("p1").AddonTimes = 1
+	offers := make([]AddonOffer, 0)
+	ce.OnCompetitionAddonOffered(func(offer AddonOffer) {
+		offers = append(offers, offer)
+	})
+
+	ce.offerAddons()
+	assert.Empty(t, offers)
+}
+
+func Test_Addon_PendingBetweenHands(t *testing.T) {
+	ce, backend := newAddonTestEngine()
+	ce.competition.State.Tables[0].State.Status = pokertable.TableStateStatus_TableGamePlaying
+
+	// 牌局進行中: 保留等待，不加入籌碼
+	assert.NoError(t, ce.PlayerAddon("t1", JoinPlayer{PlayerID: "p1", AddonPackage: "double", RedeemChips: 1}))
+	cp := ce.competition.findPlayer("p1")
+	assert.Equal(t, int64(1000), cp.Chips)
+	assert.Contains(t, ce.pendingAddons, "p1")
+	assert.Empty(t, backend.redeemed)
+
+	// 等待中不可重複增購
+	assert.ErrorIs(t, ce.PlayerAddon("t1", JoinPlayer{PlayerID: "p1", AddonPackage: "single", RedeemChips: 1}), ErrCompetitionAddonRejected)
+
+	// 該手結算後 (兩手之間) 以方案籌碼加入
+	ce.competition.State.Tables[0].State.Status = pokertable.TableStateStatus_TableGameStandby
+	ce.handlePendingAddons(context.Background(), "t1")
+
+	assert.NotContains(t, ce.pendingAddons, "p1")
+	assert.Equal(t, int64(3000), cp.Chips)
+	assert.Equal(t, int64(3000), ce.competition.State.Tables[0].State.PlayerStates[0].Bankroll)
+	assert.Equal(t, 1, cp.AddonTimes)
+	assert.Equal(t, int64(2000), cp.TotalRedeemChips)
+	assert.Equal(t, 2, ce.competition.State.Statistic.TotalAddonCount)
+	if assert.Len(t, backend.redeemed, 1) {
+		assert.Equal(t, "p1", backend.redeemed[0].PlayerID)
+		assert.Equal(t, int64(2000), backend.redeemed[0].RedeemChips)
+	}
+
+	// 已達增購次數上限
+	assert.ErrorIs(t, ce.PlayerAddon("t1", JoinPlayer{PlayerID: "p1", AddonPackage: "single", RedeemChips: 1}), ErrCompetitionExceedAddonLimit)
+}
+
+func Test_Addon_PendingCancelledWhenBusted(t *testing.T) {
+	ce, backend := newAddonTestEngine()
+	ce.competition.State.Tables[0].State.Status = pokertable.TableStateStatus_TableGamePlaying
+	assert.NoError(t, ce.PlayerAddon("t1", JoinPlayer{PlayerID: "p1", RedeemChips: 1000}))
+
+	// 該手被淘汰: 取消增購
+	ce.competition.findPlayer("p1").Chips = 0
+	ce.competition.State.Tables[0].State.Status = pokertable.TableStateStatus_TableGameStandby
+	ce.handlePendingAddons(context.Background(), "t1")
+
+	assert.NotContains(t, ce.pendingAddons, "p1")
+	assert.Equal(t, 0, ce.competition.findPlayer("p1").AddonTimes)
+	assert.Empty(t, backend.redeemed)
+}
+
+func Test_Addon_Rejected(t *testing.T) {
+	testCases := []struct {
+		name       string
+		modify     func(ce *competitionEngine)
+		joinPlayer JoinPlayer
+		err        error
+	}{
+		{name: "no redeem chips", joinPlayer: JoinPlayer{PlayerID: "p1"}, err: ErrCompetitionNoRedeemChips},
+		{name: "unknown player", joinPlayer: JoinPlayer{PlayerID: "p9", RedeemChips: 1000}, err: ErrCompetitionAddonRejected},
+		{
+			name:       "level does not allow addon",
+			modify:     func(ce *competitionEngine) { ce.competition.State.BlindState.CurrentLevelIndex = 0 },
+			joinPlayer: JoinPlayer{PlayerID: "p1", RedeemChips: 1000},
+			err:        ErrCompetitionAddonRejected,
+		},
+		{
+			name: "break only",
+			modify: func(ce *competitionEngine) {
+				ce.competition.Meta.AddonSetting.IsBreakOnly = true
+				ce.competition.Meta.Blind.Levels[1].Level = 2
+			},
+			joinPlayer: JoinPlayer{PlayerID: "p1", RedeemChips: 1000},
+			err:        ErrCompetitionAddonRejected,
+		},
+		{name: "not eligible", joinPlayer: JoinPlayer{PlayerID: "p2", RedeemChips: 1000}, err: ErrCompetitionAddonNotEligible},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ce, backend := newAddonTestEngine()
+			if tc.modify != nil {
+				tc.modify(ce)
+			}
+			assert.ErrorIs(t, ce.PlayerAddon("t1", tc.joinPlayer), tc.err)
+			assert.Empty(t, ce.pendingAddons)
+			assert.Empty(t, backend.redeemed)
+		})
+	}
+}
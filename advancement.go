@@ -0,0 +1,217 @@
+package pokercompetition
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/pokertable"
+)
+
+type AdvancePlayer struct {
+	PlayerID       string `json:"player_id"`        // 玩家 ID
+	Rank           int    `json:"rank"`             // 晉級名次 (從 1 開始)
+	Chips          int64  `json:"chips"`            // 晉級時籌碼
+	IsTieBreak     bool   `json:"is_tie_break"`     // 是否為同時淘汰後依規則遞補晉級
+	HandStartChips int64  `json:"hand_start_chips"` // 同時淘汰時該手開始籌碼 (僅 IsTieBreak 時有值)
+}
+
+type advanceElimination struct {
+	playerID       string
+	handStartChips int64
+}
+
+/*
+handleAdvanceByPlayerCount 依晉級人數 (M 取 N) 處理桌次結算
+  - 存活人數接近晉級人數時進入逐手進行 (hand-for-hand): 每桌打完一手後暫停，所有桌次皆完成該手才一起開始下一手
+  - 單桌一手即跌破晉級人數時 (尚未逐手進行) 也進入逐手進行，等其他桌次打完進行中的一手後才結算，避免依結算先後決定晉級
+  - 存活人數達到晉級人數時於一輪結束後結束晉級，同一輪淘汰的玩家依該手開始籌碼決定遞補順序
+  - @return 是否結束賽事
+*/
+func (ce *competitionEngine) handleAdvanceByPlayerCount(ctx context.Context, table pokertable.Table, alivePlayerIDs, zeroChipPlayerIDs []string) bool {
+	advanceState := ce.competition.State.AdvanceState
+	finalAdvancePlayerCount := ce.competition.Meta.AdvanceSetting.PlayerCount
+
+	// 記錄本手淘汰玩家
+	eliminations := make([]advanceElimination, 0, len(zeroChipPlayerIDs))
+	for _, playerID := range zeroChipPlayerIDs {
+		eliminations = append(eliminations, advanceElimination{
+			playerID:       playerID,
+			handStartChips: handStartChips(table, playerID),
+		})
+	}
+
+	ce.handleMTTTableSettlementNextStep(ctx, table, alivePlayerIDs, zeroChipPlayerIDs)
+
+	if !advanceState.IsHandForHand {
+		if ce.competition.PlayingPlayerCount()-finalAdvancePlayerCount > ce.handForHandPlayerCount() {
+			return false
+		}
+
+		// 其他桌次進行中的一手與本手同時進行，視為同一輪
+		advanceState.IsHandForHand = true
+		advanceState.HandForHandRound = 1
+		advanceState.HandForHandTableIDs = make([]string, 0)
+		ce.advanceRoundEliminations = make([]advanceElimination, 0)
+		ce.emitEvent("Advance -> Hand For Hand Started", "")
+		ce.emitCompetitionStateEvent(CompetitionStateEvent_HandForHandStarted)
+	}
+	ce.advanceRoundEliminations = append(ce.advanceRoundEliminations, eliminations...)
+
+	// 逐手進行: 本桌完成本輪，暫停等待其他桌次
+	if !funk.ContainsString(advanceState.HandForHandTableIDs, table.ID) {
+		advanceState.HandForHandTableIDs = append(advanceState.HandForHandTableIDs, table.ID)
+	}
+	if ce.competition.IsTableExist(table.ID) {
+		if err := ce.backend(ctx).PauseTable(table.ID); err != nil {
+			ce.emitErrorEvent(fmt.Sprintf("[%s][%d] Hand For Hand Pause Table", table.ID, table.State.GameCount), "", err)
+		}
+	}
+
+	if !ce.isHandForHandRoundCompleted() {
+		return false
+	}
+
+	// 本輪結束: 達到晉級人數則結束晉級，否則開始下一輪
+	if ce.competition.PlayingPlayerCount() <= finalAdvancePlayerCount {
+		ce.settleAdvancePlayers(ce.advanceRoundEliminations)
+		return true
+	}
+
+	advanceState.HandForHandRound++
+	advanceState.HandForHandTableIDs = make([]string, 0)
+	ce.advanceRoundEliminations = make([]advanceElimination, 0)
+	ce.resumeHandForHandTables(ctx)
+	return false
+}
+
+/*
+handForHandPlayerCount 距離晉級人數剩幾人時開始逐手進行
+  - 未設定時以每桌座位數為準 (單桌一手最多淘汰的人數)
+*/
+func (ce *competitionEngine) handForHandPlayerCount() int {
+	if count := ce.competition.Meta.AdvanceSetting.HandForHandPlayerCount; count > 0 {
+		return count
+	}
+	return ce.competition.Meta.TableMaxSeatCount
+}
+
+// isHandForHandRoundCompleted 所有可開局的桌次皆完成本輪
+func (ce *competitionEngine) isHandForHandRoundCompleted() bool {
+	for _, t := range ce.competition.State.Tables {
+		if funk.ContainsString(ce.competition.State.AdvanceState.HandForHandTableIDs, t.ID) {
+			continue
+		}
+		if len(t.AlivePlayers()) < ce.competition.Meta.TableMinPlayerCount {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// resumeHandForHandTables 所有桌次一起開始下一手 (中場休息時由休息結束後開局)
+func (ce *competitionEngine) resumeHandForHandTables(ctx context.Context) {
+	if ce.competition.IsBreaking() {
+		return
+	}
+
+	for _, t := range ce.competition.State.Tables {
+		participants := ce.generateAliveParticipants(t.State.PlayerStates)
+		if len(participants) < ce.competition.Meta.TableMinPlayerCount {
+			continue
+		}
+
+		if t.State.GameCount > 0 {
+			if err := ce.backend(ctx).SetUpTableGame(t.ID, t.State.GameCount+1, participants); err != nil {
+				ce.emitErrorEvent("Hand For Hand -> Resume Table Game", "", err)
+			}
+		} else if err := ce.backend(ctx).StartTableGame(t.ID); err != nil {
+			ce.emitErrorEvent("Hand For Hand -> Start Table Game", "", err)
+		}
+	}
+}
+
+/*
+settleAdvancePlayers 決定晉級名單並產生晉級結果
+  - 存活玩家依籌碼由多到少排序
+  - 晉級人數 (M 取 N): 存活人數不足晉級人數時，由同時淘汰的玩家遞補: 該手開始籌碼多者優先，相同時抽籤決定
+  - 盲注等級: 所有存活玩家晉級
+*/
+func (ce *competitionEngine) settleAdvancePlayers(eliminations []advanceElimination) {
+	survivors := make([]*CompetitionPlayer, 0)
+	for _, cp := range ce.competition.State.Players {
+		if cp.Chips > 0 {
+			survivors = append(survivors, cp)
+		}
+	}
+	sort.SliceStable(survivors, func(i, j int) bool {
+		return survivors[i].Chips > survivors[j].Chips
+	})
+
+	finalAdvancePlayerCount := len(survivors)
+	if ce.competition.Meta.AdvanceSetting.Rule == CompetitionAdvanceRule_PlayerCount {
+		finalAdvancePlayerCount = ce.competition.Meta.AdvanceSetting.PlayerCount
+	}
+
+	advancePlayers := make([]*AdvancePlayer, 0, finalAdvancePlayerCount)
+	for _, cp := range survivors {
+		advancePlayers = append(advancePlayers, &AdvancePlayer{
+			PlayerID: cp.PlayerID,
+			Rank:     len(advancePlayers) + 1,
+			Chips:    cp.Chips,
+		})
+	}
+
+	if len(advancePlayers) < finalAdvancePlayerCount {
+		tieBreakers := make([]advanceElimination, len(eliminations))
+		copy(tieBreakers, eliminations)
+		rand.Shuffle(len(tieBreakers), func(i, j int) {
+			tieBreakers[i], tieBreakers[j] = tieBreakers[j], tieBreakers[i]
+		})
+		sort.SliceStable(tieBreakers, func(i, j int) bool {
+			return tieBreakers[i].handStartChips > tieBreakers[j].handStartChips
+		})
+
+		for _, e := range tieBreakers {
+			if len(advancePlayers) >= finalAdvancePlayerCount {
+				break
+			}
+			advancePlayers = append(advancePlayers, &AdvancePlayer{
+				PlayerID:       e.playerID,
+				Rank:           len(advancePlayers) + 1,
+				Chips:          0,
+				IsTieBreak:     true,
+				HandStartChips: e.handStartChips,
+			})
+		}
+	}
+
+	advanceState := ce.competition.State.AdvanceState
+	advanceState.AdvancePlayers = advancePlayers
+	advanceState.IsHandForHand = false
+	advanceState.Status = CompetitionAdvanceStatus_End
+	ce.advanceRoundEliminations = make([]advanceElimination, 0)
+
+	result := ce.newAdvancementResult()
+	ce.competition.State.AdvancementResult = result
+
+	ce.emitEvent("Advance -> Advance Players Settled", "")
+	ce.emitCompetitionStateEvent(CompetitionStateEvent_AdvancePlayersSettled)
+	ce.onAdvancementResultCreated(result)
+}
+
+// handStartChips 玩家該手開始時的籌碼
+func handStartChips(table pokertable.Table, playerID string) int64 {
+	if table.State.GameState == nil {
+		return 0
+	}
+
+	gamePlayerIdx := table.FindGamePlayerIdx(playerID)
+	if gamePlayerIdx < 0 || gamePlayerIdx >= len(table.State.GameState.Players) {
+		return 0
+	}
+	return table.State.GameState.Players[gamePlayerIdx].Bankroll
+}
@@ -0,0 +1,224 @@
+package pokercompetition
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AdvancementResult struct {
+	ID              string                 `json:"id"`              // 晉級結果 ID
+	CompetitionID   string                 `json:"competition_id"`  // 賽事 ID
+	Rule            CompetitionAdvanceRule `json:"rule"`            // 晉級方式
+	BlindLevelIndex int                    `json:"blind_level_idx"` // 晉級時盲注等級索引值
+	BlindLevel      BlindLevel             `json:"blind_level"`     // 晉級時盲注等級
+	MinChipUnit     int64                  `json:"min_chip_unit"`   // 晉級時最小單位籌碼量
+	Players         []*AdvancementPlayer   `json:"players"`         // 晉級玩家 (依名次排序)
+	CreatedAt       int64                  `json:"created_at"`      // 產生時間 (Seconds)
+}
+
+type AdvancementPlayer struct {
+	PlayerID    string          `json:"player_id"`    // 玩家 ID
+	Rank        int             `json:"rank"`         // 晉級名次
+	Chips       int64           `json:"chips"`        // 晉級時籌碼
+	IsTieBreak  bool            `json:"is_tie_break"` // 是否為同時淘汰後遞補晉級
+	TableID     string          `json:"table_id"`     // 晉級時桌次 ID
+	TableNumber int             `json:"table_number"` // 晉級時桌號
+	Seat        int             `json:"seat"`         // 晉級時座位
+	Seating     SeatingMetadata `json:"seating"`      // 座位限制資訊
+}
+
+type CarryOverSetting struct {
+	TargetAverageStack int64 `json:"target_average_stack"` // 籌碼正規化: 依比例調整至平均籌碼 (0 表示沿用晉級籌碼)
+	TieBreakStack      int64 `json:"tie_break_stack"`      // 遞補晉級 (晉級時籌碼為 0) 玩家的籌碼 (0 表示使用最小單位籌碼量)
+	KeepSeats          bool  `json:"keep_seats"`           // 是否沿用晉級時桌號與座位 (無法使用時由拆併桌安排並記錄違反)
+}
+
+/*
+newAdvancementResult 依晉級名單產生晉級結果
+  - 適用時機: 晉級狀態結束
+*/
+func (ce *competitionEngine) newAdvancementResult() *AdvancementResult {
+	result := &AdvancementResult{
+		ID:              uuid.New().String(),
+		CompetitionID:   ce.competition.ID,
+		Rule:            ce.competition.Meta.AdvanceSetting.Rule,
+		BlindLevelIndex: ce.competition.State.BlindState.CurrentLevelIndex,
+		BlindLevel:      ce.competition.CurrentBlindLevel(),
+		MinChipUnit:     ce.competition.CurrentMinChipUnit(),
+		Players:         make([]*AdvancementPlayer, 0, len(ce.competition.State.AdvanceState.AdvancePlayers)),
+		CreatedAt:       time.Now().Unix(),
+	}
+
+	for _, ap := range ce.competition.State.AdvanceState.AdvancePlayers {
+		player := &AdvancementPlayer{
+			PlayerID:    ap.PlayerID,
+			Rank:        ap.Rank,
+			Chips:       ap.Chips,
+			IsTieBreak:  ap.IsTieBreak,
+			TableID:     "",
+			TableNumber: UnsetValue,
+			Seat:        UnsetValue,
+		}
+		if cp := ce.competition.findPlayer(ap.PlayerID); cp != nil {
+			player.TableID = cp.CurrentTableID
+			player.Seat = cp.CurrentSeat
+			player.Seating = cp.Seating
+			if info := ce.competition.FindTableInfo(cp.CurrentTableID); info != nil {
+				player.TableNumber = info.TableNumber
+			}
+		}
+		result.Players = append(result.Players, player)
+	}
+
+	return result
+}
+
+/*
+NewCarryOverJoinPlayers 將多個晉級結果轉換成下一階段賽事的買入資料
+  - 同一玩家出現在多個晉級結果時取籌碼較多者
+  - 籌碼正規化後取整至最小單位籌碼量 (至少一個單位)
+  - 沿用座位時指定晉級時桌號與座位，超過下一階段每桌座位數的座位則不指定
+  - 合併多個晉級結果時不同場次的相同桌號視為同一桌
+*/
+func NewCarryOverJoinPlayers(results []*AdvancementResult, setting CarryOverSetting, meta CompetitionMeta) []JoinPlayer {
+	minChipUnit := meta.MinChipUnit
+	if minChipUnit <= 0 {
+		minChipUnit = 1
+	}
+
+	players := make(map[string]*AdvancementPlayer)
+	for _, result := range results {
+		for _, p := range result.Players {
+			if existing, ok := players[p.PlayerID]; ok && existing.Chips >= p.Chips {
+				continue
+			}
+			players[p.PlayerID] = p
+		}
+	}
+
+	playerIDs := make([]string, 0, len(players))
+	totalChips := int64(0)
+	for playerID, p := range players {
+		playerIDs = append(playerIDs, playerID)
+		totalChips += p.Chips
+	}
+	sort.SliceStable(playerIDs, func(i, j int) bool {
+		a, b := players[playerIDs[i]], players[playerIDs[j]]
+		if a.Chips != b.Chips {
+			return a.Chips > b.Chips
+		}
+		return a.PlayerID < b.PlayerID
+	})
+
+	ratio := float64(1)
+	if setting.TargetAverageStack > 0 && totalChips > 0 {
+		ratio = float64(setting.TargetAverageStack) * float64(len(players)) / float64(totalChips)
+	}
+
+	joinPlayers := make([]JoinPlayer, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		p := players[playerID]
+
+		chips := p.Chips
+		if chips <= 0 {
+			chips = setting.TieBreakStack
+		} else if ratio != 1 {
+			chips = int64(math.Round(float64(chips)*ratio/float64(minChipUnit))) * minChipUnit
+		}
+		if chips < minChipUnit {
+			chips = minChipUnit
+		}
+
+		seating := p.Seating
+		seating.IsSeatPinned = false
+		seating.PinnedSeat = 0
+		seating.IsTablePinned = false
+		seating.PinnedTableNumber = 0
+		if setting.KeepSeats && p.TableNumber > 0 {
+			seating.IsTablePinned = true
+			seating.PinnedTableNumber = p.TableNumber
+		}
+		if setting.KeepSeats && p.Seat >= 0 && p.Seat < meta.TableMaxSeatCount {
+			seating.IsSeatPinned = true
+			seating.PinnedSeat = p.Seat
+		}
+
+		joinPlayers = append(joinPlayers, JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: chips,
+			Unit:        0,
+			Seating:     seating,
+		})
+	}
+	return joinPlayers
+}
+
+/*
+ValidateCarryOverJoinPlayers 驗證晉級玩家能否報名下一階段賽事
+  - 適用賽事: CT、MTT (CT 人數不可超過每桌座位數)
+  - 晉級玩家不受 MTT 人數上限與候補名單限制
+*/
+func ValidateCarryOverJoinPlayers(joinPlayers []JoinPlayer, meta CompetitionMeta) error {
+	if meta.Mode != CompetitionMode_CT && meta.Mode != CompetitionMode_MTT {
+		return ErrCompetitionCarryOverRejected
+	}
+
+	if len(joinPlayers) == 0 || (meta.Mode == CompetitionMode_CT && len(joinPlayers) > meta.TableMaxSeatCount) {
+		return ErrCompetitionCarryOverRejected
+	}
+
+	playerIDs := make(map[string]bool)
+	for _, joinPlayer := range joinPlayers {
+		if playerIDs[joinPlayer.PlayerID] {
+			return ErrCompetitionCarryOverRejected
+		}
+		playerIDs[joinPlayer.PlayerID] = true
+
+		if joinPlayer.RedeemChips <= 0 {
+			return ErrCompetitionNoRedeemChips
+		}
+		if joinPlayer.Seating.IsSeatPinned && (joinPlayer.Seating.PinnedSeat < 0 || joinPlayer.Seating.PinnedSeat >= meta.TableMaxSeatCount) {
+			return ErrCompetitionInvalidPinnedSeat
+		}
+		if joinPlayer.Seating.IsTablePinned && joinPlayer.Seating.PinnedTableNumber <= 0 {
+			return ErrCompetitionInvalidPinnedTable
+		}
+	}
+	return nil
+}
+
+/*
+PlayersCarryOver 晉級玩家報名下一階段賽事
+  - 適用時機: 開賽前 (報名中)
+  - 先驗證所有玩家，任一玩家無法報名時不報名任何玩家
+*/
+func (ce *competitionEngine) PlayersCarryOver(joinPlayers []JoinPlayer) error {
+	return ce.playersCarryOverContext(context.Background(), joinPlayers)
+}
+
+func (ce *competitionEngine) playersCarryOverContext(ctx context.Context, joinPlayers []JoinPlayer) error {
+	if ce.competition.State.Status != CompetitionStateStatus_Registering {
+		return ErrCompetitionCarryOverRejected
+	}
+
+	if err := ValidateCarryOverJoinPlayers(joinPlayers, ce.competition.Meta); err != nil {
+		return err
+	}
+
+	for _, joinPlayer := range joinPlayers {
+		if ce.competition.FindPlayerIdx(func(cp *CompetitionPlayer) bool { return cp.PlayerID == joinPlayer.PlayerID }) != UnsetValue {
+			return ErrCompetitionCarryOverRejected
+		}
+	}
+
+	for _, joinPlayer := range joinPlayers {
+		if err := ce.playerBuyIn(ctx, joinPlayer, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
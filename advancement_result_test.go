@@ -0,0 +1,152 @@
+package pokercompetition
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCarryOverTestManager(players ...*AdvancementPlayer) (*manager, string) {
+	m := NewManager(NewNativeTableManagerBackend(NewTableManager())).(*manager)
+	result := &AdvancementResult{ID: "r1", CompetitionID: "c1", Players: players}
+	m.advancementResults.Store(result.ID, result)
+	return m, result.ID
+}
+
+func newCarryOverTestSetting() CompetitionSetting {
+	setting := newValidCompetitionSetting()
+	setting.CompetitionID = "c2"
+	setting.Meta.MaxPlayerCount = 2
+	return setting
+}
+
+func competitionEngineCount(m *manager) int {
+	count := 0
+	m.competitionEngines.Range(func(key, value interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+func Test_CarryOver_BypassesPlayerCountCap(t *testing.T) {
+	m, resultID := newCarryOverTestManager(
+		&AdvancementPlayer{PlayerID: "p1", Rank: 1, Chips: 3000, TableNumber: 1, Seat: 2},
+		&AdvancementPlayer{PlayerID: "p2", Rank: 2, Chips: 2000, TableNumber: 1, Seat: 5},
+		&AdvancementPlayer{PlayerID: "p3", Rank: 3, Chips: 1000, TableNumber: 2, Seat: 0},
+	)
+
+	competition, err := m.CreateCompetitionFromAdvancement([]string{resultID}, newCarryOverTestSetting(), CarryOverSetting{KeepSeats: true}, NewDefaultCompetitionEngineOptions())
+	assert.NoError(t, err)
+	if !assert.NotNil(t, competition) {
+		return
+	}
+	defer m.ReleaseCompetition(competition.ID)
+
+	assert.Len(t, competition.State.Players, 3, "carried over players should not be capped")
+	assert.Empty(t, competition.State.Alternates)
+
+	for _, cp := range competition.State.Players {
+		assert.True(t, cp.Seating.IsTablePinned, "table should be pinned (%s)", cp.PlayerID)
+		assert.True(t, cp.Seating.IsSeatPinned, "seat should be pinned (%s)", cp.PlayerID)
+	}
+	assert.Equal(t, 2, competition.State.Players[2].Seating.PinnedTableNumber)
+
+	// 新報名玩家仍受人數上限限制
+	assert.NoError(t, m.PlayerBuyIn(competition.ID, JoinPlayer{PlayerID: "p4", RedeemChips: 1000, Unit: 1}))
+	position, err := m.GetAlternatePosition(competition.ID, "p4")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, position)
+}
+
+func Test_CarryOver_ValidatesBeforeCreate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		modify  func(s *CompetitionSetting)
+		players []*AdvancementPlayer
+		err     error
+	}{
+		{
+			name:    "invalid setting",
+			modify:  func(s *CompetitionSetting) { s.Meta.TableMaxSeatCount = 0 },
+			players: []*AdvancementPlayer{{PlayerID: "p1", Chips: 1000}},
+			err:     ErrCompetitionInvalidCreateSetting,
+		},
+		{
+			name:    "unsupported mode",
+			modify:  func(s *CompetitionSetting) { s.Meta.Mode = CompetitionMode_Cash },
+			players: []*AdvancementPlayer{{PlayerID: "p1", Chips: 1000}},
+			err:     ErrCompetitionCarryOverRejected,
+		},
+		{
+			name:    "no players",
+			modify:  func(s *CompetitionSetting) {},
+			players: []*AdvancementPlayer{},
+			err:     ErrCompetitionCarryOverRejected,
+		},
+		{
+			name: "ct over table seats",
+			modify: func(s *CompetitionSetting) {
+				s.Meta.Mode, s.Meta.TableMaxSeatCount, s.Meta.TableMinPlayerCount = CompetitionMode_CT, 2, 2
+			},
+			players: []*AdvancementPlayer{{PlayerID: "p1", Chips: 1000}, {PlayerID: "p2", Chips: 1000}, {PlayerID: "p3", Chips: 1000}},
+			err:     ErrCompetitionCarryOverRejected,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, resultID := newCarryOverTestManager(tc.players...)
+			setting := newCarryOverTestSetting()
+			tc.modify(&setting)
+
+			competition, err := m.CreateCompetitionFromAdvancement([]string{resultID}, setting, CarryOverSetting{}, NewDefaultCompetitionEngineOptions())
+			assert.True(t, errors.Is(err, tc.err), "unexpected error: %v", err)
+			assert.Nil(t, competition)
+			assert.Zero(t, competitionEngineCount(m), "competition should not be created")
+		})
+	}
+}
+
+func Test_CarryOver_ResultNotFound(t *testing.T) {
+	m, _ := newCarryOverTestManager()
+
+	competition, err := m.CreateCompetitionFromAdvancement([]string{"unknown"}, newCarryOverTestSetting(), CarryOverSetting{}, NewDefaultCompetitionEngineOptions())
+	assert.ErrorIs(t, err, ErrManagerAdvancementResultNotFound)
+	assert.Nil(t, competition)
+
+	competition, err = m.CreateCompetitionFromAdvancement([]string{}, newCarryOverTestSetting(), CarryOverSetting{}, NewDefaultCompetitionEngineOptions())
+	assert.ErrorIs(t, err, ErrManagerAdvancementResultNotFound)
+	assert.Nil(t, competition)
+	assert.Zero(t, competitionEngineCount(m))
+}
+
+func Test_CarryOver_RejectsAllWhenAnyPlayerExists(t *testing.T) {
+	ce := newTestCompetitionEngine(
+		newTestCompetitionPlayer("p2", 1000, CompetitionPlayerStatus_WaitingTableBalancing),
+	)
+
+	err := ce.PlayersCarryOver([]JoinPlayer{
+		{PlayerID: "p1", RedeemChips: 1000},
+		{PlayerID: "p2", RedeemChips: 1000},
+	})
+	assert.ErrorIs(t, err, ErrCompetitionCarryOverRejected)
+	assert.Len(t, ce.competition.State.Players, 1, "no player should be carried over")
+}
+
+func Test_CarryOver_KeepSeats(t *testing.T) {
+	results := []*AdvancementResult{{
+		Players: []*AdvancementPlayer{
+			{PlayerID: "p1", Chips: 1000, TableNumber: 3, Seat: 8},
+			{PlayerID: "p2", Chips: 900, TableNumber: UnsetValue, Seat: UnsetValue},
+		},
+	}}
+
+	joinPlayers := NewCarryOverJoinPlayers(results, CarryOverSetting{KeepSeats: true}, CompetitionMeta{MinChipUnit: 10, TableMaxSeatCount: 6})
+	assert.Equal(t, SeatingMetadata{IsTablePinned: true, PinnedTableNumber: 3}, joinPlayers[0].Seating, "seat out of range should not be pinned")
+	assert.Equal(t, SeatingMetadata{}, joinPlayers[1].Seating)
+
+	joinPlayers = NewCarryOverJoinPlayers(results, CarryOverSetting{}, CompetitionMeta{MinChipUnit: 10, TableMaxSeatCount: 9})
+	assert.Equal(t, SeatingMetadata{}, joinPlayers[0].Seating)
+}
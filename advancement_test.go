@@ -0,0 +1,187 @@
+package pokercompetition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	pokerbalancing "github.com/weedbox/pokercompetition/balancing"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokertable"
+)
+
+type advanceTestPlayer struct {
+	playerID   string
+	startChips int64 // 該手開始籌碼
+	chips      int64 // 該手結算後籌碼
+}
+
+func newAdvanceTestEngine(advancePlayerCount int) *competitionEngine {
+	ce := NewCompetitionEngine(
+		WithTableManagerBackend(NewNativeTableManagerBackend(NewTableManager())),
+	).(*competitionEngine)
+	ce.balancingStrategy = NewBalancingStrategy("")
+	ce.regulator = pokerbalancing.NewRegulator(ce.balancingStrategy)
+	ce.competition = &Competition{
+		ID: "c1",
+		Meta: CompetitionMeta{
+			Mode:                CompetitionMode_MTT,
+			TableMaxSeatCount:   3,
+			TableMinPlayerCount: 2,
+			MinChipUnit:         10,
+			Blind: Blind{
+				Levels: []BlindLevel{{Level: 1, SB: 10, BB: 20, Duration: 60}},
+			},
+			AdvanceSetting: AdvanceSetting{
+				Rule:        CompetitionAdvanceRule_PlayerCount,
+				PlayerCount: advancePlayerCount,
+			},
+		},
+		State: &CompetitionState{
+			Status:       CompetitionStateStatus_StoppedBuyIn,
+			BlindState:   &BlindState{CurrentLevelIndex: 0},
+			AdvanceState: &AdvanceState{Status: CompetitionAdvanceStatus_Updating},
+			Statistic:    &Statistic{},
+		},
+	}
+	return ce
+}
+
+// addAdvanceTestTable 加入桌次與玩家 (玩家籌碼為該手開始籌碼)
+func addAdvanceTestTable(ce *competitionEngine, tableID string, players ...advanceTestPlayer) {
+	table := &pokertable.Table{ID: tableID, State: &pokertable.TableState{GameCount: 1}}
+	for _, p := range players {
+		table.State.PlayerStates = append(table.State.PlayerStates, &pokertable.TablePlayerState{PlayerID: p.playerID, Bankroll: p.startChips})
+		ce.competition.State.Players = append(ce.competition.State.Players, &CompetitionPlayer{
+			PlayerID:       p.playerID,
+			CurrentTableID: tableID,
+			CurrentSeat:    UnsetValue,
+			Chips:          p.startChips,
+			Status:         CompetitionPlayerStatus_Playing,
+		})
+	}
+	ce.competition.State.Tables = append(ce.competition.State.Tables, table)
+}
+
+// settleAdvanceTestTable 模擬桌次結算: 更新玩家籌碼並交由晉級計算處理
+func settleAdvanceTestTable(ce *competitionEngine, tableID string, players ...advanceTestPlayer) bool {
+	table := pokertable.Table{ID: tableID, State: &pokertable.TableState{GameCount: 1, GameState: &pokerface.GameState{}}}
+	alivePlayerIDs := make([]string, 0)
+	zeroChipPlayerIDs := make([]string, 0)
+	for idx, p := range players {
+		table.State.PlayerStates = append(table.State.PlayerStates, &pokertable.TablePlayerState{PlayerID: p.playerID, Bankroll: p.chips})
+		table.State.GamePlayerIndexes = append(table.State.GamePlayerIndexes, idx)
+		table.State.GameState.Players = append(table.State.GameState.Players, &pokerface.PlayerState{Idx: idx, Bankroll: p.startChips})
+		if cp := ce.competition.findPlayer(p.playerID); cp != nil {
+			cp.Chips = p.chips
+		}
+		if p.chips > 0 {
+			alivePlayerIDs = append(alivePlayerIDs, p.playerID)
+		} else {
+			zeroChipPlayerIDs = append(zeroChipPlayerIDs, p.playerID)
+		}
+	}
+
+	tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool { return t.ID == tableID })
+	ce.competition.State.Tables[tableIdx].State.PlayerStates = table.State.PlayerStates
+	return ce.handleAdvanceByPlayerCount(context.Background(), table, alivePlayerIDs, zeroChipPlayerIDs)
+}
+
+func advancePlayerIDs(advancePlayers []*AdvancePlayer) []string {
+	playerIDs := make([]string, 0, len(advancePlayers))
+	for _, ap := range advancePlayers {
+		playerIDs = append(playerIDs, ap.PlayerID)
+	}
+	return playerIDs
+}
+
+func Test_Advancement_HandForHandStartsWithinTableSeats(t *testing.T) {
+	ce := newAdvanceTestEngine(2)
+	addAdvanceTestTable(ce, "t1", advanceTestPlayer{"a1", 100, 0}, advanceTestPlayer{"a2", 100, 0}, advanceTestPlayer{"a3", 100, 0})
+	addAdvanceTestTable(ce, "t2", advanceTestPlayer{"b1", 100, 0}, advanceTestPlayer{"b2", 100, 0}, advanceTestPlayer{"b3", 100, 0})
+
+	// 存活 6 人，距離晉級人數 4 人 > 每桌座位數 3，不逐手進行
+	assert.False(t, settleAdvanceTestTable(ce, "t1", advanceTestPlayer{"a1", 100, 100}, advanceTestPlayer{"a2", 100, 100}, advanceTestPlayer{"a3", 100, 100}))
+	assert.False(t, ce.competition.State.AdvanceState.IsHandForHand)
+
+	// 存活 5 人，距離晉級人數 3 人，開始逐手進行
+	assert.False(t, settleAdvanceTestTable(ce, "t1", advanceTestPlayer{"a1", 100, 200}, advanceTestPlayer{"a2", 100, 100}, advanceTestPlayer{"a3", 100, 0}))
+	assert.True(t, ce.competition.State.AdvanceState.IsHandForHand)
+	assert.Equal(t, 1, ce.competition.State.AdvanceState.HandForHandRound)
+	assert.Equal(t, []string{"t1"}, ce.competition.State.AdvanceState.HandForHandTableIDs)
+}
+
+func Test_Advancement_SettleAtSynchronizedPoint(t *testing.T) {
+	ce := newAdvanceTestEngine(4)
+	ce.competition.Meta.AdvanceSetting.HandForHandPlayerCount = 1
+	addAdvanceTestTable(ce, "t1", advanceTestPlayer{"a1", 100, 0}, advanceTestPlayer{"a2", 100, 0}, advanceTestPlayer{"a3", 100, 0})
+	addAdvanceTestTable(ce, "t2", advanceTestPlayer{"b1", 100, 0}, advanceTestPlayer{"b2", 100, 0}, advanceTestPlayer{"b3", 100, 0})
+
+	// t1 一手淘汰 2 人即跌破晉級人數，等 t2 進行中的一手結束才結算
+	assert.False(t, settleAdvanceTestTable(ce, "t1", advanceTestPlayer{"a1", 100, 300}, advanceTestPlayer{"a2", 100, 0}, advanceTestPlayer{"a3", 100, 0}))
+	assert.True(t, ce.competition.State.AdvanceState.IsHandForHand)
+	assert.Nil(t, ce.competition.State.AdvancementResult)
+
+	// t2 同時淘汰的玩家 (該手開始籌碼較多) 一同參與遞補
+	assert.True(t, settleAdvanceTestTable(ce, "t2", advanceTestPlayer{"b1", 100, 300}, advanceTestPlayer{"b2", 200, 0}, advanceTestPlayer{"b3", 100, 100}))
+
+	advanceState := ce.competition.State.AdvanceState
+	assert.Equal(t, CompetitionAdvanceStatus_End, advanceState.Status)
+	assert.False(t, advanceState.IsHandForHand)
+	assert.Equal(t, []string{"a1", "b1", "b3", "b2"}, advancePlayerIDs(advanceState.AdvancePlayers))
+	assert.NotNil(t, ce.competition.State.AdvancementResult)
+}
+
+func Test_Advancement_TieBreakByHandStartChips(t *testing.T) {
+	ce := newAdvanceTestEngine(4)
+	addAdvanceTestTable(ce, "t1", advanceTestPlayer{"a1", 100, 0}, advanceTestPlayer{"a2", 100, 0}, advanceTestPlayer{"a3", 100, 0})
+	addAdvanceTestTable(ce, "t2", advanceTestPlayer{"b1", 100, 0}, advanceTestPlayer{"b2", 100, 0}, advanceTestPlayer{"b3", 100, 0})
+
+	// 存活 2 人，4 人同輪淘汰遞補 2 個名額
+	assert.False(t, settleAdvanceTestTable(ce, "t1", advanceTestPlayer{"a1", 100, 400}, advanceTestPlayer{"a2", 500, 0}, advanceTestPlayer{"a3", 300, 0}))
+	assert.True(t, settleAdvanceTestTable(ce, "t2", advanceTestPlayer{"b1", 100, 500}, advanceTestPlayer{"b2", 100, 0}, advanceTestPlayer{"b3", 300, 0}))
+
+	advancePlayers := ce.competition.State.AdvanceState.AdvancePlayers
+	assert.Len(t, advancePlayers, 4)
+	assert.Equal(t, []string{"b1", "a1", "a2"}, advancePlayerIDs(advancePlayers[:3]), "survivors by chips, then the largest hand start stack")
+	assert.Contains(t, []string{"a3", "b3"}, advancePlayers[3].PlayerID, "equal hand start stacks should be drawn")
+
+	for idx, ap := range advancePlayers {
+		assert.Equal(t, idx+1, ap.Rank)
+		assert.Equal(t, idx >= 2, ap.IsTieBreak)
+	}
+	assert.Equal(t, int64(500), advancePlayers[2].HandStartChips)
+	assert.Equal(t, int64(300), advancePlayers[3].HandStartChips)
+}
+
+func Test_Advancement_CarryOverTieBreakStack(t *testing.T) {
+	results := []*AdvancementResult{
+		{
+			Players: []*AdvancementPlayer{
+				{PlayerID: "p1", Rank: 1, Chips: 1000, Seat: 2},
+				{PlayerID: "p2", Rank: 2, Chips: 0, IsTieBreak: true, Seat: 5},
+			},
+		},
+	}
+	meta := CompetitionMeta{MinChipUnit: 25, TableMaxSeatCount: 9}
+
+	testCases := []struct {
+		name          string
+		tieBreakStack int64
+		chips         int64
+	}{
+		{name: "tie break stack", tieBreakStack: 300, chips: 300},
+		{name: "min chip unit when unset", tieBreakStack: 0, chips: 25},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			joinPlayers := NewCarryOverJoinPlayers(results, CarryOverSetting{TieBreakStack: tc.tieBreakStack}, meta)
+			assert.Len(t, joinPlayers, 2)
+			assert.Equal(t, "p1", joinPlayers[0].PlayerID)
+			assert.Equal(t, int64(1000), joinPlayers[0].RedeemChips)
+			assert.Equal(t, "p2", joinPlayers[1].PlayerID)
+			assert.Equal(t, tc.chips, joinPlayers[1].RedeemChips)
+		})
+	}
+}
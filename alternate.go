@@ -0,0 +1,193 @@
+package pokercompetition
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thoas/go-funk"
+)
+
+type CompetitionAlternateStatus string
+
+const (
+	CompetitionAlternateStatus_Waiting   CompetitionAlternateStatus = "waiting"   // 候補中
+	CompetitionAlternateStatus_Seated    CompetitionAlternateStatus = "seated"    // 已遞補參賽
+	CompetitionAlternateStatus_Withdrawn CompetitionAlternateStatus = "withdrawn" // 取消候補
+	CompetitionAlternateStatus_Expired   CompetitionAlternateStatus = "expired"   // 報名截止未遞補
+)
+
+type CompetitionAlternate struct {
+	PlayerID   string                     `json:"player_id"`   // 玩家 ID
+	JoinPlayer JoinPlayer                 `json:"join_player"` // 遞補時的買入資料
+	Status     CompetitionAlternateStatus `json:"status"`      // 候補狀態
+	QueuedAt   int64                      `json:"queued_at"`   // 加入候補時間 (Seconds)
+	UpdatedAt  int64                      `json:"updated_at"`  // 狀態更新時間 (Seconds)
+}
+
+/*
+AlternatePosition 取得玩家候補順位
+  - @return 順位 (從 1 開始)，不在候補名單中時為 UnsetValue
+*/
+func (c Competition) AlternatePosition(playerID string) int {
+	position := 0
+	for _, alternate := range c.State.Alternates {
+		if alternate.Status != CompetitionAlternateStatus_Waiting {
+			continue
+		}
+		position++
+		if alternate.PlayerID == playerID {
+			return position
+		}
+	}
+	return UnsetValue
+}
+
+func (c Competition) WaitingAlternateCount() int {
+	count := 0
+	for _, alternate := range c.State.Alternates {
+		if alternate.Status == CompetitionAlternateStatus_Waiting {
+			count++
+		}
+	}
+	return count
+}
+
+/*
+LiveEntryCount 仍佔參賽名額的玩家人數
+  - 有籌碼的玩家與等待補碼中的玩家 (補碼期限內仍可回到賽事)
+  - 淘汰、退賽才會釋出名額
+*/
+func (c Competition) LiveEntryCount() int {
+	return len(funk.Filter(c.State.Players, func(player *CompetitionPlayer) bool {
+		return player.Chips > 0 || player.Status == CompetitionPlayerStatus_ReBuyWaiting
+	}).([]*CompetitionPlayer))
+}
+
+/*
+IsPlayerCountFull MTT 參賽人數是否已達上限
+  - 人數以仍佔參賽名額的玩家計算 (見 LiveEntryCount)
+  - MaxPlayerCount <= 0 表示不限人數
+*/
+func (c Competition) IsPlayerCountFull() bool {
+	if c.Meta.Mode != CompetitionMode_MTT || c.Meta.MaxPlayerCount <= 0 {
+		return false
+	}
+	return c.LiveEntryCount() >= c.Meta.MaxPlayerCount
+}
+
+/*
+addAlternate 將玩家加入候補名單 (已在候補名單中則不重複加入)
+*/
+func (ce *competitionEngine) addAlternate(joinPlayer JoinPlayer) {
+	ce.mu.Lock()
+	if ce.competition.AlternatePosition(joinPlayer.PlayerID) != UnsetValue {
+		ce.mu.Unlock()
+		return
+	}
+
+	now := time.Now().Unix()
+	ce.competition.State.Alternates = append(ce.competition.State.Alternates, &CompetitionAlternate{
+		PlayerID:   joinPlayer.PlayerID,
+		JoinPlayer: joinPlayer,
+		Status:     CompetitionAlternateStatus_Waiting,
+		QueuedAt:   now,
+		UpdatedAt:  now,
+	})
+	ce.mu.Unlock()
+
+	ce.emitEvent(fmt.Sprintf("PlayerBuyIn -> %s Alternate", joinPlayer.PlayerID), joinPlayer.PlayerID)
+	ce.emitCompetitionStateEvent(CompetitionStateEvent_AlternatesUpdated)
+}
+
+/*
+withdrawAlternate 玩家取消候補
+  - @return 玩家是否在候補名單中
+*/
+func (ce *competitionEngine) withdrawAlternate(playerID string) bool {
+	ce.mu.Lock()
+	var target *CompetitionAlternate
+	for _, alternate := range ce.competition.State.Alternates {
+		if alternate.PlayerID == playerID && alternate.Status == CompetitionAlternateStatus_Waiting {
+			target = alternate
+			break
+		}
+	}
+	if target == nil {
+		ce.mu.Unlock()
+		return false
+	}
+	target.Status = CompetitionAlternateStatus_Withdrawn
+	target.UpdatedAt = time.Now().Unix()
+	ce.mu.Unlock()
+
+	ce.emitEvent(fmt.Sprintf("PlayerRefund -> %s Withdraw Alternate", playerID), playerID)
+	ce.emitCompetitionStateEvent(CompetitionStateEvent_AlternatesUpdated)
+	return true
+}
+
+/*
+promoteAlternates 名額釋出時依序遞補候補玩家
+  - 適用時機: 玩家退賽、MTT 桌次結算後 (玩家淘汰)
+*/
+func (ce *competitionEngine) promoteAlternates(ctx context.Context) {
+	validStatuses := []CompetitionStateStatus{
+		CompetitionStateStatus_Registering,
+		CompetitionStateStatus_DelayedBuyIn,
+	}
+
+	for {
+		ce.mu.Lock()
+		if !funk.Contains(validStatuses, ce.competition.State.Status) || ce.competition.IsPlayerCountFull() {
+			ce.mu.Unlock()
+			return
+		}
+
+		var next *CompetitionAlternate
+		for _, alternate := range ce.competition.State.Alternates {
+			if alternate.Status == CompetitionAlternateStatus_Waiting {
+				next = alternate
+				break
+			}
+		}
+		if next == nil {
+			ce.mu.Unlock()
+			return
+		}
+		next.Status = CompetitionAlternateStatus_Seated
+		next.UpdatedAt = time.Now().Unix()
+		ce.mu.Unlock()
+
+		if err := ce.playerBuyIn(ctx, next.JoinPlayer, true); err != nil {
+			ce.mu.Lock()
+			next.Status = CompetitionAlternateStatus_Withdrawn
+			ce.mu.Unlock()
+			ce.emitErrorEvent("promoteAlternates -> PlayerBuyIn", next.PlayerID, err)
+			continue
+		}
+
+		ce.emitEvent(fmt.Sprintf("promoteAlternates -> %s Seated", next.PlayerID), next.PlayerID)
+		ce.emitCompetitionStateEvent(CompetitionStateEvent_AlternateSeated)
+	}
+}
+
+/*
+expireAlternates 報名截止時候補名單失效
+  - 適用時機: 停止買入
+*/
+func (ce *competitionEngine) expireAlternates() {
+	expiredCount := 0
+	now := time.Now().Unix()
+	for _, alternate := range ce.competition.State.Alternates {
+		if alternate.Status == CompetitionAlternateStatus_Waiting {
+			alternate.Status = CompetitionAlternateStatus_Expired
+			alternate.UpdatedAt = now
+			expiredCount++
+		}
+	}
+
+	if expiredCount > 0 {
+		ce.emitEvent(fmt.Sprintf("expireAlternates -> %d Expired", expiredCount), "")
+		ce.emitCompetitionStateEvent(CompetitionStateEvent_AlternatesExpired)
+	}
+}
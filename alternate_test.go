@@ -0,0 +1,77 @@
+package pokercompetition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAlternateTestEngine(maxPlayerCount int, players ...*CompetitionPlayer) *competitionEngine {
+	ce := newTestCompetitionEngine(players...)
+	ce.competition.Meta.MaxPlayerCount = maxPlayerCount
+	ce.competition.Meta.ReBuySetting = ReBuySetting{MaxTime: 3}
+	return ce
+}
+
+func Test_Alternate_LiveEntryCount(t *testing.T) {
+	ce := newAlternateTestEngine(3,
+		newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_Playing),
+		newTestCompetitionPlayer("p2", 0, CompetitionPlayerStatus_ReBuyWaiting),
+		newTestCompetitionPlayer("p3", 0, CompetitionPlayerStatus_Knockout),
+	)
+	assert.Equal(t, 2, ce.competition.LiveEntryCount(), "re-buy waiting player should keep the entry")
+	assert.False(t, ce.competition.IsPlayerCountFull())
+
+	ce.competition.State.Players = append(ce.competition.State.Players, newTestCompetitionPlayer("p4", 0, CompetitionPlayerStatus_ReBuyWaiting))
+	assert.True(t, ce.competition.IsPlayerCountFull(), "busted players waiting for re-buy should not free slots")
+
+	ce.competition.Meta.MaxPlayerCount = 0
+	assert.False(t, ce.competition.IsPlayerCountFull(), "zero max player count means unlimited")
+}
+
+func Test_Alternate_Waitlisted(t *testing.T) {
+	ce := newAlternateTestEngine(2,
+		newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_Playing),
+	)
+
+	assert.NoError(t, ce.PlayerBuyIn(JoinPlayer{PlayerID: "p2", RedeemChips: 1000, Unit: 1}))
+	_, err := ce.GetAlternatePosition("p2")
+	assert.ErrorIs(t, err, ErrCompetitionAlternateNotFound)
+
+	assert.NoError(t, ce.PlayerBuyIn(JoinPlayer{PlayerID: "p3", RedeemChips: 1000, Unit: 1}), "waitlisting should not be an error")
+	position, err := ce.GetAlternatePosition("p3")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, position)
+	assert.Nil(t, ce.competition.findPlayer("p3"))
+
+	// 候補中的玩家再次報名仍維持候補
+	assert.NoError(t, ce.PlayerBuyIn(JoinPlayer{PlayerID: "p3", RedeemChips: 1000, Unit: 1}))
+	assert.Equal(t, 1, ce.competition.WaitingAlternateCount())
+}
+
+func Test_Alternate_ReBuyWaitingHoldsSlot(t *testing.T) {
+	ce := newAlternateTestEngine(2,
+		newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_Playing),
+		newTestCompetitionPlayer("p2", 0, CompetitionPlayerStatus_ReBuyWaiting),
+	)
+
+	assert.NoError(t, ce.PlayerBuyIn(JoinPlayer{PlayerID: "p3", RedeemChips: 1000, Unit: 1}))
+	assert.Equal(t, 1, ce.competition.AlternatePosition("p3"), "slot of a re-buy waiting player should not be taken")
+
+	assert.NoError(t, ce.PlayerBuyIn(JoinPlayer{PlayerID: "p2", RedeemChips: 1000, Unit: 1}))
+	assert.Equal(t, int64(1000), ce.competition.findPlayer("p2").Chips)
+	assert.Equal(t, 2, ce.competition.LiveEntryCount())
+}
+
+func Test_Alternate_ReBuyRejectedWhenOverCap(t *testing.T) {
+	// 名額已被其他玩家占滿 (ex: 調整人數上限) 時不可補碼
+	ce := newAlternateTestEngine(2,
+		newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_Playing),
+		newTestCompetitionPlayer("p2", 1000, CompetitionPlayerStatus_Playing),
+		newTestCompetitionPlayer("p3", 0, CompetitionPlayerStatus_ReBuyWaiting),
+	)
+
+	err := ce.PlayerBuyIn(JoinPlayer{PlayerID: "p3", RedeemChips: 1000, Unit: 1})
+	assert.ErrorIs(t, err, ErrCompetitionReBuyPlayerCountFull)
+	assert.Equal(t, int64(0), ce.competition.State.Players[2].Chips)
+}
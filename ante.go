@@ -0,0 +1,105 @@
+package pokercompetition
+
+import (
+	"github.com/weedbox/pokerface"
+)
+
+/*
+GetAntePayment 計算玩家在前注階段應支付的籌碼量
+  - 一般前注: 每位玩家支付 Ante
+  - 大盲前注: 大盲位置支付全桌前注 (Ante x 本手玩家數)，其他玩家不需支付
+  - 按鈕前注: Dealer 位置支付全桌前注，其他玩家不需支付
+  - 支付量不超過玩家籌碼量
+*/
+func GetAntePayment(mode CompetitionAnteMode, gs *pokerface.GameState, playerIdx int) int64 {
+	player := gs.GetPlayer(playerIdx)
+	if player == nil || gs.Meta.Ante <= 0 {
+		return 0
+	}
+
+	ante := gs.Meta.Ante
+	switch mode {
+	case CompetitionAnteMode_BigBlind:
+		ante = tableAnte(gs, "bb", playerIdx)
+	case CompetitionAnteMode_Button:
+		ante = tableAnte(gs, "dealer", playerIdx)
+	}
+
+	if ante > player.StackSize {
+		return player.StackSize
+	}
+	return ante
+}
+
+// tableAnte 指定位置支付全桌前注，其他位置不需支付
+func tableAnte(gs *pokerface.GameState, position string, playerIdx int) int64 {
+	if !gs.HasPosition(playerIdx, position) {
+		return 0
+	}
+	return gs.Meta.Ante * int64(len(gs.Players))
+}
+
+/*
+PayAnte 依前注模式收取前注
+  - 大盲前注、按鈕前注: 支付位置實際支付全桌前注 (籌碼不足時全下)，其他玩家籌碼不變
+  - pokerface 依每位玩家的投入計算底池參與資格與結算，單一玩家投入全桌前注會變成只有該玩家可贏得的邊池，
+    因此前注底池的投入平均記在本手所有玩家 (PlayerState.Pot)，結算後再修正最終籌碼 (見 settleAnte)
+  - 每位玩家記入的前注不超過其籌碼量，支付位置只支付記入的總量 (無法整除的零頭不收取)
+  - 找不到支付位置時 (ex: 短牌沒有大盲) 以一般前注收取
+*/
+func (tgb *tableGameBackend) PayAnte(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	mode := tgb.AnteMode()
+	if mode == CompetitionAnteMode_Classic || gs.Meta.Ante <= 0 || len(gs.Players) == 0 {
+		return tgb.NativeGameBackend.PayAnte(gs)
+	}
+
+	payerIdx := UnsetValue
+	total := int64(0)
+	for _, p := range gs.Players {
+		if payment := GetAntePayment(mode, gs, p.Idx); payment > 0 {
+			payerIdx = p.Idx
+			total = payment
+			break
+		}
+	}
+	if payerIdx == UnsetValue {
+		return tgb.NativeGameBackend.PayAnte(gs)
+	}
+
+	state, err := cloneGameState(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	share := total / int64(len(state.Players))
+	adjustments := make(map[int]int64)
+	if share > 0 {
+		paid := int64(0)
+		for _, p := range state.Players {
+			contribution := share
+			if p.Idx != payerIdx {
+				if contribution > p.StackSize {
+					contribution = p.StackSize
+				}
+				adjustments[p.Idx] = contribution
+			}
+			p.Pot += contribution
+			paid += contribution
+		}
+
+		payer := state.GetPlayer(payerIdx)
+		payer.StackSize -= paid
+		payer.InitialStackSize -= paid
+		adjustments[payerIdx] = share - paid
+		state.Status.LastAction = &pokerface.Action{Source: payerIdx, Type: "ante", Value: paid}
+	}
+
+	// 支付位置籌碼不足每人一個單位時，本手不收取前注
+	g := tgb.engine.NewGameFromState(state)
+	if err := g.EmitEvent(pokerface.GameEvent_AntePaid); err != nil {
+		return nil, err
+	}
+
+	tgb.setAnteAdjustments(adjustments)
+	return cloneGameState(g.GetState())
+}
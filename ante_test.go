@@ -0,0 +1,310 @@
+package pokercompetition
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokertable"
+)
+
+func newAnteGameState(t *testing.T, backend *tableGameBackend, bankrolls []int64) *pokerface.GameState {
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Ante = 10
+	opts.Blind = pokerface.BlindSetting{SB: 10, BB: 20}
+
+	positions := [][]string{{"dealer"}, {"sb"}, {"bb"}, {}}
+	for idx, bankroll := range bankrolls {
+		opts.Players = append(opts.Players, &pokerface.PlayerSetting{
+			Bankroll:  bankroll,
+			Positions: positions[idx],
+		})
+	}
+
+	gs, err := backend.CreateGame(opts)
+	assert.NoError(t, err, "create game failed")
+	gs, err = backend.ReadyForAll(gs)
+	assert.NoError(t, err, "ready for all failed")
+	assert.Equal(t, "AnteRequested", gs.Status.CurrentEvent)
+	return gs
+}
+
+func anteStacks(gs *pokerface.GameState) []int64 {
+	stacks := make([]int64, 0, len(gs.Players))
+	for _, p := range gs.Players {
+		stacks = append(stacks, p.StackSize)
+	}
+	return stacks
+}
+
+func Test_AnteGameBackend_PayAnte(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mode      CompetitionAnteMode
+		bankrolls []int64
+		stacks    []int64
+		pot       int64
+	}{
+		{name: "classic", mode: CompetitionAnteMode_Classic, bankrolls: []int64{1000, 1000, 1000, 1000}, stacks: []int64{990, 990, 990, 990}, pot: 40},
+		{name: "big blind ante", mode: CompetitionAnteMode_BigBlind, bankrolls: []int64{1000, 1000, 1000, 1000}, stacks: []int64{1000, 1000, 960, 1000}, pot: 40},
+		{name: "button ante", mode: CompetitionAnteMode_Button, bankrolls: []int64{1000, 1000, 1000, 1000}, stacks: []int64{960, 1000, 1000, 1000}, pot: 40},
+		// 大盲只有 30，每人分得 7，零頭 2 不收取
+		{name: "short big blind", mode: CompetitionAnteMode_BigBlind, bankrolls: []int64{1000, 1000, 30, 1000}, stacks: []int64{1000, 1000, 2, 1000}, pot: 28},
+		{name: "big blind all in", mode: CompetitionAnteMode_BigBlind, bankrolls: []int64{1000, 1000, 40, 1000}, stacks: []int64{1000, 1000, 0, 1000}, pot: 40},
+		{name: "big blind below one unit each", mode: CompetitionAnteMode_BigBlind, bankrolls: []int64{1000, 1000, 3, 1000}, stacks: []int64{1000, 1000, 3, 1000}, pot: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := newTableGameBackend()
+			backend.SetAnteMode(tc.mode)
+
+			gs := newAnteGameState(t, backend, tc.bankrolls)
+			gs, err := backend.PayAnte(gs)
+			assert.NoError(t, err, "pay ante failed")
+			assert.Equal(t, tc.stacks, anteStacks(gs))
+
+			// 前注底池由所有玩家共享
+			total := int64(0)
+			for _, pot := range gs.Status.Pots {
+				total += pot.Total
+				if pot.Total > 0 {
+					assert.Len(t, pot.Contributors, len(gs.Players), "every player should be eligible for the ante pot")
+				}
+			}
+			assert.Equal(t, tc.pot, total)
+
+			// 籌碼總量不變 (前注底池只由實際支付的籌碼組成)
+			sum := total
+			for _, p := range gs.Players {
+				sum += p.StackSize
+			}
+			original := int64(0)
+			for _, b := range tc.bankrolls {
+				original += b
+			}
+			assert.Equal(t, original, sum, "chips should be conserved")
+		})
+	}
+}
+
+func Test_AnteGameBackend_NoPayerFallsBackToClassic(t *testing.T) {
+	backend := newTableGameBackend()
+	backend.SetAnteMode(CompetitionAnteMode_BigBlind)
+
+	gs := newAnteGameState(t, backend, []int64{1000, 1000, 1000, 1000})
+	for _, p := range gs.Players {
+		p.Positions = withoutPosition(p.Positions, "bb")
+	}
+
+	gs, err := backend.PayAnte(gs)
+	assert.NoError(t, err, "pay ante failed")
+	assert.Equal(t, []int64{990, 990, 990, 990}, anteStacks(gs))
+}
+
+func Test_AnteGameBackend_SettleAnte(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mode   CompetitionAnteMode
+		finals []int64
+	}{
+		// 全部棄牌，大盲贏得前注 40 + 小盲 10
+		{name: "classic", mode: CompetitionAnteMode_Classic, finals: []int64{990, 980, 1040, 990}},
+		{name: "big blind ante", mode: CompetitionAnteMode_BigBlind, finals: []int64{1000, 990, 1010, 1000}},
+		{name: "button ante", mode: CompetitionAnteMode_Button, finals: []int64{960, 990, 1050, 1000}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := newTableGameBackend()
+			backend.SetAnteMode(tc.mode)
+
+			gs := newAnteGameState(t, backend, []int64{1000, 1000, 1000, 1000})
+			gs, err := backend.PayAnte(gs)
+			assert.NoError(t, err, "pay ante failed")
+
+			for i := 0; gs.Result == nil && i < 20; i++ {
+				event := gs.Status.CurrentEvent
+				switch event {
+				case "ReadyRequested":
+					gs, err = backend.ReadyForAll(gs)
+				case "BlindsRequested":
+					gs, err = backend.PayBlinds(gs)
+				case "RoundClosed":
+					gs, err = backend.Next(gs)
+				default:
+					gs, err = backend.Fold(gs)
+				}
+				if !assert.NoError(t, err, "play game failed at %s", event) {
+					return
+				}
+			}
+			assert.NotNil(t, gs.Result, "game should be settled")
+
+			finals := make([]int64, len(gs.Result.Players))
+			sum := int64(0)
+			for _, p := range gs.Result.Players {
+				finals[p.Idx] = p.Final
+				sum += p.Final
+			}
+			assert.Equal(t, tc.finals, finals)
+			assert.Equal(t, int64(4000), sum, "chips should be conserved")
+		})
+	}
+}
+
+func Test_AnteGameBackend_Table_BigBlindAnte(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	manager := NewTableManager()
+
+	var tableEngine pokertable.TableEngine
+	var once sync.Once
+	stackChecked := false
+	options := pokertable.NewTableEngineOptions()
+	options.GameContinueInterval = 1
+	options.OpenGameTimeout = 2
+	callbacks := pokertable.NewTableEngineCallbacks()
+	callbacks.OnTableUpdated = func(table *pokertable.Table) {
+		switch table.State.Status {
+		case pokertable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerface.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.NoError(t, tableEngine.PlayerReady(playerID), "%s ready failed", playerID)
+				}
+			case pokerface.GameEvent_AnteRequested:
+				for _, playerID := range playerIDs {
+					assert.NoError(t, tableEngine.PlayerPay(playerID, table.State.BlindState.Ante), "%s pay ante failed", playerID)
+				}
+			case pokerface.GameEvent_BlindsRequested:
+				for _, position := range []string{"sb", "bb"} {
+					playerID, chips := anteTablePlayer(table, position)
+					assert.NoError(t, tableEngine.PlayerPay(playerID, chips), "%s pay %s failed", playerID, position)
+				}
+			case pokerface.GameEvent_RoundStarted:
+				if !stackChecked {
+					// 只有大盲支付前注，其他玩家籌碼為實際籌碼
+					stackChecked = true
+					for gamePlayerIdx, p := range table.State.GameState.Players {
+						player := table.State.PlayerStates[table.State.GamePlayerIndexes[gamePlayerIdx]]
+						switch {
+						case funk.ContainsString(player.Positions, "bb"):
+							assert.Equal(t, int64(15000-30-20), p.StackSize)
+						case funk.ContainsString(player.Positions, "sb"):
+							assert.Equal(t, int64(15000-10), p.StackSize)
+						default:
+							assert.Equal(t, int64(15000), p.StackSize)
+						}
+					}
+				}
+
+				currGamePlayerIdx := table.State.GameState.Status.CurrentPlayer
+				player := table.State.PlayerStates[table.State.GamePlayerIndexes[currGamePlayerIdx]]
+				if funk.ContainsString(table.State.GameState.Players[currGamePlayerIdx].AllowedActions, "fold") {
+					assert.NoError(t, tableEngine.PlayerFold(player.PlayerID), "%s fold failed", player.PlayerID)
+				}
+			}
+		case pokertable.TableStateStatus_TableGameSettled:
+			if table.State.GameCount != 1 || table.State.GameState.Result == nil {
+				return
+			}
+
+			once.Do(func() {
+				defer wg.Done()
+
+				// 大盲贏得前注 30 + 小盲 10，籌碼總量不變
+				sum := int64(0)
+				for _, playerResult := range table.State.GameState.Result.Players {
+					player := table.State.PlayerStates[table.State.GamePlayerIndexes[playerResult.Idx]]
+					switch {
+					case funk.ContainsString(player.Positions, "bb"):
+						assert.Equal(t, int64(15000+10), playerResult.Final)
+					case funk.ContainsString(player.Positions, "sb"):
+						assert.Equal(t, int64(15000-10), playerResult.Final)
+					default:
+						assert.Equal(t, int64(15000), playerResult.Final)
+					}
+					sum += playerResult.Final
+				}
+				assert.Equal(t, int64(15000*3), sum, "chips should be conserved")
+			})
+		}
+	}
+	callbacks.OnReadyOpenFirstTableGame = func(competitionID, tableID string, gameCount int, players []*pokertable.TablePlayerState) {
+		participants := map[string]int{}
+		for idx, p := range players {
+			participants[p.PlayerID] = idx
+		}
+		tableEngine.SetUpTableGame(gameCount, participants)
+	}
+
+	table, err := manager.CreateTable(options, callbacks, pokertable.TableSetting{
+		TableID: "ante-table",
+		Meta: pokertable.TableMeta{
+			CompetitionID:       "ante-competition",
+			Rule:                pokertable.CompetitionRule_Default,
+			Mode:                pokertable.CompetitionMode_CT,
+			MaxDuration:         3,
+			TableMaxSeatCount:   9,
+			TableMinPlayerCount: 2,
+			MinChipUnit:         10,
+			ActionTime:          10,
+		},
+		Blind: pokertable.TableBlindState{Level: 1, Ante: 10, SB: 10, BB: 20},
+	})
+	assert.NoError(t, err, "create table failed")
+	assert.NoError(t, manager.SetTableAnteMode(table.ID, CompetitionAnteMode_BigBlind), "set ante mode failed")
+
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.NoError(t, err, "get table engine failed")
+
+	for _, playerID := range playerIDs {
+		player := pokertable.JoinPlayer{PlayerID: playerID, RedeemChips: 15000, Seat: pokertable.UnsetValue}
+		assert.NoError(t, tableEngine.PlayerReserve(player), "%s reserve failed", playerID)
+		go func(playerID string) {
+			time.Sleep(time.Microsecond * 10)
+			assert.NoError(t, tableEngine.PlayerJoin(playerID), "%s join failed", playerID)
+		}(playerID)
+	}
+
+	time.Sleep(time.Microsecond * 100)
+	assert.NoError(t, tableEngine.StartTableGame(), "start table game failed")
+
+	wg.Wait()
+	assert.NoError(t, manager.CloseTable(table.ID), "close table failed")
+}
+
+func anteTablePlayer(table *pokertable.Table, position string) (string, int64) {
+	for _, playerIdx := range table.State.GamePlayerIndexes {
+		player := table.State.PlayerStates[playerIdx]
+		if funk.ContainsString(player.Positions, position) {
+			if position == "sb" {
+				return player.PlayerID, table.State.BlindState.SB
+			}
+			return player.PlayerID, table.State.BlindState.BB
+		}
+	}
+	return "", 0
+}
+
+func withoutPosition(positions []string, position string) []string {
+	result := make([]string, 0, len(positions))
+	for _, p := range positions {
+		if p != position {
+			result = append(result, p)
+		}
+	}
+	return result
+}
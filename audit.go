@@ -0,0 +1,214 @@
+package pokercompetition
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditSource string
+
+const (
+	AuditSource_Manager AuditSource = "manager" // Manager 呼叫 (含對應的賽事引擎操作)
+	AuditSource_Backend AuditSource = "backend" // TableManagerBackend 呼叫
+)
+
+const (
+	AuditActor_System = "system" // 非玩家或裁判發起的操作 (ex: 建立賽事、桌次更新)
+)
+
+/*
+AuditRecord 稽核紀錄
+  - 紀錄建立後不再修改，參數與結果以當下的 JSON 快照保存
+  - 同一個 Manager 呼叫所觸發的 backend 呼叫共用 CorrelationID (由 Manager 呼叫的 context 帶入)
+  - 計時器、桌次回呼等非 Manager 呼叫觸發的 backend 呼叫沒有 CorrelationID
+*/
+type AuditRecord struct {
+	ID            string          `json:"id"`             // 紀錄 ID
+	CorrelationID string          `json:"correlation_id"` // 關聯 ID (同一個 Manager 呼叫)
+	Source        AuditSource     `json:"source"`         // 紀錄來源
+	Actor         string          `json:"actor"`          // 操作者 (玩家 ID、裁判 ID 或 system)
+	CompetitionID string          `json:"competition_id"` // 賽事 ID
+	Operation     string          `json:"operation"`      // 操作名稱
+	Params        json.RawMessage `json:"params"`         // 參數
+	Result        json.RawMessage `json:"result"`         // 結果
+	Error         string          `json:"error"`          // 錯誤訊息 (成功時為空字串)
+	StartedAt     int64           `json:"started_at"`     // 開始時間 (Milliseconds)
+	EndedAt       int64           `json:"ended_at"`       // 結束時間 (Milliseconds)
+}
+
+type AuditSink interface {
+	Write(record AuditRecord) error
+}
+
+// AuditErrorHandler 處理寫入稽核紀錄失敗 (record 為寫入失敗的紀錄)
+type AuditErrorHandler func(record AuditRecord, err error)
+
+type auditParams map[string]interface{}
+
+// auditSnapshot 將參數或結果轉為 JSON 快照 (無法轉換時為 null)
+func auditSnapshot(v interface{}) json.RawMessage {
+	if v == nil {
+		return json.RawMessage("null")
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(data)
+}
+
+/*
+JSONLinesAuditSink 以 JSON Lines 格式寫入檔案的稽核紀錄
+  - 每筆紀錄一行，寫入後立即 flush
+*/
+type JSONLinesAuditSink struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+}
+
+func NewJSONLinesAuditSink(path string) (*JSONLinesAuditSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONLinesAuditSink{
+		file:   file,
+		writer: bufio.NewWriter(file),
+	}, nil
+}
+
+func (s *JSONLinesAuditSink) Write(record AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.writer.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return s.writer.Flush()
+}
+
+func (s *JSONLinesAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.writer.Flush(); err != nil {
+		return err
+	}
+	return s.file.Close()
+}
+
+/*
+auditTrail 賽事的稽核狀態
+  - onError: 寫入紀錄失敗時的處理函式
+*/
+type auditTrail struct {
+	mu            sync.RWMutex
+	sink          AuditSink
+	onError       AuditErrorHandler
+	competitionID string
+}
+
+func newAuditTrail(sink AuditSink, onError AuditErrorHandler, competitionID string) *auditTrail {
+	if onError == nil {
+		onError = func(record AuditRecord, err error) {}
+	}
+
+	return &auditTrail{
+		sink:          sink,
+		onError:       onError,
+		competitionID: competitionID,
+	}
+}
+
+func (t *auditTrail) setCompetitionID(competitionID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.competitionID = competitionID
+}
+
+func (t *auditTrail) getCompetitionID() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.competitionID
+}
+
+func (t *auditTrail) write(record AuditRecord) {
+	if t.sink == nil {
+		return
+	}
+
+	record.ID = uuid.New().String()
+	if err := t.sink.Write(record); err != nil {
+		t.onError(record, err)
+	}
+}
+
+type auditCorrelationKey struct{}
+
+// withAuditCorrelation 將 Manager 呼叫的關聯 ID 帶入 context，賽事引擎以此 context 呼叫 backend
+func withAuditCorrelation(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, auditCorrelationKey{}, correlationID)
+}
+
+// auditCorrelationID 取得 context 中的關聯 ID (不是由 Manager 呼叫觸發時為空字串)
+func auditCorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	correlationID, _ := ctx.Value(auditCorrelationKey{}).(string)
+	return correlationID
+}
+
+/*
+auditCall 一次 Manager 呼叫的稽核紀錄
+  - 關聯 ID 由呼叫本身持有 (record.CorrelationID)，透過 context 傳給賽事引擎觸發的 backend 呼叫
+  - 呼叫結束時 (end) 寫入紀錄
+*/
+type auditCall struct {
+	trail     *auditTrail
+	record    AuditRecord
+	startedAt time.Time
+}
+
+func newAuditCall(trail *auditTrail, record AuditRecord) *auditCall {
+	return &auditCall{
+		trail:     trail,
+		record:    record,
+		startedAt: time.Now(),
+	}
+}
+
+// context 帶有本次呼叫關聯 ID 的 context (沒有設定 AuditSink 時不帶關聯 ID)
+func (c *auditCall) context() context.Context {
+	if c == nil {
+		return context.Background()
+	}
+	return withAuditCorrelation(context.Background(), c.record.CorrelationID)
+}
+
+func (c *auditCall) end(result interface{}, err error) {
+	if c == nil {
+		return
+	}
+
+	c.record.Result = auditSnapshot(result)
+	if err != nil {
+		c.record.Error = err.Error()
+	}
+	c.record.StartedAt = c.startedAt.UnixMilli()
+	c.record.EndedAt = time.Now().UnixMilli()
+	c.trail.write(c.record)
+}
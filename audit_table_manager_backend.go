@@ -0,0 +1,189 @@
+package pokercompetition
+
+import (
+	"context"
+	"time"
+
+	"github.com/weedbox/pokertable"
+)
+
+/*
+auditTableManagerBackend 記錄 backend 呼叫的 TableManagerBackend
+  - 以 withContext 產生每次呼叫專用的 backend，紀錄帶入 context 中的 Manager 呼叫關聯 ID
+*/
+type auditTableManagerBackend struct {
+	backend       TableManagerBackend
+	trail         *auditTrail
+	correlationID string
+}
+
+func newAuditTableManagerBackend(backend TableManagerBackend, trail *auditTrail) TableManagerBackend {
+	return &auditTableManagerBackend{
+		backend: backend,
+		trail:   trail,
+	}
+}
+
+// contextTableManagerBackend 依呼叫的 context 產生該次呼叫專用的 TableManagerBackend
+type contextTableManagerBackend interface {
+	withContext(ctx context.Context) TableManagerBackend
+}
+
+func (atmb *auditTableManagerBackend) withContext(ctx context.Context) TableManagerBackend {
+	return &auditTableManagerBackend{
+		backend:       atmb.backend,
+		trail:         atmb.trail,
+		correlationID: auditCorrelationID(ctx),
+	}
+}
+
+func (atmb *auditTableManagerBackend) record(operation string, params auditParams, startedAt time.Time, result interface{}, err error) {
+	record := AuditRecord{
+		CorrelationID: atmb.correlationID,
+		Source:        AuditSource_Backend,
+		Actor:         AuditActor_System,
+		CompetitionID: atmb.trail.getCompetitionID(),
+		Operation:     operation,
+		Params:        auditSnapshot(params),
+		Result:        auditSnapshot(result),
+		StartedAt:     startedAt.UnixMilli(),
+		EndedAt:       time.Now().UnixMilli(),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	atmb.trail.write(record)
+}
+
+func (atmb *auditTableManagerBackend) OnTableUpdated(fn func(table *pokertable.Table)) {
+	atmb.backend.OnTableUpdated(fn)
+}
+
+func (atmb *auditTableManagerBackend) OnTablePlayerReserved(fn func(tableID string, playerState *pokertable.TablePlayerState)) {
+	atmb.backend.OnTablePlayerReserved(fn)
+}
+
+func (atmb *auditTableManagerBackend) OnReadyOpenFirstTableGame(fn func(tableID string, gameCount int, playerStates []*pokertable.TablePlayerState)) {
+	atmb.backend.OnReadyOpenFirstTableGame(fn)
+}
+
+func (atmb *auditTableManagerBackend) CreateTable(options *pokertable.TableEngineOptions, setting pokertable.TableSetting) (*pokertable.Table, error) {
+	startedAt := time.Now()
+	table, err := atmb.backend.CreateTable(options, setting)
+
+	tableID := ""
+	if table != nil {
+		tableID = table.ID
+	}
+	atmb.record("CreateTable", auditParams{"setting": setting}, startedAt, auditParams{"table_id": tableID}, err)
+	return table, err
+}
+
+func (atmb *auditTableManagerBackend) PauseTable(tableID string) error {
+	startedAt := time.Now()
+	err := atmb.backend.PauseTable(tableID)
+	atmb.record("PauseTable", auditParams{"table_id": tableID}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) CloseTable(tableID string) error {
+	startedAt := time.Now()
+	err := atmb.backend.CloseTable(tableID)
+	atmb.record("CloseTable", auditParams{"table_id": tableID}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) StartTableGame(tableID string) error {
+	startedAt := time.Now()
+	err := atmb.backend.StartTableGame(tableID)
+	atmb.record("StartTableGame", auditParams{"table_id": tableID}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) SetUpTableGame(tableID string, gameCount int, participants map[string]int) error {
+	startedAt := time.Now()
+	err := atmb.backend.SetUpTableGame(tableID, gameCount, participants)
+	atmb.record("SetUpTableGame", auditParams{"table_id": tableID, "game_count": gameCount, "participants": participants}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) UpdateBlind(tableID string, level int, ante, dealer, sb, bb int64) error {
+	startedAt := time.Now()
+	err := atmb.backend.UpdateBlind(tableID, level, ante, dealer, sb, bb)
+	atmb.record("UpdateBlind", auditParams{"table_id": tableID, "level": level, "ante": ante, "dealer": dealer, "sb": sb, "bb": bb}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) UpdateTableRule(tableID string, rule string) error {
+	startedAt := time.Now()
+	err := atmb.backend.UpdateTableRule(tableID, rule)
+	atmb.record("UpdateTableRule", auditParams{"table_id": tableID, "rule": rule}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) UpdateTableAnteMode(tableID string, mode CompetitionAnteMode) error {
+	startedAt := time.Now()
+	err := atmb.backend.UpdateTableAnteMode(tableID, mode)
+	atmb.record("UpdateTableAnteMode", auditParams{"table_id": tableID, "ante_mode": mode}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) UpdateTableBettingStructure(tableID string, structure CompetitionBettingStructure) error {
+	startedAt := time.Now()
+	err := atmb.backend.UpdateTableBettingStructure(tableID, structure)
+	atmb.record("UpdateTableBettingStructure", auditParams{"table_id": tableID, "betting_structure": structure}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) UpdateTablePlayers(tableID string, joinPlayers []pokertable.JoinPlayer, leavePlayerIDs []string) (map[string]int, error) {
+	startedAt := time.Now()
+	seats, err := atmb.backend.UpdateTablePlayers(tableID, joinPlayers, leavePlayerIDs)
+	atmb.record("UpdateTablePlayers", auditParams{"table_id": tableID, "join_players": joinPlayers, "leave_player_ids": leavePlayerIDs}, startedAt, seats, err)
+	return seats, err
+}
+
+func (atmb *auditTableManagerBackend) PlayerReserve(tableID string, joinPlayer pokertable.JoinPlayer) error {
+	startedAt := time.Now()
+	err := atmb.backend.PlayerReserve(tableID, joinPlayer)
+	atmb.record("PlayerReserve", auditParams{"table_id": tableID, "join_player": joinPlayer}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) PlayerJoin(tableID, playerID string) error {
+	startedAt := time.Now()
+	err := atmb.backend.PlayerJoin(tableID, playerID)
+	atmb.record("PlayerJoin", auditParams{"table_id": tableID, "player_id": playerID}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) PlayerRedeemChips(tableID string, joinPlayer pokertable.JoinPlayer) error {
+	startedAt := time.Now()
+	err := atmb.backend.PlayerRedeemChips(tableID, joinPlayer)
+	atmb.record("PlayerRedeemChips", auditParams{"table_id": tableID, "join_player": joinPlayer}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) PlayersLeave(tableID string, playerIDs []string) error {
+	startedAt := time.Now()
+	err := atmb.backend.PlayersLeave(tableID, playerIDs)
+	atmb.record("PlayersLeave", auditParams{"table_id": tableID, "player_ids": playerIDs}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) PlayerFold(tableID, playerID string) error {
+	startedAt := time.Now()
+	err := atmb.backend.PlayerFold(tableID, playerID)
+	atmb.record("PlayerFold", auditParams{"table_id": tableID, "player_id": playerID}, startedAt, nil, err)
+	return err
+}
+
+func (atmb *auditTableManagerBackend) UpdateTable(table *pokertable.Table) {
+	atmb.backend.UpdateTable(table)
+}
+
+func (atmb *auditTableManagerBackend) ReleaseTable(tableID string) error {
+	startedAt := time.Now()
+	err := atmb.backend.ReleaseTable(tableID)
+	atmb.record("ReleaseTable", auditParams{"table_id": tableID}, startedAt, nil, err)
+	return err
+}
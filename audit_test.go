@@ -0,0 +1,173 @@
+package pokercompetition
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryAuditSink struct {
+	mu      sync.Mutex
+	records []AuditRecord
+	err     error
+}
+
+func (s *memoryAuditSink) Write(record AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *memoryAuditSink) find(source AuditSource, operation string) []AuditRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make([]AuditRecord, 0)
+	for _, record := range s.records {
+		if record.Source == source && record.Operation == operation {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+func Test_Audit_CorrelationPerCall(t *testing.T) {
+	sink := &memoryAuditSink{}
+	trail := newAuditTrail(sink, nil, "c1")
+	backend := newAuditTableManagerBackend(NewNativeTableManagerBackend(NewTableManager()), trail).(contextTableManagerBackend)
+
+	// 同時進行的呼叫各自以自己的 context 記錄
+	first := newAuditCall(trail, AuditRecord{CorrelationID: "call-1", Operation: "First"})
+	second := newAuditCall(trail, AuditRecord{CorrelationID: "call-2", Operation: "Second"})
+	_ = backend.withContext(first.context()).PauseTable("t1")
+	_ = backend.withContext(second.context()).PauseTable("t1")
+	first.end(nil, nil)
+	second.end(nil, nil)
+
+	// 非 Manager 呼叫觸發 (例如計時器) 不記錄關聯 ID
+	_ = backend.withContext(context.Background()).PauseTable("t1")
+
+	records := sink.find(AuditSource_Backend, "PauseTable")
+	if !assert.Len(t, records, 3) {
+		return
+	}
+	assert.Equal(t, "call-1", records[0].CorrelationID)
+	assert.Equal(t, "call-2", records[1].CorrelationID)
+	assert.Equal(t, "", records[2].CorrelationID)
+	for _, record := range records {
+		assert.Equal(t, "c1", record.CompetitionID)
+		assert.NotEmpty(t, record.Error, "unknown table should be recorded as an error")
+	}
+}
+
+func Test_Audit_ConcurrentCalls(t *testing.T) {
+	sink := &memoryAuditSink{}
+	trail := newAuditTrail(sink, nil, "c1")
+	backend := newAuditTableManagerBackend(NewNativeTableManagerBackend(NewTableManager()), trail).(contextTableManagerBackend)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			call := newAuditCall(trail, AuditRecord{CorrelationID: fmt.Sprintf("call-%d", i)})
+			_ = backend.withContext(call.context()).PauseTable(fmt.Sprintf("t%d", i))
+			call.end(nil, nil)
+		}(i)
+	}
+	wg.Wait()
+
+	records := sink.find(AuditSource_Backend, "PauseTable")
+	if assert.Len(t, records, 20) {
+		for _, record := range records {
+			tableID := "t" + strings.TrimPrefix(record.CorrelationID, "call-")
+			assert.JSONEq(t, fmt.Sprintf(`{"table_id":%q}`, tableID), string(record.Params))
+		}
+	}
+}
+
+func Test_Audit_BackendCallsShareManagerCorrelation(t *testing.T) {
+	sink := &memoryAuditSink{}
+	m := NewManager(NewNativeTableManagerBackend(NewTableManager()), WithAuditSink(sink)).(*manager)
+
+	setting := newValidCompetitionSetting()
+	setting.Meta.Mode = CompetitionMode_CT
+	setting.TableSettings = []TableSetting{{TableID: "t1"}}
+	competition, err := m.CreateCompetition(setting, NewDefaultCompetitionEngineOptions())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer m.ReleaseCompetition(competition.ID)
+
+	records := sink.find(AuditSource_Manager, "CreateCompetition")
+	if !assert.Len(t, records, 1) {
+		return
+	}
+	assert.Equal(t, competition.ID, records[0].CompetitionID)
+	assert.NotEmpty(t, records[0].CorrelationID)
+
+	// 建立賽事時的建桌呼叫與建立賽事的呼叫關聯
+	tableRecords := sink.find(AuditSource_Backend, "CreateTable")
+	if assert.Len(t, tableRecords, 1) {
+		assert.Equal(t, records[0].CorrelationID, tableRecords[0].CorrelationID)
+		assert.Equal(t, competition.ID, tableRecords[0].CompetitionID)
+	}
+}
+
+func Test_Audit_Actor(t *testing.T) {
+	sink := &memoryAuditSink{}
+	m := NewManager(NewNativeTableManagerBackend(NewTableManager()), WithAuditSink(sink))
+
+	assert.ErrorIs(t, m.MovePlayerToFeaturedTable("unknown", "director-1", "p1", "t1"), ErrManagerCompetitionNotFound)
+	_, err := m.SubscribeEvents("unknown", "viewer-1", func(event Event) {}, SubscribeOptions{})
+	assert.ErrorIs(t, err, ErrManagerCompetitionNotFound)
+
+	if records := sink.find(AuditSource_Manager, "MovePlayerToFeaturedTable"); assert.Len(t, records, 1) {
+		assert.Equal(t, "director-1", records[0].Actor)
+	}
+	if records := sink.find(AuditSource_Manager, "SubscribeEvents"); assert.Len(t, records, 1) {
+		assert.Equal(t, "viewer-1", records[0].Actor)
+	}
+}
+
+func Test_Audit_DefaultErrorHandler(t *testing.T) {
+	// 未指定錯誤處理時忽略寫入失敗
+	m := NewManager(NewNativeTableManagerBackend(NewTableManager()), WithAuditSink(&memoryAuditSink{err: errors.New("disk full")}))
+	assert.NotPanics(t, func() {
+		_, err := m.GetAdvancementResult("unknown")
+		assert.ErrorIs(t, err, ErrManagerAdvancementResultNotFound)
+	})
+}
+
+func Test_Audit_ErrorHandler(t *testing.T) {
+	sinkErr := errors.New("disk full")
+	sink := &memoryAuditSink{err: sinkErr}
+
+	var failedRecords []AuditRecord
+	var failedErr error
+	m := NewManager(
+		NewNativeTableManagerBackend(NewTableManager()),
+		WithAuditSink(sink),
+		WithAuditErrorHandler(func(record AuditRecord, err error) {
+			failedRecords = append(failedRecords, record)
+			failedErr = err
+		}),
+	)
+
+	_, err := m.GetAdvancementResult("unknown")
+	assert.ErrorIs(t, err, ErrManagerAdvancementResultNotFound)
+
+	if assert.Len(t, failedRecords, 1) {
+		assert.Equal(t, "GetAdvancementResult", failedRecords[0].Operation)
+		assert.Equal(t, ErrManagerAdvancementResultNotFound.Error(), failedRecords[0].Error)
+	}
+	assert.Equal(t, sinkErr, failedErr)
+}
@@ -0,0 +1,511 @@
+package pokerbalancing
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/weedbox/pokerface/regulator"
+)
+
+type RegulatorOpt func(*balancingRegulator)
+
+var (
+	ErrNotFoundPlayer = errors.New("regulator: player not found")
+)
+
+type Regulator interface {
+	regulator.Regulator
+
+	// MovePlayers 玩家直接由某桌次移至另一桌次 (不經等待區, ex: 移至焦點桌)
+	MovePlayers(fromTableID, toTableID string, count int) error
+
+	// RemovePlayers 玩家離開賽事 (ex: 棄賽)，tableID 為空字串表示玩家在等待區
+	RemovePlayers(tableID string, players []string) error
+
+	// RequeuePlayers 玩家由某桌次回到等待區重新安排座位 (ex: 換桌失敗且無法回到原桌)，賽事人數不變
+	RequeuePlayers(tableID string, players []string) error
+}
+
+// TableInfoFn 取得桌次的桌號與是否為焦點桌 (桌號為 0 時使用監管器建立順序)
+type TableInfoFn func(tableID string) (tableNumber int, isFeatured bool)
+
+/*
+SeatingCostFn 計算玩家入座某桌次的座位限制成本 (0 表示沒有違反任何限制)
+  - tableID: 要入座的桌次 (新開桌次為空字串)
+  - pickedPlayerIDs: 同一批已選入該桌次的玩家
+*/
+type SeatingCostFn func(tableID string, pickedPlayerIDs []string, playerID string) int
+
+func MinInitialPlayers(num int) RegulatorOpt {
+	return func(r *balancingRegulator) {
+		r.minInitialPlayers = num
+	}
+}
+
+func MaxPlayersPerTable(num int) RegulatorOpt {
+	return func(r *balancingRegulator) {
+		r.maxPlayersPerTable = num
+	}
+}
+
+func WithRequestTableFn(fn regulator.RequestTableFn) RegulatorOpt {
+	return func(r *balancingRegulator) {
+		r.requestTableFn = fn
+	}
+}
+
+func WithAssignPlayersFn(fn regulator.AssignPlayersFn) RegulatorOpt {
+	return func(r *balancingRegulator) {
+		r.assignPlayersFn = fn
+	}
+}
+
+// WithSeatingCostFn 設定座位限制成本，分配玩家時優先選擇成本最低的玩家
+func WithSeatingCostFn(fn SeatingCostFn) RegulatorOpt {
+	return func(r *balancingRegulator) {
+		r.seatingCostFn = fn
+	}
+}
+
+// WithTableInfoFn 設定桌號與焦點桌來源，焦點桌不拆桌且最後補人
+func WithTableInfoFn(fn TableInfoFn) RegulatorOpt {
+	return func(r *balancingRegulator) {
+		r.tableInfoFn = fn
+	}
+}
+
+type balancingRegulator struct {
+	strategy           Strategy
+	maxPlayersPerTable int
+	minInitialPlayers  int
+	playerCount        int
+	status             regulator.CompetitionStatus
+	waitingQueue       []string
+	tables             map[string]*regulator.Table
+	tableNumbers       map[string]int // key: table id, value: 桌號 (依建立順序)
+	nextTableNumber    int
+	mu                 sync.RWMutex
+	requestTableFn     regulator.RequestTableFn
+	assignPlayersFn    regulator.AssignPlayersFn
+	seatingCostFn      SeatingCostFn
+	tableInfoFn        TableInfoFn
+}
+
+/*
+NewRegulator 建立依拆併桌策略運作的拆併桌監管器
+  - 與 pokerface regulator 相同介面，拆桌與移出人數改由 Strategy 決定
+*/
+func NewRegulator(strategy Strategy, opts ...RegulatorOpt) Regulator {
+	r := &balancingRegulator{
+		strategy:           strategy,
+		maxPlayersPerTable: 9,
+		minInitialPlayers:  6,
+		status:             regulator.CompetitionStatus_Pending,
+		waitingQueue:       make([]string, 0),
+		tables:             make(map[string]*regulator.Table),
+		tableNumbers:       make(map[string]int),
+		nextTableNumber:    1,
+		requestTableFn: func(players []string) (string, error) {
+			panic("Not implemented")
+		},
+		assignPlayersFn: func(tableID string, players []string) error {
+			panic("Not implemented")
+		},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func (r *balancingRegulator) GetPlayerCount() int {
+	return r.playerCount
+}
+
+func (r *balancingRegulator) GetTableCount() int {
+	return len(r.tables)
+}
+
+func (r *balancingRegulator) GetTable(tableID string) *regulator.Table {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if t, ok := r.tables[tableID]; ok {
+		return t
+	}
+
+	return nil
+}
+
+func (r *balancingRegulator) SetStatus(status regulator.CompetitionStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.status == status {
+		return
+	}
+
+	oldStatus := r.status
+	r.status = status
+
+	if oldStatus == regulator.CompetitionStatus_Pending && r.status == regulator.CompetitionStatus_Normal {
+		r.drainWaitingQueue()
+	}
+}
+
+func (r *balancingRegulator) AddPlayers(players []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.status == regulator.CompetitionStatus_AfterRegDeadline {
+		return regulator.ErrAfterRegDealline
+	}
+
+	r.playerCount += len(players)
+	r.updateTableRequirements(r.plan())
+
+	return r.enterWaitingQueue(players)
+}
+
+/*
+SyncState 桌次結算後同步人數
+  - @return 該桌要移出的人數 (拆桌時為該桌所有人數)、要補進該桌的玩家
+*/
+func (r *balancingRegulator) SyncState(tableID string, out int) (int, []string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tables[tableID]
+	if !ok {
+		return 0, []string{}, regulator.ErrNotFoundTable
+	}
+
+	r.playerCount -= out
+	t.PlayerCount -= out
+
+	plan := r.plan()
+	r.updateTableRequirements(plan)
+
+	// 拆桌
+	if plan.IsBreakTable(tableID) {
+		delete(r.tables, tableID)
+		delete(r.tableNumbers, tableID)
+		return t.PlayerCount, []string{}, nil
+	}
+
+	// 移出玩家
+	if releaseCount := plan.ReleaseCounts[tableID]; releaseCount > 0 {
+		t.PlayerCount -= releaseCount
+		return releaseCount, []string{}, nil
+	}
+
+	// 補進玩家
+	if t.Required > 0 {
+		players := r.requestPlayers(tableID, t.Required)
+		t.Required -= len(players)
+		t.PlayerCount += len(players)
+		return 0, players, nil
+	}
+
+	return 0, []string{}, nil
+}
+
+func (r *balancingRegulator) MovePlayers(fromTableID, toTableID string, count int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	from, ok := r.tables[fromTableID]
+	if !ok {
+		return regulator.ErrNotFoundTable
+	}
+
+	to, ok := r.tables[toTableID]
+	if !ok {
+		return regulator.ErrNotFoundTable
+	}
+
+	from.PlayerCount -= count
+	to.PlayerCount += count
+	r.updateTableRequirements(r.plan())
+	return nil
+}
+
+func (r *balancingRegulator) RemovePlayers(tableID string, players []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tableID == "" {
+		removed := make(map[string]bool)
+		for _, playerID := range players {
+			removed[playerID] = true
+		}
+
+		waitingQueue := make([]string, 0, len(r.waitingQueue))
+		for _, playerID := range r.waitingQueue {
+			if removed[playerID] {
+				r.playerCount--
+				continue
+			}
+			waitingQueue = append(waitingQueue, playerID)
+		}
+		if len(waitingQueue) == len(r.waitingQueue) {
+			return ErrNotFoundPlayer
+		}
+		r.waitingQueue = waitingQueue
+	} else {
+		t, ok := r.tables[tableID]
+		if !ok {
+			return regulator.ErrNotFoundTable
+		}
+		t.PlayerCount -= len(players)
+		r.playerCount -= len(players)
+	}
+
+	r.updateTableRequirements(r.plan())
+	return nil
+}
+
+func (r *balancingRegulator) RequeuePlayers(tableID string, players []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tables[tableID]
+	if !ok {
+		return regulator.ErrNotFoundTable
+	}
+
+	t.PlayerCount -= len(players)
+	r.updateTableRequirements(r.plan())
+	return r.enterWaitingQueue(players)
+}
+
+func (r *balancingRegulator) ReleasePlayers(tableID string, players []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.enterWaitingQueue(players)
+}
+
+// plan 依當前各桌人數取得拆併桌計畫 (尚未入座的玩家視為等待分配)
+func (r *balancingRegulator) plan() Plan {
+	tables := make([]Table, 0, len(r.tables))
+	seatedPlayerCount := 0
+	for _, t := range r.tables {
+		tableNumber, isFeatured := r.tableInfo(t.ID)
+		tables = append(tables, Table{
+			TableID:     t.ID,
+			TableNumber: tableNumber,
+			PlayerCount: t.PlayerCount,
+			IsFeatured:  isFeatured,
+		})
+		seatedPlayerCount += t.PlayerCount
+	}
+
+	return r.strategy.Plan(tables, r.playerCount-seatedPlayerCount, r.maxPlayersPerTable)
+}
+
+func (r *balancingRegulator) tableInfo(tableID string) (int, bool) {
+	if r.tableInfoFn != nil {
+		if tableNumber, isFeatured := r.tableInfoFn(tableID); tableNumber > 0 {
+			return tableNumber, isFeatured
+		}
+	}
+	return r.tableNumbers[tableID], false
+}
+
+func (r *balancingRegulator) updateTableRequirements(plan Plan) {
+	for _, t := range r.tables {
+		t.Required = plan.RequiredCounts[t.ID]
+	}
+}
+
+func (r *balancingRegulator) requestPlayers(tableID string, count int) []string {
+	players, rest := r.pickPlayers(tableID, r.waitingQueue, count)
+	r.waitingQueue = rest
+	return players
+}
+
+/*
+pickPlayers 從候選玩家中選出要入座某桌次的玩家
+  - 未設定座位限制時依排隊順序選出
+  - 有座位限制時每次選出成本最低的玩家 (成本相同時依排隊順序)，無法避免的違反由呼叫端回報
+*/
+func (r *balancingRegulator) pickPlayers(tableID string, candidates []string, count int) ([]string, []string) {
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+
+	if r.seatingCostFn == nil {
+		picked := make([]string, 0, count)
+		picked = append(picked, candidates[:count]...)
+		rest := make([]string, 0, len(candidates)-count)
+		rest = append(rest, candidates[count:]...)
+		return picked, rest
+	}
+
+	picked := make([]string, 0, count)
+	rest := make([]string, 0, len(candidates))
+	rest = append(rest, candidates...)
+	for len(picked) < count {
+		bestIdx := 0
+		bestCost := r.seatingCostFn(tableID, picked, rest[0])
+		for idx := 1; idx < len(rest) && bestCost > 0; idx++ {
+			if cost := r.seatingCostFn(tableID, picked, rest[idx]); cost < bestCost {
+				bestIdx = idx
+				bestCost = cost
+			}
+		}
+		picked = append(picked, rest[bestIdx])
+		rest = append(rest[:bestIdx], rest[bestIdx+1:]...)
+	}
+	return picked, rest
+}
+
+// getAvailableTable 需要補人的桌次 (焦點桌最後補人，再依桌號由小到大)
+func (r *balancingRegulator) getAvailableTable() *regulator.Table {
+	var available *regulator.Table
+	availableNumber, isAvailableFeatured := 0, false
+	for _, t := range r.tables {
+		if t.Required <= 0 {
+			continue
+		}
+
+		tableNumber, isFeatured := r.tableInfo(t.ID)
+		if available == nil || (isAvailableFeatured && !isFeatured) || (isAvailableFeatured == isFeatured && tableNumber < availableNumber) {
+			available = t
+			availableNumber, isAvailableFeatured = tableNumber, isFeatured
+		}
+	}
+	return available
+}
+
+func (r *balancingRegulator) dispatchPlayers(players []string) ([]string, error) {
+	t := r.getAvailableTable()
+	if t == nil {
+		return players, regulator.ErrNoAvailableTable
+	}
+
+	picked, candidates := r.pickPlayers(t.ID, players, t.Required)
+	if err := r.assignPlayersFn(t.ID, picked); err != nil {
+		return players, err
+	}
+
+	t.Required -= len(picked)
+	t.PlayerCount += len(picked)
+	return candidates, nil
+}
+
+func (r *balancingRegulator) allocateTables() error {
+	requiredTables := int(math.Ceil(float64(r.playerCount) / float64(r.maxPlayersPerTable)))
+	if requiredTables == 0 {
+		return nil
+	}
+
+	waterLevel := r.maxPlayersPerTable
+	if len(r.tables) == 0 {
+		// no table yet and we don't have enough players
+		if r.playerCount < r.minInitialPlayers {
+			return nil
+		}
+
+		// no table yet and we have enough players for more than one table
+		wl := int(math.Floor(float64(r.playerCount) / float64(requiredTables)))
+		if wl >= r.minInitialPlayers {
+			waterLevel = wl
+		} else {
+			requiredTables = int(math.Floor(float64(r.playerCount) / float64(r.maxPlayersPerTable)))
+		}
+	} else {
+		waterLevel = int(math.Floor(float64(r.playerCount) / float64(requiredTables)))
+	}
+
+	for waterLevel >= r.minInitialPlayers && len(r.tables) < requiredTables {
+		requiredPlayers := waterLevel
+
+		// the rest of players for the last table
+		if len(r.waitingQueue) > waterLevel && len(r.waitingQueue) < r.maxPlayersPerTable {
+			requiredPlayers = len(r.waitingQueue)
+		}
+
+		players := r.requestPlayers("", requiredPlayers)
+		if len(players) == 0 {
+			return nil
+		}
+
+		tableID, err := r.requestTableFn(players)
+		if err != nil {
+			return err
+		}
+
+		t := &regulator.Table{
+			ID:          tableID,
+			PlayerCount: len(players),
+		}
+		if len(players) < waterLevel {
+			t.Required = waterLevel - len(players)
+		}
+		r.tables[tableID] = t
+		r.tableNumbers[tableID] = r.nextTableNumber
+		r.nextTableNumber++
+
+		expectedTables := requiredTables - len(r.tables)
+		if expectedTables <= 0 {
+			break
+		}
+		waterLevel = int(math.Floor(float64(len(r.waitingQueue)) / float64(expectedTables)))
+	}
+
+	return nil
+}
+
+func (r *balancingRegulator) drainWaitingQueue() error {
+	// First time to allocate tables
+	if len(r.tables) == 0 {
+		if len(r.waitingQueue) >= r.minInitialPlayers {
+			return r.allocateTables()
+		}
+		return nil
+	}
+
+	var err error
+	candidates := r.waitingQueue
+	for len(candidates) > 0 {
+		candidates, err = r.dispatchPlayers(candidates)
+		if err != nil {
+			break
+		}
+	}
+
+	// 重新計算各桌需求後再分配一次
+	if len(candidates) > 0 {
+		r.updateTableRequirements(r.plan())
+		for len(candidates) > 0 {
+			candidates, err = r.dispatchPlayers(candidates)
+			if err != nil {
+				break
+			}
+		}
+	}
+
+	r.waitingQueue = candidates
+
+	// still have players
+	if len(candidates) > 0 {
+		return r.allocateTables()
+	}
+
+	return nil
+}
+
+func (r *balancingRegulator) enterWaitingQueue(players []string) error {
+	r.waitingQueue = append(r.waitingQueue, players...)
+
+	if r.status == regulator.CompetitionStatus_Pending {
+		return nil
+	}
+
+	return r.drainWaitingQueue()
+}
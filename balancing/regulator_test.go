@@ -0,0 +1,118 @@
+package pokerbalancing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/regulator"
+)
+
+func Test_Regulator_SeatingCost(t *testing.T) {
+	teams := map[string]string{
+		"a1": "A", "a2": "A",
+		"b1": "B", "b2": "B",
+	}
+
+	tables := make(map[string][]string)
+	r := NewRegulator(
+		NewTDAStrategy(),
+		MinInitialPlayers(2),
+		MaxPlayersPerTable(2),
+		WithRequestTableFn(func(players []string) (string, error) {
+			tableID := string(rune('x' + len(tables)))
+			tables[tableID] = players
+			return tableID, nil
+		}),
+		WithAssignPlayersFn(func(tableID string, players []string) error {
+			tables[tableID] = append(tables[tableID], players...)
+			return nil
+		}),
+		WithSeatingCostFn(func(tableID string, pickedPlayerIDs []string, playerID string) int {
+			cost := 0
+			for _, pickedPlayerID := range pickedPlayerIDs {
+				if teams[pickedPlayerID] == teams[playerID] {
+					cost++
+				}
+			}
+			return cost
+		}),
+	)
+
+	assert.NoError(t, r.AddPlayers([]string{"a1", "a2", "b1", "b2"}))
+	r.SetStatus(regulator.CompetitionStatus_Normal)
+
+	assert.Len(t, tables, 2)
+	for tableID, players := range tables {
+		assert.Len(t, players, 2, tableID)
+		assert.NotEqual(t, teams[players[0]], teams[players[1]], "teammates should not share table %s", tableID)
+	}
+}
+
+func Test_Regulator_RemovePlayers(t *testing.T) {
+	tables := make(map[string][]string)
+	r := NewRegulator(
+		NewTDAStrategy(),
+		MinInitialPlayers(2),
+		MaxPlayersPerTable(3),
+		WithRequestTableFn(func(players []string) (string, error) {
+			tableID := string(rune('x' + len(tables)))
+			tables[tableID] = players
+			return tableID, nil
+		}),
+		WithAssignPlayersFn(func(tableID string, players []string) error {
+			tables[tableID] = append(tables[tableID], players...)
+			return nil
+		}),
+	)
+
+	assert.NoError(t, r.AddPlayers([]string{"p1", "p2", "p3"}))
+	assert.Equal(t, 3, r.GetPlayerCount())
+
+	// 等待區玩家離開
+	assert.NoError(t, r.RemovePlayers("", []string{"p3"}))
+	assert.Equal(t, 2, r.GetPlayerCount())
+	assert.ErrorIs(t, r.RemovePlayers("", []string{"p3"}), ErrNotFoundPlayer)
+
+	r.SetStatus(regulator.CompetitionStatus_Normal)
+	assert.Len(t, tables, 1)
+
+	// 桌次內玩家離開
+	for tableID := range tables {
+		assert.NoError(t, r.RemovePlayers(tableID, []string{"p1"}))
+		assert.Equal(t, 1, r.GetTable(tableID).PlayerCount)
+	}
+	assert.Equal(t, 1, r.GetPlayerCount())
+	assert.ErrorIs(t, r.RemovePlayers("unknown", []string{"p2"}), regulator.ErrNotFoundTable)
+}
+
+func Test_Regulator_RequeuePlayers(t *testing.T) {
+	tables := make(map[string][]string)
+	r := NewRegulator(
+		NewTDAStrategy(),
+		MinInitialPlayers(2),
+		MaxPlayersPerTable(3),
+		WithRequestTableFn(func(players []string) (string, error) {
+			tableID := string(rune('x' + len(tables)))
+			tables[tableID] = players
+			return tableID, nil
+		}),
+		WithAssignPlayersFn(func(tableID string, players []string) error {
+			tables[tableID] = append(tables[tableID], players...)
+			return nil
+		}),
+	)
+
+	assert.NoError(t, r.AddPlayers([]string{"p1", "p2", "p3"}))
+	r.SetStatus(regulator.CompetitionStatus_Normal)
+	assert.Len(t, tables, 1)
+
+	// 玩家回到等待區後重新入座原桌次，人數不重複計算
+	for tableID := range tables {
+		assert.NoError(t, r.RequeuePlayers(tableID, []string{"p1"}))
+		assert.Equal(t, 3, r.GetTable(tableID).PlayerCount)
+		assert.Equal(t, []string{"p1", "p2", "p3", "p1"}, tables[tableID])
+	}
+	assert.Len(t, tables, 1)
+	assert.Equal(t, 3, r.GetPlayerCount())
+	assert.ErrorIs(t, r.RequeuePlayers("unknown", []string{"p2"}), regulator.ErrNotFoundTable)
+}
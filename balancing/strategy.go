@@ -0,0 +1,235 @@
+package pokerbalancing
+
+import (
+	"math"
+	"sort"
+)
+
+type Table struct {
+	TableID     string `json:"table_id"`     // 桌次 ID
+	TableNumber int    `json:"table_number"` // 桌號
+	PlayerCount int    `json:"player_count"` // 桌次存活人數
+	IsFeatured  bool   `json:"is_featured"`  // 是否為焦點桌 (不拆桌、最後補人)
+}
+
+type Plan struct {
+	BreakTableIDs  []string       `json:"break_table_ids"` // 要拆的桌次
+	ReleaseCounts  map[string]int `json:"release_counts"`  // 各桌要移出的人數 (不含拆桌), key: table id
+	RequiredCounts map[string]int `json:"required_counts"` // 各桌要補進的人數, key: table id
+}
+
+func (p Plan) IsBreakTable(tableID string) bool {
+	for _, id := range p.BreakTableIDs {
+		if id == tableID {
+			return true
+		}
+	}
+	return false
+}
+
+// TotalMoves 計算計畫需要移動的玩家數
+func (p Plan) TotalMoves() int {
+	moves := 0
+	for _, count := range p.RequiredCounts {
+		moves += count
+	}
+	return moves
+}
+
+type Strategy interface {
+	// Plan 依各桌人數與等待分配人數決定要拆的桌次、各桌移出與補進人數
+	Plan(tables []Table, waitingPlayerCount int, maxSeatCount int) Plan
+
+	// SelectMovingPlayers 從依下一手 BB 順序排列的玩家中選出要移動的玩家
+	SelectMovingPlayers(nextBBOrderPlayerIDs []string, count int) []string
+}
+
+/*
+NewTDAStrategy TDA 標準拆併桌
+  - 拆桌: 人數最少的桌次 (人數相同時拆桌號較大者)
+  - 平衡: 各桌人數差距不超過 1 人
+  - 移動玩家: 下一手 BB 的玩家 (移至最差位置)
+*/
+func NewTDAStrategy() Strategy {
+	return &strategy{
+		pickBreakTable: pickFewestPlayersTable,
+		tolerance:      1,
+	}
+}
+
+/*
+NewBreakHighestTableStrategy 依桌號由大到小拆桌
+  - 拆桌: 桌號最大的桌次
+  - 平衡: 各桌人數差距不超過 1 人
+*/
+func NewBreakHighestTableStrategy() Strategy {
+	return &strategy{
+		pickBreakTable: pickHighestNumberTable,
+		tolerance:      1,
+	}
+}
+
+/*
+NewMinimizeMovesStrategy 最少移動玩家數
+  - 拆桌: 人數最少的桌次 (需移動的玩家最少)
+  - 平衡: 各桌人數差距達 3 人才移動，減少換桌次數
+*/
+func NewMinimizeMovesStrategy() Strategy {
+	return &strategy{
+		pickBreakTable: pickFewestPlayersTable,
+		tolerance:      2,
+	}
+}
+
+type strategy struct {
+	pickBreakTable func(tables []Table) int
+	tolerance      int // 可容許的各桌人數差距
+}
+
+func (s *strategy) Plan(tables []Table, waitingPlayerCount int, maxSeatCount int) Plan {
+	plan := Plan{
+		BreakTableIDs:  make([]string, 0),
+		ReleaseCounts:  make(map[string]int),
+		RequiredCounts: make(map[string]int),
+	}
+	if len(tables) == 0 || maxSeatCount <= 0 {
+		return plan
+	}
+
+	totalPlayerCount := waitingPlayerCount
+	for _, t := range tables {
+		totalPlayerCount += t.PlayerCount
+	}
+	requiredTableCount := int(math.Ceil(float64(totalPlayerCount) / float64(maxSeatCount)))
+	if requiredTableCount < 1 {
+		requiredTableCount = 1
+	}
+
+	// 拆桌: 桌數多於所需桌數 (焦點桌不拆)
+	active := make([]Table, len(tables))
+	copy(active, tables)
+	movingPlayerCount := waitingPlayerCount
+	for len(active) > requiredTableCount {
+		breakable := make([]Table, 0, len(active))
+		for _, t := range active {
+			if !t.IsFeatured {
+				breakable = append(breakable, t)
+			}
+		}
+		if len(breakable) == 0 {
+			break
+		}
+
+		breakTableID := breakable[s.pickBreakTable(breakable)].TableID
+		for idx, t := range active {
+			if t.TableID == breakTableID {
+				plan.BreakTableIDs = append(plan.BreakTableIDs, t.TableID)
+				movingPlayerCount += t.PlayerCount
+				active = append(active[:idx], active[idx+1:]...)
+				break
+			}
+		}
+	}
+
+	// 拆桌與等待中的玩家補進人數最少的桌次
+	for ; movingPlayerCount > 0; movingPlayerCount-- {
+		idx := pickFewestPlayersTableToFill(active, maxSeatCount)
+		if idx == -1 {
+			break
+		}
+		active[idx].PlayerCount++
+		plan.RequiredCounts[active[idx].TableID]++
+	}
+
+	// 平衡: 從人數最多的桌次移至人數最少的桌次
+	for {
+		maxIdx := pickMostPlayersTable(active)
+		minIdx := pickFewestPlayersTableToFill(active, maxSeatCount)
+		if maxIdx == -1 || minIdx == -1 || active[maxIdx].PlayerCount-active[minIdx].PlayerCount <= s.tolerance {
+			break
+		}
+		active[maxIdx].PlayerCount--
+		active[minIdx].PlayerCount++
+		plan.ReleaseCounts[active[maxIdx].TableID]++
+		plan.RequiredCounts[active[minIdx].TableID]++
+	}
+
+	return plan
+}
+
+func (s *strategy) SelectMovingPlayers(nextBBOrderPlayerIDs []string, count int) []string {
+	if count > len(nextBBOrderPlayerIDs) {
+		count = len(nextBBOrderPlayerIDs)
+	}
+
+	players := make([]string, 0, count)
+	players = append(players, nextBBOrderPlayerIDs[:count]...)
+	return players
+}
+
+// pickFewestPlayersTable 人數最少的桌次 (人數相同時取桌號較大者)
+func pickFewestPlayersTable(tables []Table) int {
+	sorted := sortedTableIndexes(tables, func(a, b Table) bool {
+		if a.PlayerCount != b.PlayerCount {
+			return a.PlayerCount < b.PlayerCount
+		}
+		return a.TableNumber > b.TableNumber
+	})
+	return sorted[0]
+}
+
+// pickHighestNumberTable 桌號最大的桌次
+func pickHighestNumberTable(tables []Table) int {
+	sorted := sortedTableIndexes(tables, func(a, b Table) bool {
+		return a.TableNumber > b.TableNumber
+	})
+	return sorted[0]
+}
+
+// pickMostPlayersTable 人數最多的桌次 (人數相同時優先非焦點桌，再取桌號較大者)
+func pickMostPlayersTable(tables []Table) int {
+	if len(tables) == 0 {
+		return -1
+	}
+
+	sorted := sortedTableIndexes(tables, func(a, b Table) bool {
+		if a.PlayerCount != b.PlayerCount {
+			return a.PlayerCount > b.PlayerCount
+		}
+		if a.IsFeatured != b.IsFeatured {
+			return !a.IsFeatured
+		}
+		return a.TableNumber > b.TableNumber
+	})
+	return sorted[0]
+}
+
+// pickFewestPlayersTableToFill 尚有空位且人數最少的桌次 (人數相同時焦點桌最後補人，再取桌號較小者)
+func pickFewestPlayersTableToFill(tables []Table, maxSeatCount int) int {
+	sorted := sortedTableIndexes(tables, func(a, b Table) bool {
+		if a.PlayerCount != b.PlayerCount {
+			return a.PlayerCount < b.PlayerCount
+		}
+		if a.IsFeatured != b.IsFeatured {
+			return !a.IsFeatured
+		}
+		return a.TableNumber < b.TableNumber
+	})
+	for _, idx := range sorted {
+		if tables[idx].PlayerCount < maxSeatCount {
+			return idx
+		}
+	}
+	return -1
+}
+
+func sortedTableIndexes(tables []Table, less func(a, b Table) bool) []int {
+	indexes := make([]int, len(tables))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return less(tables[indexes[i]], tables[indexes[j]])
+	})
+	return indexes
+}
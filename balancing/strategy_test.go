@@ -0,0 +1,137 @@
+package pokerbalancing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TDAStrategy_BreakFewestPlayersTable(t *testing.T) {
+	tables := []Table{
+		{TableID: "t1", TableNumber: 1, PlayerCount: 7},
+		{TableID: "t2", TableNumber: 2, PlayerCount: 4},
+		{TableID: "t3", TableNumber: 3, PlayerCount: 6},
+	}
+
+	plan := NewTDAStrategy().Plan(tables, 0, 9)
+	assert.Equal(t, []string{"t2"}, plan.BreakTableIDs, "table with fewest players should break")
+	assert.Equal(t, 4, plan.TotalMoves(), "players of broken table should all move")
+	assert.Equal(t, 2, plan.RequiredCounts["t1"])
+	assert.Equal(t, 2, plan.RequiredCounts["t3"])
+	assert.Empty(t, plan.ReleaseCounts, "no balancing needed after break")
+}
+
+func Test_BreakHighestTableStrategy(t *testing.T) {
+	tables := []Table{
+		{TableID: "t1", TableNumber: 1, PlayerCount: 7},
+		{TableID: "t2", TableNumber: 2, PlayerCount: 4},
+		{TableID: "t3", TableNumber: 3, PlayerCount: 6},
+	}
+
+	plan := NewBreakHighestTableStrategy().Plan(tables, 0, 9)
+	assert.Equal(t, []string{"t3"}, plan.BreakTableIDs, "highest-numbered table should break")
+	assert.Equal(t, 2, plan.RequiredCounts["t1"])
+	assert.Equal(t, 4, plan.RequiredCounts["t2"])
+}
+
+func Test_Strategy_Balancing(t *testing.T) {
+	tables := []Table{
+		{TableID: "t1", TableNumber: 1, PlayerCount: 9},
+		{TableID: "t2", TableNumber: 2, PlayerCount: 6},
+	}
+
+	tda := NewTDAStrategy().Plan(tables, 0, 9)
+	assert.Empty(t, tda.BreakTableIDs)
+	assert.Equal(t, 1, tda.ReleaseCounts["t1"], "tda should move one player to keep difference within 1")
+	assert.Equal(t, 1, tda.RequiredCounts["t2"])
+
+	minimize := NewMinimizeMovesStrategy().Plan(tables, 0, 9)
+	assert.Equal(t, 1, minimize.ReleaseCounts["t1"], "difference of 3 should still be balanced")
+
+	tables[0].PlayerCount = 8
+	minimize = NewMinimizeMovesStrategy().Plan(tables, 0, 9)
+	assert.Equal(t, 0, minimize.TotalMoves(), "difference of 2 should be tolerated")
+}
+
+func Test_Strategy_WaitingPlayers(t *testing.T) {
+	tables := []Table{
+		{TableID: "t1", TableNumber: 1, PlayerCount: 5},
+		{TableID: "t2", TableNumber: 2, PlayerCount: 3},
+	}
+
+	plan := NewTDAStrategy().Plan(tables, 3, 9)
+	assert.Empty(t, plan.BreakTableIDs, "waiting players should be counted before breaking tables")
+	assert.Equal(t, 1, plan.RequiredCounts["t1"])
+	assert.Equal(t, 2, plan.RequiredCounts["t2"])
+}
+
+func Test_Strategy_SelectMovingPlayers(t *testing.T) {
+	players := NewTDAStrategy().SelectMovingPlayers([]string{"p1", "p2", "p3"}, 2)
+	assert.Equal(t, []string{"p1", "p2"}, players, "players who will be big blind next should move")
+
+	players = NewTDAStrategy().SelectMovingPlayers([]string{"p1"}, 3)
+	assert.Equal(t, []string{"p1"}, players)
+}
+
+func Test_Regulator_BreakTable(t *testing.T) {
+	tableSeq := 0
+	assigned := make(map[string][]string)
+	r := NewRegulator(NewBreakHighestTableStrategy(),
+		MinInitialPlayers(2),
+		MaxPlayersPerTable(3),
+		WithRequestTableFn(func(players []string) (string, error) {
+			tableSeq++
+			tableID := []string{"", "t1", "t2"}[tableSeq]
+			assigned[tableID] = append(assigned[tableID], players...)
+			return tableID, nil
+		}),
+		WithAssignPlayersFn(func(tableID string, players []string) error {
+			assigned[tableID] = append(assigned[tableID], players...)
+			return nil
+		}),
+	)
+
+	assert.NoError(t, r.AddPlayers([]string{"p1", "p2", "p3", "p4", "p5", "p6"}))
+	r.SetStatus(1) // CompetitionStatus_Normal
+	assert.Equal(t, 2, r.GetTableCount())
+	assert.Len(t, assigned["t1"], 3)
+	assert.Len(t, assigned["t2"], 3)
+
+	// t1 淘汰 2 人後剩 4 人，仍需 2 桌 (ceil(4/3))
+	release, newPlayers, err := r.SyncState("t1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, release)
+	assert.Empty(t, newPlayers)
+
+	// t2 再淘汰 1 人，剩 3 人只需 1 桌，桌號較大的 t2 需拆桌
+	release, _, err = r.SyncState("t2", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, release, "all remaining players of broken table should be released")
+	assert.Nil(t, r.GetTable("t2"))
+
+	assert.NoError(t, r.ReleasePlayers("t2", []string{"p5", "p6"}))
+	assert.Equal(t, []string{"p1", "p2", "p3", "p5", "p6"}, assigned["t1"])
+	assert.Equal(t, 3, r.GetTable("t1").PlayerCount)
+}
+
+func Test_Strategy_FeaturedTable(t *testing.T) {
+	tables := []Table{
+		{TableID: "t1", TableNumber: 1, PlayerCount: 3, IsFeatured: true},
+		{TableID: "t2", TableNumber: 2, PlayerCount: 7},
+		{TableID: "t3", TableNumber: 3, PlayerCount: 6},
+	}
+
+	plan := NewTDAStrategy().Plan(tables, 0, 9)
+	assert.Equal(t, []string{"t3"}, plan.BreakTableIDs, "featured table should never break")
+	assert.Equal(t, 5, plan.RequiredCounts["t1"])
+	assert.Equal(t, 1, plan.RequiredCounts["t2"])
+
+	// 人數相同時焦點桌最後補人
+	tables = []Table{
+		{TableID: "t1", TableNumber: 1, PlayerCount: 6, IsFeatured: true},
+		{TableID: "t2", TableNumber: 2, PlayerCount: 6},
+	}
+	plan = NewTDAStrategy().Plan(tables, 1, 9)
+	assert.Equal(t, 0, plan.RequiredCounts["t1"])
+	assert.Equal(t, 1, plan.RequiredCounts["t2"])
+}
@@ -0,0 +1,140 @@
+package pokercompetition
+
+import (
+	"errors"
+
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokertable"
+)
+
+var (
+	ErrBettingStructureWagerOutOfRange = errors.New("betting structure: wager is out of range")
+)
+
+type WagerRange struct {
+	Min int64 `json:"min"` // 最小合法籌碼量
+	Max int64 `json:"max"` // 最大合法籌碼量
+}
+
+/*
+GetBetRange 計算玩家下注 (bet) 合法籌碼範圍
+  - 無限注: 最小下注量 ~ 玩家籌碼量
+  - 底池限注: 最小下注量 ~ 底池籌碼量
+  - 固定限注: Preflop/Flop 為 BB, Turn/River 為 2 倍 BB
+*/
+func GetBetRange(structure CompetitionBettingStructure, gs *pokerface.GameState, playerIdx int) WagerRange {
+	player := gs.GetPlayer(playerIdx)
+	if player == nil {
+		return WagerRange{}
+	}
+
+	wr := WagerRange{
+		Min: gs.Status.MiniBet,
+		Max: player.InitialStackSize,
+	}
+
+	switch structure {
+	case CompetitionBettingStructure_PotLimit:
+		wr.Max = currentPotSize(gs)
+	case CompetitionBettingStructure_FixedLimit:
+		wr.Min = fixedLimitBetSize(gs)
+		wr.Max = wr.Min
+	}
+
+	return wr.limitTo(player.InitialStackSize)
+}
+
+/*
+GetRaiseRange 計算玩家加注 (raise) 合法籌碼級別範圍 (加注後本輪總下注量)
+  - 無限注: 當前下注量 + 前次加注量 ~ 玩家籌碼量
+  - 底池限注: 當前下注量 + 前次加注量 ~ 當前下注量 + 跟注後底池籌碼量
+  - 固定限注: 當前下注量 + 固定下注量
+*/
+func GetRaiseRange(structure CompetitionBettingStructure, gs *pokerface.GameState, playerIdx int) WagerRange {
+	player := gs.GetPlayer(playerIdx)
+	if player == nil {
+		return WagerRange{}
+	}
+
+	wr := WagerRange{
+		Min: gs.Status.CurrentWager + gs.Status.PreviousRaiseSize,
+		Max: player.InitialStackSize,
+	}
+
+	switch structure {
+	case CompetitionBettingStructure_PotLimit:
+		callChips := gs.Status.CurrentWager - player.Wager
+		wr.Max = gs.Status.CurrentWager + currentPotSize(gs) + callChips
+	case CompetitionBettingStructure_FixedLimit:
+		wr.Min = gs.Status.CurrentWager + fixedLimitBetSize(gs)
+		wr.Max = wr.Min
+	}
+
+	return wr.limitTo(player.InitialStackSize)
+}
+
+/*
+validateWagerLevel 驗證玩家下注後本輪總下注量是否符合下注結構
+  - 未超過當前下注量 (跟注、全下跟注) 不限制
+  - 當前無人下注時依下注範圍驗證，否則依加注範圍驗證
+  - 無限注由 pokerface 驗證
+*/
+func validateWagerLevel(structure CompetitionBettingStructure, gs *pokerface.GameState, playerIdx int, level int64) error {
+	if structure == "" || structure == CompetitionBettingStructure_NoLimit {
+		return nil
+	}
+
+	player := gs.GetPlayer(playerIdx)
+	if player == nil || level <= gs.Status.CurrentWager {
+		return nil
+	}
+
+	wr := GetRaiseRange(structure, gs, playerIdx)
+	if gs.Status.CurrentWager == 0 {
+		wr = GetBetRange(structure, gs, playerIdx)
+		level -= player.Wager
+	}
+
+	if level < wr.Min || level > wr.Max {
+		return ErrBettingStructureWagerOutOfRange
+	}
+	return nil
+}
+
+// Clamp 將籌碼量限制在合法範圍內
+func (wr WagerRange) Clamp(chips int64) int64 {
+	if chips < wr.Min {
+		return wr.Min
+	}
+	if chips > wr.Max {
+		return wr.Max
+	}
+	return chips
+}
+
+// limitTo 籌碼不足時只能全下 (最小、最大值皆不超過玩家籌碼量)
+func (wr WagerRange) limitTo(stack int64) WagerRange {
+	if wr.Max > stack {
+		wr.Max = stack
+	}
+	if wr.Min > wr.Max {
+		wr.Min = wr.Max
+	}
+	return wr
+}
+
+func currentPotSize(gs *pokerface.GameState) int64 {
+	total := gs.Status.CurrentRoundPot
+	for _, pot := range gs.Status.Pots {
+		total += pot.Total
+	}
+	return total
+}
+
+func fixedLimitBetSize(gs *pokerface.GameState) int64 {
+	switch gs.Status.Round {
+	case pokertable.GameRound_Turn, pokertable.GameRound_River:
+		return gs.Meta.Blind.BB * 2
+	}
+	return gs.Meta.Blind.BB
+}
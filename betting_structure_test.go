@@ -0,0 +1,192 @@
+package pokercompetition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/pot"
+	"github.com/weedbox/pokertable"
+)
+
+func newWagerGameState(round string, stack, wager int64) *pokerface.GameState {
+	return &pokerface.GameState{
+		Meta: pokerface.Meta{
+			Blind: pokerface.BlindSetting{SB: 10, BB: 20},
+		},
+		Status: pokerface.Status{
+			Round:             round,
+			MiniBet:           20,
+			CurrentWager:      40,
+			PreviousRaiseSize: 20,
+			CurrentRoundPot:   60,
+			Pots:              []*pot.Pot{{Total: 100}},
+		},
+		Players: []*pokerface.PlayerState{
+			{Idx: 0, InitialStackSize: stack, StackSize: stack - wager, Wager: wager},
+		},
+	}
+}
+
+func Test_BettingStructure_BetRange(t *testing.T) {
+	testCases := []struct {
+		name      string
+		structure CompetitionBettingStructure
+		round     string
+		stack     int64
+		expected  WagerRange
+	}{
+		{name: "no limit", structure: CompetitionBettingStructure_NoLimit, round: pokertable.GameRound_Flop, stack: 1000, expected: WagerRange{Min: 20, Max: 1000}},
+		{name: "pot limit", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 1000, expected: WagerRange{Min: 20, Max: 160}},
+		{name: "fixed limit flop", structure: CompetitionBettingStructure_FixedLimit, round: pokertable.GameRound_Flop, stack: 1000, expected: WagerRange{Min: 20, Max: 20}},
+		{name: "fixed limit turn", structure: CompetitionBettingStructure_FixedLimit, round: pokertable.GameRound_Turn, stack: 1000, expected: WagerRange{Min: 40, Max: 40}},
+		{name: "fixed limit river", structure: CompetitionBettingStructure_FixedLimit, round: pokertable.GameRound_River, stack: 1000, expected: WagerRange{Min: 40, Max: 40}},
+		{name: "pot limit short stack", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 100, expected: WagerRange{Min: 20, Max: 100}},
+		{name: "short stack all in", structure: CompetitionBettingStructure_NoLimit, round: pokertable.GameRound_Flop, stack: 15, expected: WagerRange{Min: 15, Max: 15}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := newWagerGameState(tc.round, tc.stack, 0)
+			assert.Equal(t, tc.expected, GetBetRange(tc.structure, gs, 0))
+		})
+	}
+
+	assert.Equal(t, WagerRange{}, GetBetRange(CompetitionBettingStructure_NoLimit, newWagerGameState(pokertable.GameRound_Flop, 1000, 0), 1), "unknown player")
+}
+
+func Test_BettingStructure_RaiseRange(t *testing.T) {
+	testCases := []struct {
+		name      string
+		structure CompetitionBettingStructure
+		round     string
+		stack     int64
+		wager     int64
+		expected  WagerRange
+	}{
+		{name: "no limit", structure: CompetitionBettingStructure_NoLimit, round: pokertable.GameRound_Flop, stack: 1000, expected: WagerRange{Min: 60, Max: 1000}},
+		// 跟注 40 後底池 200: 40 + 160 + 40
+		{name: "pot limit", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 1000, expected: WagerRange{Min: 60, Max: 240}},
+		// 已下注 20，跟注 20 後底池 180: 40 + 160 + 20
+		{name: "pot limit with wager", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 1000, wager: 20, expected: WagerRange{Min: 60, Max: 220}},
+		{name: "fixed limit preflop", structure: CompetitionBettingStructure_FixedLimit, round: pokertable.GameRound_Preflop, stack: 1000, expected: WagerRange{Min: 60, Max: 60}},
+		{name: "fixed limit river", structure: CompetitionBettingStructure_FixedLimit, round: pokertable.GameRound_River, stack: 1000, expected: WagerRange{Min: 80, Max: 80}},
+		{name: "short stack all in", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 50, expected: WagerRange{Min: 50, Max: 50}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := newWagerGameState(tc.round, tc.stack, tc.wager)
+			assert.Equal(t, tc.expected, GetRaiseRange(tc.structure, gs, 0))
+		})
+	}
+}
+
+func Test_BettingStructure_Clamp(t *testing.T) {
+	wr := WagerRange{Min: 20, Max: 100}
+	assert.Equal(t, int64(20), wr.Clamp(5))
+	assert.Equal(t, int64(50), wr.Clamp(50))
+	assert.Equal(t, int64(100), wr.Clamp(500))
+}
+
+func Test_BettingStructure_ValidateWagerLevel(t *testing.T) {
+	testCases := []struct {
+		name         string
+		structure    CompetitionBettingStructure
+		round        string
+		stack        int64
+		currentWager int64
+		level        int64
+		err          error
+	}{
+		{name: "no limit", structure: CompetitionBettingStructure_NoLimit, round: pokertable.GameRound_Flop, stack: 1000, currentWager: 40, level: 1000},
+		{name: "call", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 1000, currentWager: 40, level: 40},
+		{name: "pot limit max raise", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 1000, currentWager: 40, level: 240},
+		{name: "pot limit over pot", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 1000, currentWager: 40, level: 250, err: ErrBettingStructureWagerOutOfRange},
+		{name: "pot limit under min raise", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 1000, currentWager: 40, level: 50, err: ErrBettingStructureWagerOutOfRange},
+		{name: "pot limit bet", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 1000, currentWager: 0, level: 160},
+		{name: "pot limit bet over pot", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 1000, currentWager: 0, level: 170, err: ErrBettingStructureWagerOutOfRange},
+		{name: "pot limit short stack all in", structure: CompetitionBettingStructure_PotLimit, round: pokertable.GameRound_Flop, stack: 50, currentWager: 40, level: 50},
+		{name: "fixed limit raise", structure: CompetitionBettingStructure_FixedLimit, round: pokertable.GameRound_Flop, stack: 1000, currentWager: 40, level: 60},
+		{name: "fixed limit over raise", structure: CompetitionBettingStructure_FixedLimit, round: pokertable.GameRound_Flop, stack: 1000, currentWager: 40, level: 80, err: ErrBettingStructureWagerOutOfRange},
+		{name: "fixed limit turn bet", structure: CompetitionBettingStructure_FixedLimit, round: pokertable.GameRound_Turn, stack: 1000, currentWager: 0, level: 40},
+		{name: "fixed limit turn under bet", structure: CompetitionBettingStructure_FixedLimit, round: pokertable.GameRound_Turn, stack: 1000, currentWager: 0, level: 20, err: ErrBettingStructureWagerOutOfRange},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := newWagerGameState(tc.round, tc.stack, 0)
+			gs.Status.CurrentWager = tc.currentWager
+			assert.Equal(t, tc.err, validateWagerLevel(tc.structure, gs, 0, tc.level))
+		})
+	}
+}
+
+func Test_BettingStructure_TableGameBackend(t *testing.T) {
+	testCases := []struct {
+		name      string
+		structure CompetitionBettingStructure
+		play      func(backend *tableGameBackend, gs *pokerface.GameState) (*pokerface.GameState, error)
+		err       error
+	}{
+		{name: "no limit all in", structure: CompetitionBettingStructure_NoLimit, play: func(backend *tableGameBackend, gs *pokerface.GameState) (*pokerface.GameState, error) {
+			return backend.Allin(gs)
+		}},
+		// 翻牌前底池 30: 加注上限為 20 + 30 + 20
+		{name: "pot limit raise", structure: CompetitionBettingStructure_PotLimit, play: func(backend *tableGameBackend, gs *pokerface.GameState) (*pokerface.GameState, error) {
+			return backend.Raise(gs, 70)
+		}},
+		{name: "pot limit over raise", structure: CompetitionBettingStructure_PotLimit, play: func(backend *tableGameBackend, gs *pokerface.GameState) (*pokerface.GameState, error) {
+			return backend.Raise(gs, 200)
+		}, err: ErrBettingStructureWagerOutOfRange},
+		{name: "pot limit all in", structure: CompetitionBettingStructure_PotLimit, play: func(backend *tableGameBackend, gs *pokerface.GameState) (*pokerface.GameState, error) {
+			return backend.Allin(gs)
+		}, err: ErrBettingStructureWagerOutOfRange},
+		{name: "pot limit over pay", structure: CompetitionBettingStructure_PotLimit, play: func(backend *tableGameBackend, gs *pokerface.GameState) (*pokerface.GameState, error) {
+			return backend.Pay(gs, 500)
+		}, err: ErrBettingStructureWagerOutOfRange},
+		{name: "fixed limit raise", structure: CompetitionBettingStructure_FixedLimit, play: func(backend *tableGameBackend, gs *pokerface.GameState) (*pokerface.GameState, error) {
+			return backend.Raise(gs, 40)
+		}},
+		{name: "fixed limit over raise", structure: CompetitionBettingStructure_FixedLimit, play: func(backend *tableGameBackend, gs *pokerface.GameState) (*pokerface.GameState, error) {
+			return backend.Raise(gs, 60)
+		}, err: ErrBettingStructureWagerOutOfRange},
+		{name: "fixed limit call", structure: CompetitionBettingStructure_FixedLimit, play: func(backend *tableGameBackend, gs *pokerface.GameState) (*pokerface.GameState, error) {
+			return backend.Call(gs)
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := newTableGameBackend()
+			backend.SetBettingStructure(tc.structure)
+
+			opts := pokerface.NewStardardGameOptions()
+			opts.Deck = pokerface.NewStandardDeckCards()
+			opts.Blind = pokerface.BlindSetting{SB: 10, BB: 20}
+			for _, positions := range [][]string{{"dealer"}, {"sb"}, {"bb"}} {
+				opts.Players = append(opts.Players, &pokerface.PlayerSetting{Bankroll: 1000, Positions: positions})
+			}
+
+			gs, err := backend.CreateGame(opts)
+			assert.NoError(t, err, "create game failed")
+			for i := 0; gs.Status.CurrentEvent != "RoundStarted" && i < 10; i++ {
+				switch gs.Status.CurrentEvent {
+				case "ReadyRequested":
+					gs, err = backend.ReadyForAll(gs)
+				case "AnteRequested":
+					gs, err = backend.PayAnte(gs)
+				case "BlindsRequested":
+					gs, err = backend.PayBlinds(gs)
+				}
+				if !assert.NoError(t, err, "prepare game failed") {
+					return
+				}
+			}
+			assert.Equal(t, pokertable.GameRound_Preflop, gs.Status.Round)
+
+			_, err = tc.play(backend, gs)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
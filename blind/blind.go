@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/weedbox/timebank"
@@ -26,6 +27,7 @@ type Blind interface {
 	ApplyOptions(options *BlindOptions) *BlindState
 	UpdateInitialLevel(level int) error
 	Start() (*BlindState, error)
+	RecordHand(tableID string, activeTableIDs []string) error
 	End()
 	IsStarted() bool
 }
@@ -36,6 +38,7 @@ type blind struct {
 	stateUpdater func(*BlindState)
 	errorUpdater func(*BlindState, error)
 	isEnd        bool
+	mu           sync.Mutex
 }
 
 func NewBlind() Blind {
@@ -81,12 +84,14 @@ func (b *blind) ApplyOptions(options *BlindOptions) *BlindState {
 		Meta: Meta{
 			InitialLevel:         options.InitialLevel,
 			FinalBuyInLevelIndex: options.FinalBuyInLevelIndex,
+			HandCountMode:        options.HandCountMode,
 			Levels:               options.Levels,
 		},
 		Status: Status{
 			FinalBuyInLevelIndex: options.FinalBuyInLevelIndex,
 			CurrentLevelIndex:    UnsetValue,
 			LevelEndAts:          levelEndAts,
+			LevelHandCounts:      make(map[string]int),
 		},
 		CreatedAt: nowUnix,
 		StartedAt: UnsetValue,
@@ -123,13 +128,92 @@ func (b *blind) Start() (*BlindState, error) {
 		}
 	}
 
-	for i := (b.bs.Status.CurrentLevelIndex); i < len(b.bs.Meta.Levels); i++ {
-		if i == b.bs.Status.CurrentLevelIndex {
+	b.scheduleLevels(b.bs.Status.CurrentLevelIndex, startAt)
+
+	return b.bs, nil
+}
+
+/*
+RecordHand 記錄桌次完成一手牌 (以手數計算的等級使用)
+  - HandCountMode_MaxTable: 任一桌次當前等級手數達到 HandCount 即升級
+  - HandCountMode_Synchronized: activeTableIDs 內所有桌次當前等級手數皆達到 HandCount 才升級
+  - 盲注等級為全賽事共用，不支援各桌獨立升級，先達到手數的桌次維持當前等級直到其他桌次追上
+  - 以時間計算的等級不受影響
+*/
+func (b *blind) RecordHand(tableID string, activeTableIDs []string) error {
+	if b.bs == nil {
+		return ErrBlindNoState
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.IsStarted() || b.isEnd {
+		return nil
+	}
+
+	levelIdx := b.bs.Status.CurrentLevelIndex
+	if !b.bs.IsHandCountLevel(levelIdx) {
+		return nil
+	}
+
+	b.bs.Status.LevelHandCounts[tableID]++
+
+	handCount := b.bs.Meta.Levels[levelIdx].HandCount
+	isLevelEnd := false
+	switch b.bs.Meta.HandCountMode {
+	case HandCountMode_Synchronized:
+		isLevelEnd = len(activeTableIDs) > 0
+		for _, id := range activeTableIDs {
+			if b.bs.Status.LevelHandCounts[id] < handCount {
+				isLevelEnd = false
+				break
+			}
+		}
+	default:
+		isLevelEnd = b.bs.Status.LevelHandCounts[tableID] >= handCount
+	}
+
+	if !isLevelEnd || levelIdx+1 >= len(b.bs.Meta.Levels) {
+		return nil
+	}
+
+	// 升級後重新排程後續以時間計算的等級
+	now := time.Now()
+	b.bs.Status.LevelEndAts[levelIdx] = now.Unix()
+	b.bs.Status.CurrentLevelIndex++
+	b.bs.Status.LevelHandCounts = make(map[string]int)
+	b.scheduleLevels(b.bs.Status.CurrentLevelIndex, now)
+	b.emitState()
+	return nil
+}
+
+func (b *blind) End() {
+	b.isEnd = true
+}
+
+func (b *blind) IsStarted() bool {
+	return b.bs.IsActive()
+}
+
+/*
+scheduleLevels 從 fromIdx 開始排程連續以時間計算的等級
+  - 遇到以手數計算的等級即停止，該等級與之後等級的結束時間待升級後再計算
+*/
+func (b *blind) scheduleLevels(fromIdx int, startAt time.Time) {
+	for i := fromIdx; i < len(b.bs.Meta.Levels); i++ {
+		if i == fromIdx {
 			b.bs.Status.LevelEndAts[i] = startAt.Unix()
 		} else {
 			b.bs.Status.LevelEndAts[i] = b.bs.Status.LevelEndAts[i-1]
 		}
 
+		if b.bs.IsHandCountLevel(i) {
+			// 結束時間未知，由 RecordHand 觸發升級
+			b.bs.Status.LevelEndAts[i] = UnsetValue
+			break
+		}
+
 		if b.bs.Meta.Levels[i].Duration == -1 {
 			// unlimited duration
 			b.bs.Status.LevelEndAts[i] = int64(b.bs.Meta.Levels[i].Duration)
@@ -144,16 +228,6 @@ func (b *blind) Start() (*BlindState, error) {
 			b.emitState()
 		}
 	}
-
-	return b.bs, nil
-}
-
-func (b *blind) End() {
-	b.isEnd = true
-}
-
-func (b *blind) IsStarted() bool {
-	return b.bs.IsActive()
 }
 
 func (b *blind) updateLevel(endAt int64) {
@@ -167,8 +241,12 @@ func (b *blind) updateLevel(endAt int64) {
 			return
 		}
 
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
 		if b.bs.Status.CurrentLevelIndex+1 < len(b.bs.Meta.Levels) {
 			b.bs.Status.CurrentLevelIndex++
+			b.bs.Status.LevelHandCounts = make(map[string]int)
 			b.emitState()
 		}
 	}); err != nil {
@@ -471,3 +471,104 @@ func Test_Blind_0_Duration(t *testing.T) {
 
 	wg.Wait()
 }
+
+func Test_Blind_HandCountLevel_MaxTable(t *testing.T) {
+	blind := NewBlind()
+	options := &BlindOptions{
+		ID:                   uuid.New().String(),
+		InitialLevel:         1,
+		FinalBuyInLevelIndex: 2,
+		HandCountMode:        HandCountMode_MaxTable,
+		Levels: []BlindLevel{
+			{
+				Level:     1,
+				Blind:     pokerface.BlindSetting{SB: 10, BB: 20},
+				HandCount: 2,
+			},
+			{
+				Level:    2,
+				Blind:    pokerface.BlindSetting{SB: 20, BB: 40},
+				Duration: 60,
+			},
+			{
+				Level:    3,
+				Blind:    pokerface.BlindSetting{SB: 30, BB: 60},
+				Duration: 60,
+			},
+		},
+	}
+	blind.ApplyOptions(options)
+
+	bs, err := blind.Start()
+	assert.NoError(t, err, "starting blind failed")
+	assert.Equal(t, int64(UnsetValue), bs.Status.LevelEndAts[0], "hand count level end at should be unknown")
+	assert.Equal(t, int64(UnsetValue), bs.Status.LevelEndAts[1], "levels after hand count level should not be scheduled")
+
+	tableIDs := []string{"table-1", "table-2"}
+	assert.NoError(t, blind.RecordHand("table-1", tableIDs))
+	assert.NoError(t, blind.RecordHand("table-2", tableIDs))
+	assert.Equal(t, 1, blind.GetState().CurrentLevel().Level, "level should not change before any table reaches hand count")
+
+	assert.NoError(t, blind.RecordHand("table-1", tableIDs))
+	bs = blind.GetState()
+	assert.Equal(t, 2, bs.CurrentLevel().Level, "level should change when a table reaches hand count")
+	assert.Equal(t, bs.Status.LevelEndAts[0]+60, bs.Status.LevelEndAts[1], "time level should be scheduled after hand count level ends")
+	assert.Equal(t, bs.Status.LevelEndAts[1]+60, bs.Status.LevelEndAts[2], "following time levels should be scheduled")
+
+	// 以時間計算的等級不受手數影響
+	assert.NoError(t, blind.RecordHand("table-1", tableIDs))
+	assert.Equal(t, 2, blind.GetState().CurrentLevel().Level, "time level should ignore hands")
+	blind.End()
+}
+
+func Test_Blind_HandCountLevel_Synchronized(t *testing.T) {
+	blind := NewBlind()
+	options := &BlindOptions{
+		ID:                   uuid.New().String(),
+		InitialLevel:         1,
+		FinalBuyInLevelIndex: UnsetValue,
+		HandCountMode:        HandCountMode_Synchronized,
+		Levels: []BlindLevel{
+			{
+				Level:    1,
+				Blind:    pokerface.BlindSetting{SB: 10, BB: 20},
+				Duration: 1,
+			},
+			{
+				Level:     2,
+				Blind:     pokerface.BlindSetting{SB: 20, BB: 40},
+				HandCount: 1,
+			},
+			{
+				Level:     3,
+				Blind:     pokerface.BlindSetting{SB: 30, BB: 60},
+				HandCount: 1,
+			},
+		},
+	}
+	blind.ApplyOptions(options)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	blind.OnBlindStateUpdated(func(bs *BlindState) {
+		if bs.CurrentLevel().Level == 2 {
+			wg.Done()
+		}
+	})
+
+	_, err := blind.Start()
+	assert.NoError(t, err, "starting blind failed")
+	wg.Wait()
+
+	tableIDs := []string{"table-1", "table-2"}
+	assert.NoError(t, blind.RecordHand("table-1", tableIDs))
+	assert.NoError(t, blind.RecordHand("table-1", tableIDs))
+	assert.Equal(t, 2, blind.GetState().CurrentLevel().Level, "level should not change until every table reaches hand count")
+
+	blind.OnBlindStateUpdated(func(bs *BlindState) {})
+	assert.NoError(t, blind.RecordHand("table-2", tableIDs))
+	bs := blind.GetState()
+	assert.Equal(t, 3, bs.CurrentLevel().Level, "level should change when every table reaches hand count")
+	assert.Empty(t, bs.Status.LevelHandCounts, "hand counts should reset on level change")
+	blind.End()
+}
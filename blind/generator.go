@@ -0,0 +1,184 @@
+package pokerblind
+
+import (
+	"errors"
+	"math"
+
+	"github.com/weedbox/pokerface"
+)
+
+var (
+	ErrGeneratorInvalidOptions = errors.New("blind: invalid generator options")
+)
+
+type GeneratorPreset string
+
+const (
+	GeneratorPreset_Regular GeneratorPreset = "regular" // 一般賽
+	GeneratorPreset_Turbo   GeneratorPreset = "turbo"   // 快速賽
+	GeneratorPreset_Hyper   GeneratorPreset = "hyper"   // 超快速賽
+)
+
+type GeneratorOptions struct {
+	ID                     string          `json:"id"`
+	Preset                 GeneratorPreset `json:"preset"`                   // 預設賽制 (影響起始深度、等級長度、前注開始等級)
+	StartingStack          int64           `json:"starting_stack"`           // 起始籌碼
+	ExpectedEntries        int             `json:"expected_entries"`         // 預估參賽人次
+	TargetDuration         int             `json:"target_duration"`          // 預估比賽總長 (Seconds)
+	LevelDuration          int             `json:"level_duration"`           // 每個等級持續時間 (Seconds, 0 表示使用 Preset 預設值)
+	BreakEvery             int             `json:"break_every"`              // 每幾個等級休息一次 (0 表示不休息)
+	BreakDuration          int             `json:"break_duration"`           // 中場休息時間 (Seconds)
+	LateRegistrationLevels int             `json:"late_registration_levels"` // 延遲買入等級數 (0 表示不可延遲買入)
+	AntePercent            int             `json:"ante_percent"`             // 前注佔 BB 百分比 (0 表示不收前注)
+	MinChipUnit            int64           `json:"min_chip_unit"`            // 最小單位籌碼量
+}
+
+type generatorPresetSetting struct {
+	startingBigBlinds int64 // 起始籌碼為幾個 BB
+	levelDuration     int   // 預設等級持續時間 (Seconds)
+	anteStartLevel    int   // 第幾個等級開始收前注
+}
+
+var generatorPresetSettings = map[GeneratorPreset]generatorPresetSetting{
+	GeneratorPreset_Regular: {startingBigBlinds: 100, levelDuration: 20 * 60, anteStartLevel: 4},
+	GeneratorPreset_Turbo:   {startingBigBlinds: 50, levelDuration: 10 * 60, anteStartLevel: 3},
+	GeneratorPreset_Hyper:   {startingBigBlinds: 25, levelDuration: 5 * 60, anteStartLevel: 1},
+}
+
+// 比賽預計結束時，場上總籌碼約為幾個 BB
+const generatorEndingBigBlinds = 20
+
+var niceBlindSteps = []float64{1, 1.2, 1.5, 2, 2.5, 3, 4, 5, 6, 8}
+
+/*
+Generate 依起始籌碼、預估人次與比賽總長產生盲注結構
+  - 盲注由起始深度以等比方式成長至比賽預計結束時的大小，並取整至常用盲注數值與最小單位籌碼量
+  - 每 BreakEvery 個等級插入一次中場休息 (Level = -1)
+  - FinalBuyInLevelIndex 為最後一個延遲買入等級的索引值
+*/
+func Generate(opts GeneratorOptions) (*BlindOptions, error) {
+	preset, ok := generatorPresetSettings[opts.Preset]
+	if !ok {
+		preset = generatorPresetSettings[GeneratorPreset_Regular]
+	}
+
+	if opts.StartingStack <= 0 || opts.ExpectedEntries <= 0 || opts.TargetDuration <= 0 || opts.MinChipUnit <= 0 {
+		return nil, ErrGeneratorInvalidOptions
+	}
+
+	levelDuration := opts.LevelDuration
+	if levelDuration <= 0 {
+		levelDuration = preset.levelDuration
+	}
+
+	// 計算總長內可容納的等級數 (含中場休息時間)
+	levelCount := 0
+	elapsed := 0
+	for {
+		next := elapsed + levelDuration
+		if opts.BreakEvery > 0 && levelCount > 0 && levelCount%opts.BreakEvery == 0 {
+			next += opts.BreakDuration
+		}
+		if next > opts.TargetDuration && levelCount > 0 {
+			break
+		}
+		elapsed = next
+		levelCount++
+	}
+
+	startBB := roundToChipUnit(float64(opts.StartingStack)/float64(preset.startingBigBlinds), opts.MinChipUnit)
+	totalChips := float64(opts.StartingStack) * float64(opts.ExpectedEntries)
+	endBB := math.Max(totalChips/generatorEndingBigBlinds, float64(startBB))
+
+	growth := 1.0
+	if levelCount > 1 {
+		growth = math.Pow(endBB/float64(startBB), 1/float64(levelCount-1))
+	}
+
+	levels := make([]BlindLevel, 0)
+	finalBuyInLevelIndex := UnsetValue
+	prevBB, prevSB := int64(0), int64(0)
+	for i := 0; i < levelCount; i++ {
+		bb := roundToChipUnit(niceBlind(float64(startBB)*math.Pow(growth, float64(i))), opts.MinChipUnit)
+		if bb <= prevBB {
+			bb = prevBB + opts.MinChipUnit
+		}
+		prevBB = bb
+
+		sb := roundToChipUnit(float64(bb)/2, opts.MinChipUnit)
+		if sb >= bb {
+			sb = bb - opts.MinChipUnit
+		}
+		if sb <= 0 {
+			sb = opts.MinChipUnit
+		}
+		// 小盲同樣必須遞增 (前一級小盲小於前一級大盲，因此仍小於本級大盲)
+		if sb <= prevSB {
+			sb = prevSB + opts.MinChipUnit
+		}
+		prevSB = sb
+
+		ante := int64(0)
+		if opts.AntePercent > 0 && i+1 >= preset.anteStartLevel {
+			ante = roundToChipUnit(float64(bb)*float64(opts.AntePercent)/100, opts.MinChipUnit)
+		}
+
+		levels = append(levels, BlindLevel{
+			Level: i + 1,
+			Ante:  ante,
+			Blind: pokerface.BlindSetting{
+				Dealer: 0,
+				SB:     sb,
+				BB:     bb,
+			},
+			Duration: levelDuration,
+		})
+
+		if i+1 == opts.LateRegistrationLevels {
+			finalBuyInLevelIndex = len(levels) - 1
+		}
+
+		// 中場休息 (最後一個等級後不需休息)
+		if opts.BreakEvery > 0 && (i+1)%opts.BreakEvery == 0 && i+1 < levelCount {
+			levels = append(levels, BlindLevel{
+				Level:    -1,
+				Duration: opts.BreakDuration,
+			})
+		}
+	}
+
+	return &BlindOptions{
+		ID:                   opts.ID,
+		InitialLevel:         1,
+		FinalBuyInLevelIndex: finalBuyInLevelIndex,
+		Levels:               levels,
+	}, nil
+}
+
+// niceBlind 取整至常用盲注數值 (1, 1.2, 1.5, 2, 2.5, 3, 4, 5, 6, 8 x 10^n)
+func niceBlind(value float64) float64 {
+	if value <= 0 {
+		return 0
+	}
+
+	magnitude := math.Pow(10, math.Floor(math.Log10(value)))
+	nice := niceBlindSteps[len(niceBlindSteps)-1] * magnitude
+	minDiff := math.Abs(value - nice)
+	for _, step := range append(niceBlindSteps, 10) {
+		candidate := step * magnitude
+		if diff := math.Abs(value - candidate); diff < minDiff {
+			nice = candidate
+			minDiff = diff
+		}
+	}
+	return nice
+}
+
+// roundToChipUnit 取整至最小單位籌碼量的倍數 (至少一個單位)
+func roundToChipUnit(value float64, unit int64) int64 {
+	rounded := int64(math.Round(value/float64(unit))) * unit
+	if rounded < unit {
+		return unit
+	}
+	return rounded
+}
@@ -0,0 +1,88 @@
+package pokerblind
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Generator_Regular(t *testing.T) {
+	options, err := Generate(GeneratorOptions{
+		ID:                     uuid.New().String(),
+		Preset:                 GeneratorPreset_Regular,
+		StartingStack:          20000,
+		ExpectedEntries:        200,
+		TargetDuration:         8 * 60 * 60,
+		LevelDuration:          30 * 60,
+		BreakEvery:             4,
+		BreakDuration:          10 * 60,
+		LateRegistrationLevels: 6,
+		AntePercent:            10,
+		MinChipUnit:            100,
+	})
+	assert.NoError(t, err, "generate blind failed")
+	assert.Equal(t, 1, options.InitialLevel, "initial level is wrong")
+
+	totalDuration := 0
+	prevBB, prevSB := int64(0), int64(0)
+	breakCount := 0
+	for idx, bl := range options.Levels {
+		totalDuration += bl.Duration
+		if bl.Level == -1 {
+			breakCount++
+			assert.Equal(t, 10*60, bl.Duration, "break duration is wrong")
+			assert.NotEqual(t, len(options.Levels)-1, idx, "last level should not be a break")
+			continue
+		}
+
+		assert.Greater(t, bl.Blind.BB, prevBB, "bb should increase")
+		assert.Greater(t, bl.Blind.SB, prevSB, "sb should increase")
+		assert.Less(t, bl.Blind.SB, bl.Blind.BB, "sb should be less than bb")
+		assert.Zero(t, bl.Blind.BB%100, "bb should be a multiple of min chip unit")
+		assert.Zero(t, bl.Blind.SB%100, "sb should be a multiple of min chip unit")
+		assert.Zero(t, bl.Ante%100, "ante should be a multiple of min chip unit")
+		if bl.Level < 4 {
+			assert.Zero(t, bl.Ante, "ante should start at level 4")
+		}
+		prevBB, prevSB = bl.Blind.BB, bl.Blind.SB
+	}
+
+	assert.LessOrEqual(t, totalDuration, 8*60*60, "total duration exceeds target")
+	assert.Greater(t, breakCount, 0, "should have breaks")
+	assert.Equal(t, int64(200), options.Levels[0].Blind.BB, "regular should start at 100 bb deep")
+	assert.Equal(t, 6, options.Levels[options.FinalBuyInLevelIndex].Level, "final buy in level is wrong")
+}
+
+func Test_Generator_Presets(t *testing.T) {
+	generate := func(preset GeneratorPreset) *BlindOptions {
+		options, err := Generate(GeneratorOptions{
+			Preset:          preset,
+			StartingStack:   10000,
+			ExpectedEntries: 50,
+			TargetDuration:  3 * 60 * 60,
+			MinChipUnit:     25,
+		})
+		assert.NoError(t, err, "generate blind failed")
+		return options
+	}
+
+	regular := generate(GeneratorPreset_Regular)
+	turbo := generate(GeneratorPreset_Turbo)
+	hyper := generate(GeneratorPreset_Hyper)
+
+	assert.Less(t, len(regular.Levels), len(turbo.Levels), "turbo should have more levels")
+	assert.Less(t, len(turbo.Levels), len(hyper.Levels), "hyper should have more levels")
+	assert.Less(t, regular.Levels[0].Blind.BB, hyper.Levels[0].Blind.BB, "hyper should start shallower")
+	assert.Equal(t, UnsetValue, regular.FinalBuyInLevelIndex, "no late registration by default")
+}
+
+func Test_Generator_InvalidOptions(t *testing.T) {
+	_, err := Generate(GeneratorOptions{
+		StartingStack:   10000,
+		ExpectedEntries: 0,
+		TargetDuration:  3600,
+		MinChipUnit:     25,
+	})
+	assert.ErrorIs(t, err, ErrGeneratorInvalidOptions, "should reject invalid options")
+}
@@ -1,8 +1,9 @@
 package pokerblind
 
 type BlindOptions struct {
-	ID                   string       `json:"id"`
-	InitialLevel         int          `json:"initial_level"`
-	FinalBuyInLevelIndex int          `json:"final_buy_in_level_index"`
-	Levels               []BlindLevel `json:"levels"`
+	ID                   string        `json:"id"`
+	InitialLevel         int           `json:"initial_level"`
+	FinalBuyInLevelIndex int           `json:"final_buy_in_level_index"`
+	HandCountMode        HandCountMode `json:"hand_count_mode"` // 以手數計算等級時的計算方式
+	Levels               []BlindLevel  `json:"levels"`
 }
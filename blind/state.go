@@ -2,8 +2,14 @@ package pokerblind
 
 import "github.com/weedbox/pokerface"
 
+type HandCountMode string
+
 const (
 	UnsetValue = -1
+
+	// HandCountMode
+	HandCountMode_MaxTable     HandCountMode = "max_table"    // 任一桌次達到手數即升級 (取各桌手數最大值)
+	HandCountMode_Synchronized HandCountMode = "synchronized" // 所有進行中桌次皆達到手數才一起升級 (各桌手數分開計算，但全賽事共用同一個盲注等級)
 )
 
 type BlindState struct {
@@ -16,28 +22,35 @@ type BlindState struct {
 }
 
 type Meta struct {
-	InitialLevel         int          `json:"initial_level"`
-	FinalBuyInLevelIndex int          `json:"final_buy_in_level_index"`
-	Levels               []BlindLevel `json:"levels"`
+	InitialLevel         int           `json:"initial_level"`
+	FinalBuyInLevelIndex int           `json:"final_buy_in_level_index"`
+	HandCountMode        HandCountMode `json:"hand_count_mode"`
+	Levels               []BlindLevel  `json:"levels"`
 }
 
 type Status struct {
-	FinalBuyInLevelIndex int     `json:"final_buy_in_level_idx"`
-	CurrentLevelIndex    int     `json:"current_level_index"`
-	LevelEndAts          []int64 `json:"level_end_ats"`
+	FinalBuyInLevelIndex int            `json:"final_buy_in_level_idx"`
+	CurrentLevelIndex    int            `json:"current_level_index"`
+	LevelEndAts          []int64        `json:"level_end_ats"`
+	LevelHandCounts      map[string]int `json:"level_hand_counts"` // 當前等級各桌已完成手數, key: table id, value: hand count
 }
 
 type BlindLevel struct {
-	Level    int                    `json:"level"` // 盲注等級(-1 表示中場休息)
-	Ante     int64                  `json:"ante"`
-	Blind    pokerface.BlindSetting `json:"blind"`
-	Duration int                    `json:"duration"` // 等級持續時間 (Seconds)
+	Level     int                    `json:"level"` // 盲注等級(-1 表示中場休息)
+	Ante      int64                  `json:"ante"`
+	Blind     pokerface.BlindSetting `json:"blind"`
+	Duration  int                    `json:"duration"`   // 等級持續時間 (Seconds)
+	HandCount int                    `json:"hand_count"` // 等級持續手數 (大於 0 時以手數計算，不看 Duration)
 }
 
 func (bs *BlindState) CurrentLevel() BlindLevel {
 	return bs.Meta.Levels[bs.Status.CurrentLevelIndex]
 }
 
+func (bs *BlindState) IsHandCountLevel(levelIdx int) bool {
+	return bs.Meta.Levels[levelIdx].HandCount > 0
+}
+
 func (bs *BlindState) IsActive() bool {
 	return bs.StartedAt != UnsetValue
 }
@@ -0,0 +1,98 @@
+package pokercompetition
+
+import (
+	"math/rand"
+	"sort"
+)
+
+type ColorUp struct {
+	BlindLevelIndex int                      `json:"blind_level_idx"` // 盲注等級索引值 (進入該等級後升級)
+	MinChipUnit     int64                    `json:"min_chip_unit"`   // 升級後最小單位籌碼量
+	Method          CompetitionColorUpMethod `json:"method"`          // 零碎籌碼處理方式, 比籌碼(chip_race), 四捨五入(round_up)
+}
+
+type ColorUpRecord struct {
+	TableID             string                   `json:"table_id"`               // 桌次 ID
+	BlindLevelIndex     int                      `json:"blind_level_idx"`        // 升級時盲注等級索引值
+	PreviousMinChipUnit int64                    `json:"previous_min_chip_unit"` // 升級前最小單位籌碼量
+	MinChipUnit         int64                    `json:"min_chip_unit"`          // 升級後最小單位籌碼量
+	Method              CompetitionColorUpMethod `json:"method"`                 // 零碎籌碼處理方式
+	Players             []ColorUpPlayerResult    `json:"players"`                // 玩家籌碼升級結果
+	CreatedAt           int64                    `json:"created_at"`             // 升級時間 (Seconds)
+}
+
+type ColorUpPlayerResult struct {
+	PlayerID      string `json:"player_id"`      // 玩家 ID
+	OriginalChips int64  `json:"original_chips"` // 升級前籌碼
+	OddChips      int64  `json:"odd_chips"`      // 零碎籌碼 (不足新最小單位的籌碼)
+	Chips         int64  `json:"chips"`          // 升級後籌碼
+}
+
+/*
+ColorUpChips 依新最小單位籌碼量移除玩家零碎籌碼
+  - 比籌碼 (chip_race): 零碎籌碼總和換算成新單位 (過半進位)，每個舊單位零碎籌碼抽一張牌，牌最大者依序各得一個新單位，每人最多一個
+  - 四捨五入 (round_up): 零碎籌碼達新單位一半者得一個新單位，否則移除
+  - 玩家不會因升級被淘汰: 籌碼全為零碎籌碼者保留一個新單位，且不參與比籌碼
+*/
+func ColorUpChips(method CompetitionColorUpMethod, previousMinChipUnit, minChipUnit int64, playerChips map[string]int64) []ColorUpPlayerResult {
+	if previousMinChipUnit <= 0 {
+		previousMinChipUnit = 1
+	}
+
+	playerIDs := make([]string, 0, len(playerChips))
+	for playerID := range playerChips {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+
+	results := make([]ColorUpPlayerResult, 0, len(playerIDs))
+	racers := make([]int, 0) // 需處理零碎籌碼的玩家 results index
+	totalOddChips := int64(0)
+	for _, playerID := range playerIDs {
+		chips := playerChips[playerID]
+		oddChips := chips % minChipUnit
+		result := ColorUpPlayerResult{
+			PlayerID:      playerID,
+			OriginalChips: chips,
+			OddChips:      oddChips,
+			Chips:         chips - oddChips,
+		}
+
+		if oddChips > 0 {
+			if result.Chips == 0 {
+				result.Chips = minChipUnit
+			} else {
+				racers = append(racers, len(results))
+				totalOddChips += oddChips
+			}
+		}
+		results = append(results, result)
+	}
+
+	switch method {
+	case CompetitionColorUpMethod_RoundUp:
+		for _, idx := range racers {
+			if results[idx].OddChips*2 >= minChipUnit {
+				results[idx].Chips += minChipUnit
+			}
+		}
+	default:
+		winnerCount := int((totalOddChips + minChipUnit/2) / minChipUnit)
+		cards := make(map[int]int)
+		for _, idx := range racers {
+			for i := int64(0); i < results[idx].OddChips/previousMinChipUnit || i == 0; i++ {
+				if card := rand.Int(); card > cards[idx] {
+					cards[idx] = card
+				}
+			}
+		}
+		sort.Slice(racers, func(i, j int) bool {
+			return cards[racers[i]] > cards[racers[j]]
+		})
+		for i := 0; i < len(racers) && i < winnerCount; i++ {
+			results[racers[i]].Chips += minChipUnit
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,95 @@
+package pokercompetition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func colorUpResultMap(results []ColorUpPlayerResult) map[string]ColorUpPlayerResult {
+	resultMap := make(map[string]ColorUpPlayerResult)
+	for _, result := range results {
+		resultMap[result.PlayerID] = result
+	}
+	return resultMap
+}
+
+func Test_ColorUp_ChipRace(t *testing.T) {
+	// 零碎籌碼: 75 + 50 + 25 + 50 = 200 -> 新單位 100 共 2 個
+	playerChips := map[string]int64{
+		"p1": 1075,
+		"p2": 2050,
+		"p3": 525,
+		"p4": 350,
+		"p5": 800,
+		"p6": 25, // 只剩零碎籌碼: 保留一個新單位，不參與比籌碼
+	}
+
+	for i := 0; i < 100; i++ {
+		results := colorUpResultMap(ColorUpChips(CompetitionColorUpMethod_ChipRace, 25, 100, playerChips))
+		assert.Len(t, results, len(playerChips))
+
+		winners := 0
+		for playerID, result := range results {
+			assert.Equal(t, playerChips[playerID], result.OriginalChips)
+			assert.Equal(t, playerChips[playerID]%100, result.OddChips)
+			assert.Zero(t, result.Chips%100, "chips should be a multiple of the new unit (%s)", playerID)
+
+			switch playerID {
+			case "p5":
+				assert.Equal(t, int64(800), result.Chips, "player without odd chips should keep chips")
+			case "p6":
+				assert.Equal(t, int64(100), result.Chips, "player with only odd chips should keep one unit")
+			default:
+				base := playerChips[playerID] - result.OddChips
+				if result.Chips == base+100 {
+					winners++
+				} else {
+					assert.Equal(t, base, result.Chips, "racer should win at most one unit (%s)", playerID)
+				}
+			}
+		}
+		assert.Equal(t, 2, winners, "odd chips should be rounded to the nearest unit")
+	}
+}
+
+func Test_ColorUp_ChipRace_RoundsTotal(t *testing.T) {
+	testCases := []struct {
+		name        string
+		playerChips map[string]int64
+		winners     int
+	}{
+		// 25 + 25 = 50 -> 過半進位 1 個
+		{name: "half rounds up", playerChips: map[string]int64{"p1": 125, "p2": 225}, winners: 1},
+		// 25 -> 不足一半 0 個
+		{name: "below half", playerChips: map[string]int64{"p1": 125, "p2": 200}, winners: 0},
+		// 75 x 4 = 300 -> 3 個，每人最多一個
+		{name: "more units than half", playerChips: map[string]int64{"p1": 175, "p2": 275, "p3": 375, "p4": 475}, winners: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			winners := 0
+			for _, result := range ColorUpChips(CompetitionColorUpMethod_ChipRace, 25, 100, tc.playerChips) {
+				if result.Chips > result.OriginalChips-result.OddChips {
+					winners++
+				}
+			}
+			assert.Equal(t, tc.winners, winners)
+		})
+	}
+}
+
+func Test_ColorUp_RoundUp(t *testing.T) {
+	results := colorUpResultMap(ColorUpChips(CompetitionColorUpMethod_RoundUp, 25, 100, map[string]int64{
+		"p1": 1075,
+		"p2": 2050,
+		"p3": 525,
+		"p4": 50,
+	}))
+
+	assert.Equal(t, int64(1100), results["p1"].Chips)
+	assert.Equal(t, int64(2100), results["p2"].Chips)
+	assert.Equal(t, int64(500), results["p3"].Chips)
+	assert.Equal(t, int64(100), results["p4"].Chips)
+}
@@ -11,6 +11,11 @@ type CompetitionStateStatus string
 type CompetitionPlayerStatus string
 type CompetitionMode string
 type CompetitionRule string
+type CompetitionBettingStructure string
+type CompetitionRuleRotationTrigger string
+type CompetitionAnteMode string
+type CompetitionColorUpMethod string
+type CompetitionBalancingStrategy string
 type CompetitionAdvanceRule string
 type CompetitionAdvanceStatus string
 
@@ -42,6 +47,29 @@ const (
 	CompetitionRule_ShortDeck CompetitionRule = "short_deck" // 短牌
 	CompetitionRule_Omaha     CompetitionRule = "omaha"      // 奧瑪哈
 
+	// CompetitionBettingStructure
+	CompetitionBettingStructure_NoLimit    CompetitionBettingStructure = "no_limit"    // 無限注
+	CompetitionBettingStructure_PotLimit   CompetitionBettingStructure = "pot_limit"   // 底池限注
+	CompetitionBettingStructure_FixedLimit CompetitionBettingStructure = "fixed_limit" // 固定限注
+
+	// CompetitionAnteMode
+	CompetitionAnteMode_Classic  CompetitionAnteMode = "classic"   // 一般前注: 每位玩家支付前注
+	CompetitionAnteMode_BigBlind CompetitionAnteMode = "big_blind" // 大盲前注: 大盲位置支付全桌前注
+	CompetitionAnteMode_Button   CompetitionAnteMode = "button"    // 按鈕前注: Dealer 位置支付全桌前注
+
+	// CompetitionColorUpMethod
+	CompetitionColorUpMethod_ChipRace CompetitionColorUpMethod = "chip_race" // 比籌碼
+	CompetitionColorUpMethod_RoundUp  CompetitionColorUpMethod = "round_up"  // 四捨五入
+
+	// CompetitionBalancingStrategy
+	CompetitionBalancingStrategy_TDA               CompetitionBalancingStrategy = "tda"                 // TDA 標準拆併桌
+	CompetitionBalancingStrategy_BreakHighestTable CompetitionBalancingStrategy = "break_highest_table" // 依桌號由大到小拆桌
+	CompetitionBalancingStrategy_MinimizeMoves     CompetitionBalancingStrategy = "minimize_moves"      // 最少移動玩家數
+
+	// CompetitionRuleRotationTrigger
+	CompetitionRuleRotationTrigger_BlindLevel CompetitionRuleRotationTrigger = "blind_level" // 混合賽制輪替時機: 盲注等級變更
+	CompetitionRuleRotationTrigger_HandCount  CompetitionRuleRotationTrigger = "hand_count"  // 混合賽制輪替時機: 每 N 手
+
 	// CompetitionAdvanceRule
 	CompetitionAdvanceRule_PlayerCount CompetitionAdvanceRule = "player_count" // 晉級方式: 尚未淘汰玩家人數
 	CompetitionAdvanceRule_BlindLevel  CompetitionAdvanceRule = "blind_level"  // 晉級方式: 盲注等級
@@ -61,34 +89,47 @@ type Competition struct {
 }
 
 type CompetitionMeta struct {
-	Blind                          Blind           `json:"blind"`                              // 盲注資訊
-	MaxDuration                    int             `json:"max_duration"`                       // 比賽時間總長 (Seconds)
-	MinPlayerCount                 int             `json:"min_player_count"`                   // 最小參賽人數
-	MaxPlayerCount                 int             `json:"max_player_count"`                   // 最大參賽人數
-	TableMaxSeatCount              int             `json:"table_max_seat_count"`               // 每桌人數上限
-	TableMinPlayerCount            int             `json:"table_min_player_count"`             // 每桌最小開打數
-	RegulatorMinInitialPlayerCount int             `json:"regulator_min_initial_player_count"` // 拆併桌每桌至少需要人數
-	Rule                           CompetitionRule `json:"rule"`                               // 德州撲克規則, 常牌(default), 短牌(short_deck), 奧瑪哈(omaha)
-	Mode                           CompetitionMode `json:"mode"`                               // 賽事模式 (CT, MTT, Cash)
-	ReBuySetting                   ReBuySetting    `json:"re_buy_setting"`                     // 補碼設定
-	AddonSetting                   AddonSetting    `json:"addon_setting"`                      // 增購設定
-	AdvanceSetting                 AdvanceSetting  `json:"advance_setting"`                    // 晉級設定
-	ActionTime                     int             `json:"action_time"`                        // 思考時間 (Seconds)
-	MinChipUnit                    int64           `json:"min_chip_unit"`                      // 最小單位籌碼量
+	Blind                          Blind                        `json:"blind"`                              // 盲注資訊
+	MaxDuration                    int                          `json:"max_duration"`                       // 比賽時間總長 (Seconds)
+	MinPlayerCount                 int                          `json:"min_player_count"`                   // 最小參賽人數
+	MaxPlayerCount                 int                          `json:"max_player_count"`                   // 最大參賽人數
+	TableMaxSeatCount              int                          `json:"table_max_seat_count"`               // 每桌人數上限
+	TableMinPlayerCount            int                          `json:"table_min_player_count"`             // 每桌最小開打數
+	RegulatorMinInitialPlayerCount int                          `json:"regulator_min_initial_player_count"` // 拆併桌每桌至少需要人數
+	BalancingStrategy              CompetitionBalancingStrategy `json:"balancing_strategy"`                 // MTT 拆併桌策略, TDA 標準(tda, default), 依桌號由大到小拆桌(break_highest_table), 最少移動玩家數(minimize_moves)
+	Rule                           CompetitionRule              `json:"rule"`                               // 德州撲克規則, 常牌(default), 短牌(short_deck), 奧瑪哈(omaha)
+	BettingStructure               CompetitionBettingStructure  `json:"betting_structure"`                  // 下注結構, 無限注(no_limit), 底池限注(pot_limit), 固定限注(fixed_limit)
+	RuleRotation                   RuleRotation                 `json:"rule_rotation"`                      // 混合賽制輪替設定
+	Mode                           CompetitionMode              `json:"mode"`                               // 賽事模式 (CT, MTT, Cash)
+	ReBuySetting                   ReBuySetting                 `json:"re_buy_setting"`                     // 補碼設定
+	AddonSetting                   AddonSetting                 `json:"addon_setting"`                      // 增購設定
+	AdvanceSetting                 AdvanceSetting               `json:"advance_setting"`                    // 晉級設定
+	ActionTime                     int                          `json:"action_time"`                        // 思考時間 (Seconds)
+	MinChipUnit                    int64                        `json:"min_chip_unit"`                      // 最小單位籌碼量
+	ColorUps                       []ColorUp                    `json:"color_ups"`                          // 籌碼升級設定 (提高最小單位籌碼量)
+	ForfeitPolicy                  ForfeitPolicy                `json:"forfeit_policy"`                     // MTT 棄賽籌碼處理, 移除籌碼(remove_chips, default), 盲注耗盡(blind_off)
+	RefundPolicy                   RefundPolicy                 `json:"refund_policy"`                      // 開賽後退賽規則
 }
 
 type CompetitionState struct {
-	OpenAt       int64                  `json:"open_at"`       // 賽事建立時間 (可報名、尚未開打)
-	DisableAt    int64                  `json:"disable_at"`    // 賽事未開打前，賽局可見時間 (Seconds)
-	StartAt      int64                  `json:"start_at"`      // 賽事開打時間 (可報名、開打) (Seconds)
-	EndAt        int64                  `json:"end_at"`        // 賽事結束時間 (Seconds)
-	BlindState   *BlindState            `json:"blind_state"`   // 盲注狀態
-	Players      []*CompetitionPlayer   `json:"players"`       // 參與過賽事玩家陣列
-	Status       CompetitionStateStatus `json:"status"`        // 賽事狀態
-	Tables       []*pokertable.Table    `json:"tables"`        // 多桌
-	Rankings     []*CompetitionRank     `json:"rankings"`      // 停止買入後玩家排名 (陣列 Index 即是排名 rank - 1, ex: index 0 -> 第一名, index 1 -> 第二名...)
-	AdvanceState *AdvanceState          `json:"advance_state"` // 晉級狀態
-	Statistic    *Statistic             `json:"statistic"`     // 賽事統計資料
+	OpenAt            int64                   `json:"open_at"`            // 賽事建立時間 (可報名、尚未開打)
+	DisableAt         int64                   `json:"disable_at"`         // 賽事未開打前，賽局可見時間 (Seconds)
+	StartAt           int64                   `json:"start_at"`           // 賽事開打時間 (可報名、開打) (Seconds)
+	EndAt             int64                   `json:"end_at"`             // 賽事結束時間 (Seconds)
+	BlindState        *BlindState             `json:"blind_state"`        // 盲注狀態
+	Players           []*CompetitionPlayer    `json:"players"`            // 參與過賽事玩家陣列
+	Status            CompetitionStateStatus  `json:"status"`             // 賽事狀態
+	Tables            []*pokertable.Table     `json:"tables"`             // 多桌
+	Rankings          []*CompetitionRank      `json:"rankings"`           // 停止買入後玩家排名 (陣列 Index 即是排名 rank - 1, ex: index 0 -> 第一名, index 1 -> 第二名...)
+	AdvanceState      *AdvanceState           `json:"advance_state"`      // 晉級狀態
+	AdvancementResult *AdvancementResult      `json:"advancement_result"` // 晉級結果 (晉級結束後產生)
+	Statistic         *Statistic              `json:"statistic"`          // 賽事統計資料
+	ColorUps          []*ColorUpRecord        `json:"color_ups"`          // 籌碼升級紀錄
+	SeatingViolations []*SeatingViolation     `json:"seating_violations"` // 無法避免的座位限制違反紀錄
+	TableInfos        []*TableInfo            `json:"table_infos"`        // 桌號與焦點桌資訊
+	Alternates        []*CompetitionAlternate `json:"alternates"`         // MTT 候補名單 (依候補順序)
+	Refunds           []*RefundRecord         `json:"refunds"`            // 退賽紀錄
+	DirectorAudits    []*DirectorAuditRecord  `json:"director_audits"`    // 裁判操作紀錄
 }
 
 type CompetitionRank struct {
@@ -104,6 +145,12 @@ type CompetitionPlayer struct {
 	ReBuyWaitingAt int64  `json:"rebuy_waiting_at"` // 補碼等待時間 (Seconds)
 	KnockoutAt     int64  `json:"knockout_at"`      // 淘汰時間 (Seconds)
 
+	// seating info
+	Seating SeatingMetadata `json:"seating"` // 座位限制資訊
+
+	// director info
+	Penalty *PlayerPenalty `json:"penalty"` // 裁判處罰 (沒有處罰時為 nil)
+
 	// current info
 	Status          CompetitionPlayerStatus `json:"status"`           // 參與玩家狀態
 	Rank            int                     `json:"rank"`             // 當前桌次排名
@@ -112,8 +159,11 @@ type CompetitionPlayer struct {
 
 	Chips           int64 `json:"chips"`              // 當前籌碼
 	IsReBuying      bool  `json:"is_re_buying"`       // 是否正在補碼
+	IsForfeited     bool  `json:"is_forfeited"`       // 是否已棄賽 (MTT, 不可再補碼)
+	IsDisqualified  bool  `json:"is_disqualified"`    // 是否被裁判取消資格
 	ReBuyEndAt      int64 `json:"re_buy_end_at"`      // 最後補碼時間 (Seconds)
 	ReBuyTimes      int   `json:"re_buy_times"`       // 補碼次數
+	ReBuyUnits      int   `json:"re_buy_units"`       // 補碼發數 (ex: double re-buy 計兩發)
 	AddonTimes      int   `json:"addon_times"`        // 增購次數
 	TotalBuyInUnits int   `json:"total_buy_in_units"` // 總買入發數
 
@@ -164,11 +214,13 @@ type CompetitionPlayer struct {
 }
 
 type Blind struct {
-	ID                   string       `json:"id"`                     // ID
-	InitialLevel         int          `json:"initial_level"`          // 起始盲注級別
-	FinalBuyInLevelIndex int          `json:"final_buy_in_level_idx"` // 最後買入盲注等級索引值
-	DealerBlindTime      int          `json:"dealer_blind_time"`      // Dealer 位置要收取的前注倍數 (短牌用)
-	Levels               []BlindLevel `json:"levels"`                 // 級別資訊列表
+	ID                   string              `json:"id"`                     // ID
+	InitialLevel         int                 `json:"initial_level"`          // 起始盲注級別
+	FinalBuyInLevelIndex int                 `json:"final_buy_in_level_idx"` // 最後買入盲注等級索引值
+	DealerBlindTime      int                 `json:"dealer_blind_time"`      // Dealer 位置要收取的前注倍數 (短牌用)
+	AnteMode             CompetitionAnteMode `json:"ante_mode"`              // 前注模式 (預設為一般前注)
+	HandCountMode        string              `json:"hand_count_mode"`        // 以手數計算等級時的計算方式 (max_table: 取各桌手數最大值, synchronized: 所有桌次皆須達到後一起升級)
+	Levels               []BlindLevel        `json:"levels"`                 // 級別資訊列表
 }
 
 type BlindLevel struct {
@@ -177,6 +229,7 @@ type BlindLevel struct {
 	BB         int64 `json:"bb"`          // 大盲籌碼量
 	Ante       int64 `json:"ante"`        // 前注籌碼量
 	Duration   int   `json:"duration"`    // 等級持續時間 (Seconds)
+	HandCount  int   `json:"hand_count"`  // 等級持續手數 (大於 0 時以手數計算，不看 Duration)
 	AllowAddon bool  `json:"allow_addon"` // 是否允許增購
 }
 
@@ -191,6 +244,12 @@ type AdvanceState struct {
 	TotalTables     int                      `json:"total_tables"`      // 總桌數
 	UpdatedTables   int                      `json:"updated_tables"`    // 已更新桌數
 	UpdatedTableIDs []string                 `json:"updated_table_ids"` // 已更新桌次 ID
+
+	// 晉級人數 (M 取 N)
+	IsHandForHand       bool             `json:"is_hand_for_hand"`        // 是否逐手進行
+	HandForHandRound    int              `json:"hand_for_hand_round"`     // 逐手進行輪次
+	HandForHandTableIDs []string         `json:"hand_for_hand_table_ids"` // 本輪已完成的桌次 ID
+	AdvancePlayers      []*AdvancePlayer `json:"advance_players"`         // 晉級玩家名單 (依名次排序)
 }
 
 type Statistic struct {
@@ -200,23 +259,45 @@ type Statistic struct {
 	WaitingTableBalancingPlayerCount int `json:"waiting_table_balancing_player_count"` // 現在有籌碼且等待拆併桌中的玩家數
 	KnockoutPlayerCount              int `json:"knockout_player_count"`                // 現在沒有籌碼且淘汰玩家數 (不能再 Re Buy)
 	ReBuyWaitingPlayerCount          int `json:"re_buy_waiting_player_count"`          // 現在沒有籌碼且等待補碼中的玩家數 (能再 Re Buy)
+	TotalRefundCount                 int `json:"total_refund_count"`                   // 總退賽人次
+	TotalRefundUnits                 int `json:"total_refund_units"`                   // 總退還買入發數
 }
 
 type ReBuySetting struct {
-	MaxTime     int `json:"max_time"`     // 最大次數
-	WaitingTime int `json:"waiting_time"` // 玩家可補碼時間 (Seconds)
+	MaxTime          int       `json:"max_time"`            // 最大次數 (double re-buy 計一次)
+	WaitingTime      int       `json:"waiting_time"`        // 玩家可補碼時間 (Seconds)
+	MTTWaitingTime   int       `json:"mtt_waiting_time"`    // MTT 玩家可補碼時間 (Seconds)，逾時直接淘汰 (0 表示可補碼至停止買入)
+	Rule             ReBuyRule `json:"rule"`                // 補碼規則, 沒有籌碼(bust, default), 籌碼小於等於門檻(at_or_below_stack)
+	StackThreshold   int64     `json:"stack_threshold"`     // 可補碼籌碼門檻 (at_or_below_stack 用，通常為起始籌碼)
+	MaxUnitsPerReBuy int       `json:"max_units_per_rebuy"` // 單次補碼最大發數 (ex: 2 表示可 double re-buy, 0 表示不限制)
 }
 
 type AddonSetting struct {
-	IsBreakOnly bool    `json:"is_break_only"` // 是否中場休息限定
-	RedeemChips []int64 `json:"redeem_chips"`  // 可兌換籌碼數
-	MaxTime     int     `json:"max_time"`      // 最大次數
+	IsBreakOnly bool           `json:"is_break_only"` // 是否中場休息限定
+	RedeemChips []int64        `json:"redeem_chips"`  // 可兌換籌碼數 (未設定方案時使用)
+	MaxTime     int            `json:"max_time"`      // 最大次數
+	Packages    []AddonPackage `json:"packages"`      // 增購方案 (設定後依方案驗證兌換籌碼數與購買資格)
+}
+
+type RuleRotation struct {
+	Trigger   CompetitionRuleRotationTrigger `json:"trigger"`    // 輪替時機, 盲注等級變更(blind_level), 每 N 手(hand_count)
+	HandCount int                            `json:"hand_count"` // 每幾手輪替一次 (hand_count 用)
+	Games     []RuleRotationGame             `json:"games"`      // 輪替賽制列表 (依序輪替)
+}
+
+type RuleRotationGame struct {
+	Rule             CompetitionRule             `json:"rule"`              // 德州撲克規則, 常牌(default), 短牌(short_deck), 奧瑪哈(omaha)
+	BettingStructure CompetitionBettingStructure `json:"betting_structure"` // 下注結構 (未設定時使用賽事下注結構)
+	BlindPercent     int                         `json:"blind_percent"`     // 大小盲注百分比 (0 表示不調整)
+	AntePercent      int                         `json:"ante_percent"`      // 前注百分比 (0 表示不調整, -1 表示不收前注)
 }
 
 type AdvanceSetting struct {
 	Rule        CompetitionAdvanceRule `json:"rule"`         // 晉級方式
 	PlayerCount int                    `json:"player_count"` // 晉級人數
 	BlindLevel  int                    `json:"blind_level"`  // 晉級盲注級別
+
+	HandForHandPlayerCount int `json:"hand_for_hand_player_count"` // 存活人數距離晉級人數幾人內開始逐手進行 (0 表示以每桌座位數為準)
 }
 
 // Competition Setters
@@ -279,6 +360,65 @@ func (c Competition) CurrentBlindLevel() BlindLevel {
 	return c.Meta.Blind.Levels[c.State.BlindState.CurrentLevelIndex]
 }
 
+/*
+CurrentMinChipUnit 取得當前最小單位籌碼量 (已套用已到達等級的籌碼升級設定)
+*/
+func (c Competition) CurrentMinChipUnit() int64 {
+	minChipUnit := c.Meta.MinChipUnit
+	for _, colorUp := range c.Meta.ColorUps {
+		if colorUp.BlindLevelIndex <= c.State.BlindState.CurrentLevelIndex && colorUp.MinChipUnit > minChipUnit {
+			minChipUnit = colorUp.MinChipUnit
+		}
+	}
+	return minChipUnit
+}
+
+/*
+CurrentColorUp 取得當前盲注等級 (含之前) 最近一次籌碼升級設定
+*/
+func (c Competition) CurrentColorUp() (ColorUp, bool) {
+	current := ColorUp{BlindLevelIndex: UnsetValue}
+	for _, colorUp := range c.Meta.ColorUps {
+		if colorUp.BlindLevelIndex <= c.State.BlindState.CurrentLevelIndex && colorUp.BlindLevelIndex > current.BlindLevelIndex {
+			current = colorUp
+		}
+	}
+	return current, current.BlindLevelIndex != UnsetValue
+}
+
+/*
+TableMinChipUnit 取得桌次目前使用的最小單位籌碼量
+  - 以該桌最後一次籌碼升級紀錄為準，未升級過則為建桌時的設定
+*/
+func (c Competition) TableMinChipUnit(tableID string) int64 {
+	for i := len(c.State.ColorUps) - 1; i >= 0; i-- {
+		if c.State.ColorUps[i].TableID == tableID {
+			return c.State.ColorUps[i].MinChipUnit
+		}
+	}
+
+	if tableIdx := c.FindTableIdx(func(t *pokertable.Table) bool {
+		return t.ID == tableID
+	}); tableIdx != UnsetValue {
+		return c.State.Tables[tableIdx].Meta.MinChipUnit
+	}
+	return c.Meta.MinChipUnit
+}
+
+/*
+CurrentAnteMode 取得前注模式 (未設定時為一般前注)
+*/
+func (c Competition) CurrentAnteMode() CompetitionAnteMode {
+	if c.Meta.Blind.AnteMode == "" {
+		return CompetitionAnteMode_Classic
+	}
+	return c.Meta.Blind.AnteMode
+}
+
+/*
+CurrentBlindData 取得當前盲注資訊 (level, ante, dealer, sb, bb)
+  - ante 為每位玩家的前注量，大盲前注/按鈕前注由該位置支付 ante x 本手玩家數 (見 GetAntePayment)
+*/
 func (c Competition) CurrentBlindData() (int, int64, int64, int64, int64) {
 	bl := c.CurrentBlindLevel()
 	dealer := int64(0)
@@ -288,6 +428,113 @@ func (c Competition) CurrentBlindData() (int, int64, int64, int64, int64) {
 	return bl.Level, bl.Ante, dealer, bl.SB, bl.BB
 }
 
+/*
+CurrentRuleRotationGame 取得當前輪替賽制
+  - 盲注等級變更: 依開賽後經過的非中場休息盲注等級數輪替
+  - 每 N 手: 依該桌次已進行手數輪替 (tableGameCount 為該桌已完成手數)
+*/
+func (c Competition) CurrentRuleRotationGame(tableGameCount int) (RuleRotationGame, bool) {
+	rotation := c.Meta.RuleRotation
+	if len(rotation.Games) == 0 {
+		return RuleRotationGame{}, false
+	}
+
+	rotationCount := 0
+	switch rotation.Trigger {
+	case CompetitionRuleRotationTrigger_BlindLevel:
+		for idx := 0; idx < c.State.BlindState.CurrentLevelIndex && idx < len(c.Meta.Blind.Levels); idx++ {
+			if c.Meta.Blind.Levels[idx].Level != -1 {
+				rotationCount++
+			}
+		}
+	case CompetitionRuleRotationTrigger_HandCount:
+		if rotation.HandCount <= 0 {
+			return RuleRotationGame{}, false
+		}
+		rotationCount = tableGameCount / rotation.HandCount
+	default:
+		return RuleRotationGame{}, false
+	}
+
+	return rotation.Games[rotationCount%len(rotation.Games)], true
+}
+
+/*
+CurrentTableRule 取得桌次下一手要使用的德州撲克規則
+*/
+func (c Competition) CurrentTableRule(tableGameCount int) CompetitionRule {
+	if game, ok := c.CurrentRuleRotationGame(tableGameCount); ok {
+		return game.Rule
+	}
+	return c.Meta.Rule
+}
+
+/*
+CurrentTableBettingStructure 取得桌次下一手要使用的下注結構
+  - 輪替賽制有設定下注結構時使用輪替賽制的設定，否則使用賽事設定 (未設定時為無限注)
+*/
+func (c Competition) CurrentTableBettingStructure(tableGameCount int) CompetitionBettingStructure {
+	if game, ok := c.CurrentRuleRotationGame(tableGameCount); ok && game.BettingStructure != "" {
+		return game.BettingStructure
+	}
+	if c.Meta.BettingStructure == "" {
+		return CompetitionBettingStructure_NoLimit
+	}
+	return c.Meta.BettingStructure
+}
+
+/*
+TableSeatingRule 取得建桌時使用的德州撲克規則
+  - 座位管理 (按鈕、盲注位置) 於建桌時依規則決定，常牌/奧瑪哈座位可進行三種規則，短牌座位只能進行短牌
+  - 混合賽制輪替包含短牌以外的規則時，以常牌座位建桌，各手規則於兩手之間更新 (見 CurrentTableRule)
+*/
+func (c Competition) TableSeatingRule() CompetitionRule {
+	rule := c.CurrentTableRule(0)
+	if rule != CompetitionRule_ShortDeck {
+		return rule
+	}
+
+	for _, game := range c.Meta.RuleRotation.Games {
+		if game.Rule != CompetitionRule_ShortDeck {
+			return CompetitionRule_Default
+		}
+	}
+	return rule
+}
+
+/*
+CurrentTableBlindData 取得桌次下一手要使用的盲注資訊 (已套用輪替賽制的盲注/前注調整)
+  - 輪替到短牌時只收 Dealer 盲注，不收大小盲
+*/
+func (c Competition) CurrentTableBlindData(tableGameCount int) (int, int64, int64, int64, int64) {
+	level, ante, dealer, sb, bb := c.CurrentBlindData()
+	game, ok := c.CurrentRuleRotationGame(tableGameCount)
+	if !ok {
+		return level, ante, dealer, sb, bb
+	}
+
+	if game.BlindPercent > 0 {
+		sb = sb * int64(game.BlindPercent) / 100
+		bb = bb * int64(game.BlindPercent) / 100
+	}
+
+	// Dealer 前注倍數僅適用短牌
+	if game.Rule == CompetitionRule_ShortDeck {
+		sb, bb = 0, 0
+	} else {
+		dealer = 0
+	}
+
+	if game.AntePercent == UnsetValue {
+		ante, dealer = 0, 0
+	} else if game.AntePercent > 0 {
+		ante = ante * int64(game.AntePercent) / 100
+		dealer = dealer * int64(game.AntePercent) / 100
+	}
+
+	return level, ante, dealer, sb, bb
+}
+
 func (c Competition) IsBreaking() bool {
 	return c.CurrentBlindLevel().Level == -1
 }
@@ -0,0 +1,223 @@
+package pokercompetition
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	pokerjsonpatch "github.com/weedbox/pokercompetition/jsonpatch"
+)
+
+var (
+	ErrCompetitionDeltaGap       = errors.New("competition: delta update gap detected")
+	ErrCompetitionDeltaNotSynced = errors.New("competition: delta client is waiting for a snapshot")
+)
+
+const defaultDeltaSnapshotInterval = 50
+
+/*
+CompetitionDelta 賽事增量更新
+  - 快照: Snapshot 為完整賽事資料
+  - 增量: Patch 為由 BaseSerial 版本轉換為 UpdateSerial 版本的 RFC 6902 JSON Patch
+*/
+type CompetitionDelta struct {
+	CompetitionID string                     `json:"competition_id"`     // 賽事 ID
+	BaseSerial    int64                      `json:"base_serial"`        // 套用 patch 前的更新序列號 (快照時為 UnsetValue)
+	UpdateSerial  int64                      `json:"update_serial"`      // 套用 patch 後的更新序列號
+	IsSnapshot    bool                       `json:"is_snapshot"`        // 是否為完整快照
+	Snapshot      json.RawMessage            `json:"snapshot,omitempty"` // 完整賽事資料 (快照時)
+	Patch         []pokerjsonpatch.Operation `json:"patch,omitempty"`    // JSON Patch (增量時)
+	UpdateAt      int64                      `json:"update_at"`          // 更新時間 (Seconds)
+}
+
+type competitionDeltaState struct {
+	mu                   sync.Mutex
+	snapshotInterval     int         // 每 N 次更新發送一次完整快照
+	doc                  interface{} // 上一次發送的賽事資料
+	updateSerial         int64       // 上一次發送的更新序列號
+	updatesSinceSnapshot int         // 上一次快照後的增量更新次數
+	isSnapshotRequested  bool        // 下一次更新發送完整快照
+}
+
+// WithDeltaSnapshotInterval 設定增量更新每 N 次發送一次完整快照
+func WithDeltaSnapshotInterval(interval int) CompetitionEngineOpt {
+	return func(ce *competitionEngine) {
+		if interval > 0 {
+			ce.deltaState.snapshotInterval = interval
+		}
+	}
+}
+
+/*
+emitCompetitionDelta 發送賽事增量更新
+  - 適用時機: 賽事更新 (emitEvent)
+  - 第一次更新、達到快照間隔、被要求快照或 patch 比快照大時發送完整快照，其餘發送 JSON Patch
+*/
+func (ce *competitionEngine) emitCompetitionDelta() {
+	state := &ce.deltaState
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if ce.onCompetitionDeltaUpdated == nil {
+		return
+	}
+
+	data, err := json.Marshal(ce.competition)
+	if err != nil {
+		ce.emitErrorEvent("emitCompetitionDelta -> Marshal", "", err)
+		return
+	}
+	doc, err := pokerjsonpatch.Decode(data)
+	if err != nil {
+		ce.emitErrorEvent("emitCompetitionDelta -> Decode", "", err)
+		return
+	}
+
+	updateSerial := ce.competition.UpdateSerial
+	if state.doc != nil && updateSerial <= state.updateSerial && !state.isSnapshotRequested {
+		// 此版本已發送
+		return
+	}
+
+	delta := CompetitionDelta{
+		CompetitionID: ce.competition.ID,
+		BaseSerial:    state.updateSerial,
+		UpdateSerial:  updateSerial,
+		UpdateAt:      time.Now().Unix(),
+	}
+
+	isSnapshot := state.doc == nil || state.isSnapshotRequested || state.updatesSinceSnapshot+1 >= state.snapshotInterval
+	if !isSnapshot {
+		delta.Patch = pokerjsonpatch.Diff(state.doc, doc)
+
+		// patch 比完整快照還大時改發送快照
+		if patchData, err := json.Marshal(delta.Patch); err != nil || len(patchData) >= len(data) {
+			delta.Patch = nil
+			isSnapshot = true
+		}
+	}
+
+	if isSnapshot {
+		delta.BaseSerial = UnsetValue
+		delta.IsSnapshot = true
+		delta.Snapshot = data
+		state.updatesSinceSnapshot = 0
+		state.isSnapshotRequested = false
+	} else {
+		state.updatesSinceSnapshot++
+	}
+
+	state.doc = doc
+	state.updateSerial = updateSerial
+	ce.onCompetitionDeltaUpdated(delta)
+}
+
+/*
+RequestDeltaSnapshot 立即發送完整快照
+  - 適用時機: 用戶端偵測到增量更新缺漏
+*/
+func (ce *competitionEngine) RequestDeltaSnapshot() {
+	ce.deltaState.mu.Lock()
+	ce.deltaState.isSnapshotRequested = true
+	ce.deltaState.mu.Unlock()
+
+	ce.emitCompetitionDelta()
+}
+
+/*
+CompetitionDeltaClient 用戶端套用賽事增量更新
+  - 以快照初始化，之後依序套用 patch
+  - BaseSerial 與目前版本不符時視為缺漏，需等待 (或要求) 下一次快照
+*/
+type CompetitionDeltaClient struct {
+	mu           sync.RWMutex
+	doc          interface{}
+	updateSerial int64
+	isSynced     bool
+}
+
+func NewCompetitionDeltaClient() *CompetitionDeltaClient {
+	return &CompetitionDeltaClient{
+		updateSerial: UnsetValue,
+	}
+}
+
+/*
+Apply 套用增量更新
+  - 已套用過的版本直接略過
+  - 偵測到缺漏時回傳 ErrCompetitionDeltaGap，之後的增量更新回傳 ErrCompetitionDeltaNotSynced 直到收到快照
+*/
+func (c *CompetitionDeltaClient) Apply(delta CompetitionDelta) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if delta.IsSnapshot {
+		doc, err := pokerjsonpatch.Decode(delta.Snapshot)
+		if err != nil {
+			c.isSynced = false
+			return err
+		}
+		c.doc = doc
+		c.updateSerial = delta.UpdateSerial
+		c.isSynced = true
+		return nil
+	}
+
+	if !c.isSynced {
+		return ErrCompetitionDeltaNotSynced
+	}
+
+	if delta.UpdateSerial <= c.updateSerial {
+		return nil
+	}
+
+	if delta.BaseSerial != c.updateSerial {
+		c.isSynced = false
+		return ErrCompetitionDeltaGap
+	}
+
+	doc, err := pokerjsonpatch.Apply(c.doc, delta.Patch)
+	if err != nil {
+		c.isSynced = false
+		return err
+	}
+	c.doc = doc
+	c.updateSerial = delta.UpdateSerial
+	return nil
+}
+
+// IsSynced 是否已與賽事同步 (false 表示需要快照)
+func (c *CompetitionDeltaClient) IsSynced() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.isSynced
+}
+
+// UpdateSerial 目前套用的更新序列號
+func (c *CompetitionDeltaClient) UpdateSerial() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.updateSerial
+}
+
+// Competition 取得目前套用的賽事資料
+func (c *CompetitionDeltaClient) Competition() (*Competition, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.isSynced {
+		return nil, ErrCompetitionDeltaNotSynced
+	}
+
+	data, err := json.Marshal(c.doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var competition Competition
+	if err := json.Unmarshal(data, &competition); err != nil {
+		return nil, err
+	}
+	return &competition, nil
+}
@@ -0,0 +1,184 @@
+package pokercompetition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newDeltaTestEngine 建立每 interval 次更新發送一次快照的賽事，回傳收到的增量更新
+func newDeltaTestEngine(interval int) (*competitionEngine, *[]CompetitionDelta) {
+	ce := newTestCompetitionEngine(newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_Playing))
+	WithDeltaSnapshotInterval(interval)(ce)
+
+	deltas := make([]CompetitionDelta, 0)
+	ce.OnCompetitionDeltaUpdated(func(delta CompetitionDelta) {
+		deltas = append(deltas, delta)
+	})
+	return ce, &deltas
+}
+
+func Test_CompetitionDelta_SnapshotInterval(t *testing.T) {
+	ce, deltas := newDeltaTestEngine(3)
+	client := NewCompetitionDeltaClient()
+
+	for i := 0; i < 6; i++ {
+		ce.competition.State.Players[0].Chips += 100
+		ce.emitEvent("chips updated", "p1")
+	}
+
+	// 快照、增量、增量、快照...
+	if !assert.Len(t, *deltas, 6) {
+		return
+	}
+	for idx, delta := range *deltas {
+		isSnapshot := idx%3 == 0
+		assert.Equal(t, isSnapshot, delta.IsSnapshot, "delta %d", idx)
+		assert.Equal(t, "c1", delta.CompetitionID)
+		assert.Equal(t, int64(idx+1), delta.UpdateSerial)
+		if isSnapshot {
+			assert.Equal(t, int64(UnsetValue), delta.BaseSerial)
+			assert.NotEmpty(t, delta.Snapshot)
+			assert.Empty(t, delta.Patch)
+		} else {
+			assert.Equal(t, int64(idx), delta.BaseSerial)
+			assert.Empty(t, delta.Snapshot)
+			assert.NotEmpty(t, delta.Patch)
+		}
+		assert.NoError(t, client.Apply(delta))
+	}
+
+	competition, err := client.Competition()
+	if assert.NoError(t, err) {
+		assert.Equal(t, ce.competition.UpdateSerial, competition.UpdateSerial)
+		assert.Equal(t, int64(1600), competition.State.Players[0].Chips)
+	}
+
+	// 沒有更新時不重複發送
+	ce.emitCompetitionDelta()
+	assert.Len(t, *deltas, 6)
+}
+
+func Test_CompetitionDelta_ClientGap(t *testing.T) {
+	ce, deltas := newDeltaTestEngine(100)
+	for i := 0; i < 4; i++ {
+		ce.competition.State.Players[0].Chips += 100
+		ce.emitEvent("chips updated", "p1")
+	}
+	if !assert.Len(t, *deltas, 4) {
+		return
+	}
+
+	// 收到快照前無法套用增量
+	client := NewCompetitionDeltaClient()
+	assert.ErrorIs(t, client.Apply((*deltas)[1]), ErrCompetitionDeltaNotSynced)
+	assert.False(t, client.IsSynced())
+	assert.Equal(t, int64(UnsetValue), client.UpdateSerial())
+
+	assert.NoError(t, client.Apply((*deltas)[0]))
+	assert.NoError(t, client.Apply((*deltas)[1]))
+	assert.Equal(t, int64(2), client.UpdateSerial())
+
+	// 已套用過的版本略過
+	assert.NoError(t, client.Apply((*deltas)[1]))
+	assert.Equal(t, int64(2), client.UpdateSerial())
+
+	// 缺少版本 3
+	assert.ErrorIs(t, client.Apply((*deltas)[3]), ErrCompetitionDeltaGap)
+	assert.False(t, client.IsSynced())
+	assert.Equal(t, int64(2), client.UpdateSerial())
+	_, err := client.Competition()
+	assert.ErrorIs(t, err, ErrCompetitionDeltaNotSynced)
+
+	// 要求快照後重新同步
+	assert.ErrorIs(t, client.Apply((*deltas)[2]), ErrCompetitionDeltaNotSynced)
+	ce.RequestDeltaSnapshot()
+	if assert.Len(t, *deltas, 5) {
+		assert.NoError(t, client.Apply((*deltas)[4]))
+	}
+	assert.True(t, client.IsSynced())
+	competition, err := client.Competition()
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(4), competition.UpdateSerial)
+		assert.Equal(t, int64(1400), competition.State.Players[0].Chips)
+	}
+}
+
+func Test_CompetitionDelta_RequestSnapshot(t *testing.T) {
+	ce, deltas := newDeltaTestEngine(100)
+	ce.emitEvent("created", "")
+	ce.emitEvent("updated", "")
+
+	// 賽事沒有更新時仍發送快照
+	ce.RequestDeltaSnapshot()
+	if !assert.Len(t, *deltas, 3) {
+		return
+	}
+	snapshot := (*deltas)[2]
+	assert.True(t, snapshot.IsSnapshot)
+	assert.Equal(t, int64(UnsetValue), snapshot.BaseSerial)
+	assert.Equal(t, int64(2), snapshot.UpdateSerial)
+
+	// 快照後恢復發送增量
+	ce.emitEvent("updated", "")
+	if assert.Len(t, *deltas, 4) {
+		assert.False(t, (*deltas)[3].IsSnapshot)
+		assert.Equal(t, int64(2), (*deltas)[3].BaseSerial)
+	}
+
+	// 沒有監聽者時不發送
+	ce.onCompetitionDeltaUpdated = nil
+	assert.NotPanics(t, ce.RequestDeltaSnapshot)
+}
+
+func Test_CompetitionDelta_PatchLargerThanSnapshot(t *testing.T) {
+	ce, deltas := newDeltaTestEngine(100)
+	levels := make([]BlindLevel, 0)
+	for i := 1; i <= 100; i++ {
+		levels = append(levels, BlindLevel{
+			Level:      i,
+			SB:         int64(10 * i),
+			BB:         int64(20 * i),
+			Ante:       int64(i),
+			Duration:   60 + i,
+			HandCount:  i,
+			AllowAddon: i%2 == 0,
+		})
+	}
+	ce.competition.Meta.Blind.Levels = levels
+	ce.emitEvent("created", "")
+
+	// 小幅更新發送增量
+	ce.competition.State.Players[0].Chips = 2000
+	ce.emitEvent("chips updated", "p1")
+
+	// 移除第一個等級後，每個等級的每個欄位都不同，patch 比快照大
+	ce.competition.Meta.Blind.Levels = levels[1:]
+	ce.emitEvent("levels updated", "")
+
+	if !assert.Len(t, *deltas, 3) {
+		return
+	}
+	assert.True(t, (*deltas)[0].IsSnapshot)
+	assert.False(t, (*deltas)[1].IsSnapshot)
+	fallback := (*deltas)[2]
+	assert.True(t, fallback.IsSnapshot)
+	assert.Equal(t, int64(UnsetValue), fallback.BaseSerial)
+	assert.Empty(t, fallback.Patch)
+
+	client := NewCompetitionDeltaClient()
+	for _, delta := range *deltas {
+		assert.NoError(t, client.Apply(delta))
+	}
+	competition, err := client.Competition()
+	if assert.NoError(t, err) {
+		assert.Equal(t, levels[1:], competition.Meta.Blind.Levels)
+	}
+
+	// 快照後重新計算快照間隔
+	ce.emitEvent("updated", "")
+	if assert.Len(t, *deltas, 4) {
+		assert.False(t, (*deltas)[3].IsSnapshot)
+		assert.Equal(t, int64(3), (*deltas)[3].BaseSerial)
+	}
+}
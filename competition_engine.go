@@ -1,6 +1,7 @@
 package pokercompetition
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/thoas/go-funk"
+	pokerbalancing "github.com/weedbox/pokercompetition/balancing"
 	pokerblind "github.com/weedbox/pokercompetition/blind"
 	"github.com/weedbox/pokerface/regulator"
 	"github.com/weedbox/pokertable"
@@ -20,15 +22,30 @@ var (
 	ErrCompetitionUpdateBlindInitialLevelRejected = errors.New("competition: not allowed to update blind initial level")
 	ErrCompetitionLeaveRejected                   = errors.New("competition: not allowed to leave")
 	ErrCompetitionRefundRejected                  = errors.New("competition: not allowed to refund")
+	ErrCompetitionRefundDuringHand                = errors.New("competition: not allowed to refund during a hand")
+	ErrCompetitionDirectorActionRejected          = errors.New("competition: director action rejected")
+	ErrCompetitionInvalidPenalty                  = errors.New("competition: invalid penalty")
+	ErrCompetitionInvalidChipAdjustment           = errors.New("competition: invalid chip adjustment")
 	ErrCompetitionQuitRejected                    = errors.New("competition: not allowed to quit")
 	ErrCompetitionNoRedeemChips                   = errors.New("competition: not redeem any chips")
 	ErrCompetitionAddonRejected                   = errors.New("competition: not allowed to addon")
 	ErrCompetitionReBuyRejected                   = errors.New("competition: not allowed to re-buy")
 	ErrCompetitionBuyInRejected                   = errors.New("competition: not allowed to buy in")
 	ErrCompetitionExceedReBuyLimit                = errors.New("competition: exceed re-buy limit")
+	ErrCompetitionReBuyExpired                    = errors.New("competition: re-buy time expired")
+	ErrCompetitionExceedReBuyUnitLimit            = errors.New("competition: exceed re-buy unit limit")
 	ErrCompetitionExceedAddonLimit                = errors.New("competition: exceed addon limit")
+	ErrCompetitionInvalidAddonPackage             = errors.New("competition: invalid addon package")
+	ErrCompetitionAddonNotEligible                = errors.New("competition: not eligible for addon package")
 	ErrCompetitionPlayerNotFound                  = errors.New("competition: player not found")
 	ErrCompetitionTableNotFound                   = errors.New("competition: table not found")
+	ErrCompetitionInvalidPinnedSeat               = errors.New("competition: invalid pinned seat")
+	ErrCompetitionInvalidPinnedTable              = errors.New("competition: invalid pinned table")
+	ErrCompetitionCarryOverRejected               = errors.New("competition: not allowed to carry over players")
+	ErrCompetitionTableNotFeatured                = errors.New("competition: table is not featured")
+	ErrCompetitionPlayerMoveRejected              = errors.New("competition: not allowed to move player")
+	ErrCompetitionReBuyPlayerCountFull            = errors.New("competition: player count is full, re-buy rejected")
+	ErrCompetitionAlternateNotFound               = errors.New("competition: alternate not found")
 	ErrMatchInitFailed                            = errors.New("competition: failed to init match")
 	ErrMatchTableReservePlayerFailed              = errors.New("competition: failed to balance player to table by match")
 )
@@ -40,25 +57,46 @@ type CompetitionEngine interface {
 	OnCompetitionUpdated(fn func(competition *Competition))                                         // 賽事更新事件監聽器
 	OnCompetitionErrorUpdated(fn func(competition *Competition, err error))                         // 賽事錯誤更新事件監聽器
 	OnCompetitionPlayerUpdated(fn func(competitionID string, competitionPlayer *CompetitionPlayer)) // 賽事玩家更新事件監聽器
-	OnCompetitionFinalPlayerRankUpdated(fn func(competitionID, playerID string, rank int))          // 賽事玩家最終名次監聽器
+	OnCompetitionFinalPlayerRankUpdated(fn func(competitionID, playerID string, rank int))          // 賽事玩家最終名次監聽器 (由 Typed Event 轉接)
 	OnCompetitionStateUpdated(fn func(event string, competition *Competition))                      // 賽事狀態監聽器
-	OnCompetitionPlayerCashOut(fn func(competitionID string, competitionPlayer *CompetitionPlayer)) // 現金桌賽事玩家結算事件監聽器
+	OnCompetitionPlayerCashOut(fn func(competitionID string, competitionPlayer *CompetitionPlayer)) // 現金桌賽事玩家結算事件監聽器 (由 Typed Event 轉接)
 	OnAdvancePlayerCountUpdated(fn func(competitionID string, totalBuyInCount int) int)             // 賽事晉級人數更新監聽器
+	OnCompetitionPlayerMoved(fn func(moved PlayerMoved))                                            // 賽事玩家換桌事件監聽器 (由 Typed Event 轉接)
+	OnAdvancementResultCreated(fn func(result *AdvancementResult))                                  // 賽事晉級結果監聽器
+	OnCompetitionAddonOffered(fn func(offer AddonOffer))                                            // 賽事增購邀請監聽器 (由 Typed Event 轉接)
+	OnEvent(fn func(event Event))                                                                   // 賽事事件監聽器 (Typed Event)
+	OnCompetitionDeltaUpdated(fn func(delta CompetitionDelta))                                      // 賽事增量更新監聽器 (JSON Patch)
+	SubscribeEvents(handler func(event Event), options SubscribeOptions) *Subscription              // 訂閱賽事事件 (非同步派送)
+	CloseEventBus()                                                                                 // 結束所有賽事事件訂閱
+	RequestDeltaSnapshot()                                                                          // 立即發送完整快照 (增量更新)
 	OnTableCreated(fn func(table *pokertable.Table))                                                // TODO: Test Only
 
 	// Competition Actions
 	GetCompetition() *Competition                                                  // 取得賽事
+	GetSchedule() *Schedule                                                        // 取得賽事時程
 	CreateCompetition(competitionSetting CompetitionSetting) (*Competition, error) // 建立賽事
 	UpdateCompetitionBlindInitialLevel(level int) error                            // 更新賽事盲注初始等級
 	CloseCompetition(endStatus CompetitionStateStatus) error                       // 關閉賽事
 	StartCompetition() (int64, error)                                              // 開始賽事
 
 	// Player Operations
-	PlayerBuyIn(joinPlayer JoinPlayer) error                 // 玩家報名或補碼
+	PlayerBuyIn(joinPlayer JoinPlayer) error                 // 玩家報名或補碼 (MTT 人數已滿時加入候補名單，以 GetAlternatePosition 查詢)
+	PlayersCarryOver(joinPlayers []JoinPlayer) error         // 晉級玩家報名 (不受人數上限與候補名單限制)
 	PlayerAddon(tableID string, joinPlayer JoinPlayer) error // 玩家增購
-	PlayerRefund(playerID string) error                      // 玩家退賽
+	PlayerRefund(playerID string) error                      // 玩家退賽 (開賽後依退賽規則)
 	PlayerCashOut(tableID, playerID string) error            // 玩家離桌結算 (現金桌)
-	PlayerQuit(tableID, playerID string) error               // 玩家棄賽淘汰
+	PlayerQuit(tableID, playerID string) error               // 玩家棄賽淘汰 (MTT 棄賽, Cash 離桌結算)
+	GetAlternatePosition(playerID string) (int, error)       // 取得玩家候補順位 (MTT)
+
+	// Table Operations
+	SetTableFeatured(tableID string, isFeatured bool) error   // 設定焦點桌 (MTT)
+	MovePlayerToFeaturedTable(playerID, tableID string) error // 下一手前將玩家移至焦點桌 (MTT)
+
+	// Director Operations
+	DisqualifyPlayer(directorID, playerID, reason string) error                           // 裁判取消玩家資格
+	PenalizePlayer(directorID, playerID string, orbits, seconds int, reason string) error // 裁判處罰玩家暫停參與 N 圈或 N 秒
+	AdjustPlayerChips(directorID, playerID string, chips int64, reason string) error      // 裁判調整玩家籌碼
+	MovePlayer(directorID, playerID, tableID string, seat int, reason string) error       // 裁判指定玩家換桌 (MTT)
 
 	// Others
 	UpdateTable(table *pokertable.Table)                                                    // 桌次更新
@@ -82,12 +120,33 @@ type competitionEngine struct {
 	onCompetitionStateUpdated           func(event string, competition *Competition)
 	onAdvancePlayerCountUpdated         func(competitionID string, totalBuyInCount int) int
 	onCompetitionPlayerCashOut          func(competitionID string, competitionPlayer *CompetitionPlayer)
+	onCompetitionPlayerMoved            func(moved PlayerMoved)
+	onAdvancementResultCreated          func(result *AdvancementResult)
+	onCompetitionAddonOffered           func(offer AddonOffer)
+	onEvent                             func(event Event)
+	eventMu                             sync.Mutex                   // 事件序號與發布順序
+	eventSeq                            int64                        // 事件序號 (受 eventMu 保護)
+	eventBus                            *EventBus                    // 賽事事件訂閱
+	onCompetitionDeltaUpdated           func(delta CompetitionDelta) // nil 表示不發送增量更新
+	deltaState                          competitionDeltaState
 	breakingPauseResumeStates           map[string]map[int]bool // key: tableID, value: (k,v): (breaking blind level index, is resume from pause)
 	blind                               pokerblind.Blind
-	regulator                           regulator.Regulator
+	regulator                           pokerbalancing.Regulator
+	regulatorMu                         sync.Mutex      // 拆併桌監管器操作 (見 regulate)
+	regulatorCtx                        context.Context // 進行中的監管器操作 ctx (受 regulatorMu 保護)
+	balancingStrategy                   pokerbalancing.Strategy
 	isStarted                           bool
 	isRegulatorStarted                  bool
 	waitingPlayers                      []string
+	playerMoves                         map[string]playerMove   // key: playerID, value: 下一手前要執行的換桌
+	movingPlayers                       sync.Map                // key: playerID, value: *PlayerMoved (離開原桌次、尚未入座新桌次)
+	advanceRoundEliminations            []advanceElimination    // 晉級計算: 本輪 (逐手進行) 淘汰玩家
+	pendingReBuys                       map[string]JoinPlayer   // key: playerID, value: 兩手之間要加入的補碼資料
+	pendingAddons                       map[string]pendingAddon // key: playerID, value: 兩手之間要套用的增購資料
+	pendingForfeits                     map[string]bool         // key: playerID, value: 兩手之間要移除籌碼的棄賽玩家
+	pendingChipAdjustments              map[string]int64        // key: playerID, value: 兩手之間要調整的籌碼數
+	penaltyFoldRecords                  sync.Map                // key: <tableID.game_count.playerID>, value: 已自動棄牌
+	addonOfferedLevelIndex              int                     // 已發送增購邀請的盲注等級索引值
 
 	// TODO: Test Only
 	onTableCreated func(table *pokertable.Table)
@@ -104,11 +163,29 @@ func NewCompetitionEngine(opts ...CompetitionEngineOpt) CompetitionEngine {
 		onCompetitionStateUpdated:           func(event string, competition *Competition) {},
 		onAdvancePlayerCountUpdated:         func(competitionID string, totalBuyInCount int) int { return 0 },
 		onCompetitionPlayerCashOut:          func(competitionID string, competitionPlayer *CompetitionPlayer) {},
-		breakingPauseResumeStates:           make(map[string]map[int]bool),
-		blind:                               pokerblind.NewBlind(),
-		isStarted:                           false,
-		isRegulatorStarted:                  false,
-		waitingPlayers:                      make([]string, 0),
+		onCompetitionPlayerMoved:            func(moved PlayerMoved) {},
+		onAdvancementResultCreated:          func(result *AdvancementResult) {},
+		onCompetitionAddonOffered:           func(offer AddonOffer) {},
+		onEvent:                             func(event Event) {},
+		eventBus:                            NewEventBus(),
+		deltaState: competitionDeltaState{
+			snapshotInterval: defaultDeltaSnapshotInterval,
+			updateSerial:     UnsetValue,
+		},
+		movingPlayers:             sync.Map{},
+		breakingPauseResumeStates: make(map[string]map[int]bool),
+		blind:                     pokerblind.NewBlind(),
+		isStarted:                 false,
+		isRegulatorStarted:        false,
+		regulatorCtx:              context.Background(),
+		waitingPlayers:            make([]string, 0),
+		playerMoves:               make(map[string]playerMove),
+		pendingReBuys:             make(map[string]JoinPlayer),
+		pendingAddons:             make(map[string]pendingAddon),
+		pendingForfeits:           make(map[string]bool),
+		pendingChipAdjustments:    make(map[string]int64),
+		penaltyFoldRecords:        sync.Map{},
+		addonOfferedLevelIndex:    UnsetValue,
 
 		// TODO: Test Only
 		onTableCreated: func(table *pokertable.Table) {},
@@ -127,6 +204,15 @@ func WithTableOptions(opts *pokertable.TableEngineOptions) CompetitionEngineOpt
 	}
 }
 
+/*
+WithBalancingStrategy 自訂 MTT 拆併桌策略 (優先於 CompetitionMeta.BalancingStrategy)
+*/
+func WithBalancingStrategy(strategy pokerbalancing.Strategy) CompetitionEngineOpt {
+	return func(ce *competitionEngine) {
+		ce.balancingStrategy = strategy
+	}
+}
+
 func WithTableManagerBackend(tmb TableManagerBackend) CompetitionEngineOpt {
 	return func(ce *competitionEngine) {
 		ce.tableManagerBackend = tmb
@@ -170,6 +256,34 @@ func (ce *competitionEngine) OnCompetitionPlayerCashOut(fn func(competitionID st
 	ce.onCompetitionPlayerCashOut = fn
 }
 
+func (ce *competitionEngine) OnCompetitionPlayerMoved(fn func(moved PlayerMoved)) {
+	ce.onCompetitionPlayerMoved = fn
+}
+
+func (ce *competitionEngine) OnAdvancementResultCreated(fn func(result *AdvancementResult)) {
+	ce.onAdvancementResultCreated = fn
+}
+
+func (ce *competitionEngine) OnCompetitionAddonOffered(fn func(offer AddonOffer)) {
+	ce.onCompetitionAddonOffered = fn
+}
+
+func (ce *competitionEngine) OnEvent(fn func(event Event)) {
+	ce.onEvent = fn
+}
+
+func (ce *competitionEngine) OnCompetitionDeltaUpdated(fn func(delta CompetitionDelta)) {
+	ce.onCompetitionDeltaUpdated = fn
+}
+
+func (ce *competitionEngine) SubscribeEvents(handler func(event Event), options SubscribeOptions) *Subscription {
+	return ce.eventBus.Subscribe(handler, options)
+}
+
+func (ce *competitionEngine) CloseEventBus() {
+	ce.eventBus.Close()
+}
+
 // TODO: Test Only
 func (ce *competitionEngine) OnTableCreated(fn func(table *pokertable.Table)) {
 	ce.onTableCreated = fn
@@ -179,25 +293,29 @@ func (ce *competitionEngine) GetCompetition() *Competition {
 	return ce.competition
 }
 
-func (ce *competitionEngine) CreateCompetition(competitionSetting CompetitionSetting) (*Competition, error) {
-	// validate competitionSetting
-	now := time.Now()
-	if competitionSetting.StartAt != UnsetValue && competitionSetting.StartAt < now.Unix() {
-		return nil, ErrCompetitionInvalidCreateSetting
+/*
+GetSchedule 取得賽事時程
+  - 每次呼叫皆依當前盲注狀態重新推算 (等級變更、暫停後會反映最新結束時間)
+*/
+func (ce *competitionEngine) GetSchedule() *Schedule {
+	if ce.competition == nil {
+		return nil
 	}
+	return NewSchedule(ce.competition)
+}
 
-	if competitionSetting.DisableAt < now.Unix() {
-		return nil, ErrCompetitionInvalidCreateSetting
-	}
+func (ce *competitionEngine) CreateCompetition(competitionSetting CompetitionSetting) (*Competition, error) {
+	return ce.createCompetitionContext(context.Background(), competitionSetting)
+}
 
-	for _, tableSetting := range competitionSetting.TableSettings {
-		if len(tableSetting.JoinPlayers) > competitionSetting.Meta.TableMaxSeatCount {
-			return nil, ErrCompetitionInvalidCreateSetting
-		}
+func (ce *competitionEngine) createCompetitionContext(ctx context.Context, competitionSetting CompetitionSetting) (*Competition, error) {
+	// validate competitionSetting
+	if violations := ValidateCompetitionSetting(competitionSetting); len(violations) > 0 {
+		return nil, violations
 	}
 
 	// setup blind
-	ce.initBlind(competitionSetting.Meta)
+	ce.initBlind(ctx, competitionSetting.Meta)
 
 	// create competition instance
 	endAts := make([]int64, 0)
@@ -208,23 +326,30 @@ func (ce *competitionEngine) CreateCompetition(competitionSetting CompetitionSet
 		ID:   competitionSetting.CompetitionID,
 		Meta: competitionSetting.Meta,
 		State: &CompetitionState{
-			OpenAt:    time.Now().Unix(),
-			DisableAt: competitionSetting.DisableAt,
-			StartAt:   competitionSetting.StartAt,
-			EndAt:     UnsetValue,
-			Players:   make([]*CompetitionPlayer, 0),
-			Status:    CompetitionStateStatus_Registering,
-			Tables:    make([]*pokertable.Table, 0),
-			Rankings:  make([]*CompetitionRank, 0),
+			OpenAt:            time.Now().Unix(),
+			DisableAt:         competitionSetting.DisableAt,
+			StartAt:           competitionSetting.StartAt,
+			EndAt:             UnsetValue,
+			Players:           make([]*CompetitionPlayer, 0),
+			Status:            CompetitionStateStatus_Registering,
+			Tables:            make([]*pokertable.Table, 0),
+			Rankings:          make([]*CompetitionRank, 0),
+			ColorUps:          make([]*ColorUpRecord, 0),
+			SeatingViolations: make([]*SeatingViolation, 0),
+			TableInfos:        make([]*TableInfo, 0),
+			Alternates:        make([]*CompetitionAlternate, 0),
+			Refunds:           make([]*RefundRecord, 0),
+			DirectorAudits:    make([]*DirectorAuditRecord, 0),
 			BlindState: &BlindState{
 				FinalBuyInLevelIndex: competitionSetting.Meta.Blind.FinalBuyInLevelIndex,
 				CurrentLevelIndex:    UnsetValue,
 				EndAts:               endAts,
 			},
 			AdvanceState: &AdvanceState{
-				Status:        CompetitionAdvanceStatus_NotStart,
-				TotalTables:   UnsetValue,
-				UpdatedTables: UnsetValue,
+				Status:         CompetitionAdvanceStatus_NotStart,
+				TotalTables:    UnsetValue,
+				UpdatedTables:  UnsetValue,
+				AdvancePlayers: make([]*AdvancePlayer, 0),
 			},
 			Statistic: &Statistic{
 				TotalBuyInCount: 0,
@@ -243,23 +368,37 @@ func (ce *competitionEngine) CreateCompetition(competitionSetting CompetitionSet
 				SB:     pokertable.UnsetValue,
 				BB:     pokertable.UnsetValue,
 			}
-			if _, err := ce.addCompetitionTable(tableSetting, blind); err != nil {
+			if _, err := ce.addCompetitionTable(ctx, tableSetting, blind); err != nil {
 				return nil, err
 			}
 		}
 	case CompetitionMode_MTT:
 		// 初始化拆併桌監管器
+		if ce.balancingStrategy == nil {
+			ce.balancingStrategy = NewBalancingStrategy(competitionSetting.Meta.BalancingStrategy)
+		}
 		if ce.regulator == nil {
-			ce.regulator = regulator.NewRegulator(
-				regulator.MinInitialPlayers(competitionSetting.Meta.RegulatorMinInitialPlayerCount),
-				regulator.WithRequestTableFn(func(playerIDs []string) (string, error) {
-					return ce.regulatorCreateAndDistributePlayers(playerIDs)
+			ce.regulator = pokerbalancing.NewRegulator(
+				ce.balancingStrategy,
+				pokerbalancing.MinInitialPlayers(competitionSetting.Meta.RegulatorMinInitialPlayerCount),
+				pokerbalancing.MaxPlayersPerTable(competitionSetting.Meta.TableMaxSeatCount),
+				pokerbalancing.WithRequestTableFn(func(playerIDs []string) (string, error) {
+					return ce.regulatorCreateAndDistributePlayers(ce.regulatorCtx, playerIDs)
 				}),
-				regulator.WithAssignPlayersFn(func(tableID string, playerIDs []string) error {
-					return ce.regulatorDistributePlayers(tableID, playerIDs)
+				pokerbalancing.WithAssignPlayersFn(func(tableID string, playerIDs []string) error {
+					return ce.regulatorDistributePlayers(ce.regulatorCtx, tableID, playerIDs)
+				}),
+				pokerbalancing.WithTableInfoFn(func(tableID string) (int, bool) {
+					return ce.regulatorTableInfo(tableID)
+				}),
+				pokerbalancing.WithSeatingCostFn(func(tableID string, pickedPlayerIDs []string, playerID string) int {
+					return ce.seatingCost(tableID, pickedPlayerIDs, playerID)
 				}),
 			)
-			ce.regulator.SetStatus(regulator.CompetitionStatus_Pending)
+			_ = ce.regulate(ctx, func() error {
+				ce.regulator.SetStatus(regulator.CompetitionStatus_Pending)
+				return nil
+			})
 		}
 	}
 
@@ -296,6 +435,7 @@ func (ce *competitionEngine) CreateCompetition(competitionSetting CompetitionSet
 	}
 
 	ce.emitEvent("CreateCompetition", "")
+	ce.emitTypedEvent(EventType_CompetitionCreated, CompetitionCreatedPayload{Mode: ce.competition.Meta.Mode})
 	return ce.competition, nil
 }
 
@@ -317,12 +457,16 @@ CloseCompetition 關閉賽事
   - 適用時機: 賽事出狀況需要臨時關閉賽事、未達開賽條件自動關閉賽事、正常結束賽事
 */
 func (ce *competitionEngine) CloseCompetition(endStatus CompetitionStateStatus) error {
+	return ce.closeCompetitionContext(context.Background(), endStatus)
+}
+
+func (ce *competitionEngine) closeCompetitionContext(ctx context.Context, endStatus CompetitionStateStatus) error {
 	if ce.isEndStatus() {
 		return nil
 	}
 
 	ce.settleCompetition(endStatus)
-	_ = ce.ReleaseTables()
+	_ = ce.releaseTablesContext(ctx)
 	return nil
 }
 
@@ -331,6 +475,10 @@ StartCompetition 開賽
   - 適用時機: MTT 手動開賽、MTT 自動開賽、CT 開賽
 */
 func (ce *competitionEngine) StartCompetition() (int64, error) {
+	return ce.startCompetitionContext(context.Background())
+}
+
+func (ce *competitionEngine) startCompetitionContext(ctx context.Context) (int64, error) {
 	if ce.isStarted {
 		return ce.competition.State.StartAt, ErrCompetitionStartRejected
 	}
@@ -371,7 +519,7 @@ func (ce *competitionEngine) StartCompetition() (int64, error) {
 				}
 				// 桌次尚未結束，處理關桌
 				if !funk.Contains(noneCloseTableStatuses, ce.competition.State.Tables[0].State.Status) {
-					if err := ce.tableManagerBackend.CloseTable(ce.competition.State.Tables[0].ID); err != nil {
+					if err := ce.backend(context.Background()).CloseTable(ce.competition.State.Tables[0].ID); err != nil {
 						ce.emitErrorEvent("end time auto close -> CloseTable", "", err)
 					}
 				}
@@ -382,21 +530,45 @@ func (ce *competitionEngine) StartCompetition() (int64, error) {
 	case CompetitionMode_MTT:
 		// 更新拆併桌監管器狀態
 		if ce.shouldActivateRegulator() {
-			ce.activateRegulator()
+			ce.activateRegulator(ctx)
 		}
 	}
 
 	ce.emitEvent("StartCompetition", "")
 	ce.emitCompetitionStateEvent(CompetitionStateEvent_Started)
+	ce.emitTypedEvent(EventType_CompetitionStarted, CompetitionStartedPayload{StartAt: ce.competition.State.StartAt})
 	return ce.competition.State.StartAt, nil
 }
 
 func (ce *competitionEngine) PlayerBuyIn(joinPlayer JoinPlayer) error {
+	return ce.playerBuyInContext(context.Background(), joinPlayer)
+}
+
+func (ce *competitionEngine) playerBuyInContext(ctx context.Context, joinPlayer JoinPlayer) error {
+	joinPlayer.Unit = buyInUnits(joinPlayer.Unit)
+	return ce.playerBuyIn(ctx, joinPlayer, false)
+}
+
+/*
+playerBuyIn 玩家報名或補碼
+  - joinPlayer.Unit: 買入發數 (PlayerBuyIn 已計算，晉級玩家為 0 發)
+  - skipWaitlist: 是否略過 MTT 人數上限與候補名單 (候補遞補、晉級玩家)
+  - MTT 人數已滿時加入候補名單，不視為錯誤 (候補順位見 GetAlternatePosition)
+*/
+func (ce *competitionEngine) playerBuyIn(ctx context.Context, joinPlayer JoinPlayer, skipWaitlist bool) error {
 	// validate join player data
 	if joinPlayer.RedeemChips <= 0 {
 		return ErrCompetitionNoRedeemChips
 	}
 
+	if joinPlayer.Seating.IsSeatPinned && (joinPlayer.Seating.PinnedSeat < 0 || joinPlayer.Seating.PinnedSeat >= ce.competition.Meta.TableMaxSeatCount) {
+		return ErrCompetitionInvalidPinnedSeat
+	}
+
+	if joinPlayer.Seating.IsTablePinned && joinPlayer.Seating.PinnedTableNumber <= 0 {
+		return ErrCompetitionInvalidPinnedTable
+	}
+
 	playerIdx := ce.competition.FindPlayerIdx(func(player *CompetitionPlayer) bool {
 		return player.PlayerID == joinPlayer.PlayerID
 	})
@@ -418,23 +590,42 @@ func (ce *competitionEngine) PlayerBuyIn(joinPlayer JoinPlayer) error {
 			cp := ce.competition.State.Players[playerIdx]
 
 			// validate re-buy player
-			if cp.Status == CompetitionPlayerStatus_Knockout {
+			if cp.Status == CompetitionPlayerStatus_Knockout || cp.IsForfeited {
 				return ErrCompetitionReBuyRejected
 			}
 
+			// 籌碼未歸零: 依補碼規則於兩手之間加入籌碼
+			if cp.Chips > 0 {
+				return ce.playerTopUpReBuy(ctx, cp, joinPlayer)
+			}
+
 			// validate re-buy conditions
 			if cp.Status != CompetitionPlayerStatus_ReBuyWaiting {
 				return ErrCompetitionReBuyRejected
 			}
 
-			if cp.Chips > 0 {
-				return ErrCompetitionReBuyRejected
+			if err := ce.validateReBuyUnits(cp, joinPlayer.Unit); err != nil {
+				return err
+			}
+
+			// MTT 超過補碼時間 (等待淘汰中) 不可補碼
+			if ce.competition.Meta.Mode == CompetitionMode_MTT && cp.ReBuyEndAt != UnsetValue && time.Now().Unix() > cp.ReBuyEndAt {
+				return ErrCompetitionReBuyExpired
 			}
 
-			if cp.ReBuyTimes >= ce.competition.Meta.ReBuySetting.MaxTime {
-				return ErrCompetitionExceedReBuyLimit
+			// MTT 補碼玩家仍佔參賽名額，名額已被其他玩家占滿時 (ex: 名額計算異常) 不可補碼
+			if ce.competition.Meta.Mode == CompetitionMode_MTT && ce.competition.Meta.MaxPlayerCount > 0 && ce.competition.LiveEntryCount()-1 >= ce.competition.Meta.MaxPlayerCount {
+				return ErrCompetitionReBuyPlayerCountFull
 			}
 		} else {
+			// check mtt buy in conditions: 達人數上限或有人候補中，加入候補名單
+			if ce.competition.Meta.Mode == CompetitionMode_MTT && !skipWaitlist {
+				if ce.competition.IsPlayerCountFull() || ce.competition.WaitingAlternateCount() > 0 {
+					ce.addAlternate(joinPlayer)
+					return nil
+				}
+			}
+
 			// check ct buy in conditions
 			if ce.competition.Meta.Mode == CompetitionMode_CT {
 				if len(ce.competition.State.Tables) == 0 {
@@ -466,6 +657,7 @@ func (ce *competitionEngine) PlayerBuyIn(joinPlayer JoinPlayer) error {
 	ce.mu.Lock()
 	if isBuyIn {
 		player := ce.newDefaultCompetitionPlayerData(tableID, joinPlayer.PlayerID, joinPlayer.RedeemChips, playerStatus, joinPlayer.Unit)
+		player.Seating = joinPlayer.Seating
 		ce.competition.State.Players = append(ce.competition.State.Players, &player)
 		if ce.competition.IsModeCTorMTT() {
 			ce.competition.State.Statistic.TotalBuyInCount += joinPlayer.Unit
@@ -480,6 +672,7 @@ func (ce *competitionEngine) PlayerBuyIn(joinPlayer JoinPlayer) error {
 		cp.ReBuyWaitingAt = UnsetValue
 		cp.Chips = joinPlayer.RedeemChips
 		cp.ReBuyTimes++
+		cp.ReBuyUnits += joinPlayer.Unit
 		cp.IsReBuying = false
 		cp.ReBuyEndAt = UnsetValue
 		cp.TotalRedeemChips += joinPlayer.RedeemChips
@@ -503,9 +696,22 @@ func (ce *competitionEngine) PlayerBuyIn(joinPlayer JoinPlayer) error {
 	if isBuyIn {
 		ce.emitEvent(fmt.Sprintf("PlayerBuyIn -> %s Buy In", joinPlayer.PlayerID), joinPlayer.PlayerID)
 		ce.emitPlayerEvent("PlayerBuyIn -> Buy In", competitionPlayer)
+		ce.emitTypedEvent(EventType_PlayerRegistered, PlayerRegisteredPayload{
+			PlayerID:    joinPlayer.PlayerID,
+			TableID:     tableID,
+			RedeemChips: joinPlayer.RedeemChips,
+			Units:       joinPlayer.Unit,
+		})
 	} else {
 		ce.emitEvent(fmt.Sprintf("PlayerBuyIn -> %s Re Buy", joinPlayer.PlayerID), joinPlayer.PlayerID)
 		ce.emitPlayerEvent("PlayerBuyIn -> Re Buy", competitionPlayer)
+		ce.emitTypedEvent(EventType_PlayerReBought, PlayerReBoughtPayload{
+			PlayerID:    joinPlayer.PlayerID,
+			RedeemChips: joinPlayer.RedeemChips,
+			Units:       joinPlayer.Unit,
+			ReBuyTimes:  competitionPlayer.ReBuyTimes,
+			Chips:       competitionPlayer.Chips,
+		})
 	}
 	ce.emitCompetitionStateEvent(CompetitionStateEvent_CompetitionStatisticUpdated)
 
@@ -517,17 +723,17 @@ func (ce *competitionEngine) PlayerBuyIn(joinPlayer JoinPlayer) error {
 			RedeemChips: joinPlayer.RedeemChips,
 			Seat:        pokertable.UnsetValue,
 		}
-		if err := ce.tableManagerBackend.PlayerReserve(tableID, jp); err != nil {
+		if err := ce.backend(ctx).PlayerReserve(tableID, jp); err != nil {
 			ce.emitErrorEvent("PlayerBuyIn -> PlayerReserve", joinPlayer.PlayerID, err)
 		}
 	case CompetitionMode_MTT:
 		// 更新拆併桌監管器狀態
 		if ce.isRegulatorStarted {
 			// 開賽後且達到開賽最低人數之後，丟到拆併桌程式
-			ce.regulatorAddPlayers([]string{joinPlayer.PlayerID})
+			ce.regulatorAddPlayers(ctx, []string{joinPlayer.PlayerID})
 		} else {
 			if ce.shouldActivateRegulator() {
-				ce.activateRegulator()
+				ce.activateRegulator(ctx)
 			} else {
 				// 開賽前 or 開賽後且尚未達到開賽最低人數之前，都把玩家放到等待佇列
 				ce.waitingPlayers = append(ce.waitingPlayers, joinPlayer.PlayerID)
@@ -539,6 +745,10 @@ func (ce *competitionEngine) PlayerBuyIn(joinPlayer JoinPlayer) error {
 }
 
 func (ce *competitionEngine) PlayerAddon(tableID string, joinPlayer JoinPlayer) error {
+	return ce.playerAddonContext(context.Background(), tableID, joinPlayer)
+}
+
+func (ce *competitionEngine) playerAddonContext(ctx context.Context, tableID string, joinPlayer JoinPlayer) error {
 	// validate join player data
 	if joinPlayer.RedeemChips <= 0 {
 		return ErrCompetitionNoRedeemChips
@@ -565,70 +775,98 @@ func (ce *competitionEngine) PlayerAddon(tableID string, joinPlayer JoinPlayer)
 		return ErrCompetitionExceedAddonLimit
 	}
 
-	// do logic
-	ce.mu.Lock()
-	cp.CurrentTableID = tableID
-	cp.Chips += joinPlayer.RedeemChips
-	cp.AddonTimes++
-	cp.TotalRedeemChips += joinPlayer.RedeemChips
-	if ce.competition.IsModeCTorMTT() {
-		ce.competition.State.Statistic.TotalAddonCount += joinPlayer.Unit
+	// validate addon package
+	addonPackage, err := ce.competition.findAddonPackage(cp, joinPlayer)
+	if err != nil {
+		return err
+	}
+	if addonPackage != nil {
+		joinPlayer.AddonPackage = addonPackage.Name
+		joinPlayer.RedeemChips = addonPackage.RedeemChips
+		if addonPackage.Unit > 0 {
+			joinPlayer.Unit = addonPackage.Unit
+		}
 	}
-	ce.refreshPlayerCompetitionRanks()
-	defer ce.mu.Unlock()
-
-	// emit events
-	ce.emitEvent("PlayerAddon", joinPlayer.PlayerID)
-	ce.emitPlayerEvent("PlayerAddon", cp)
-	ce.emitCompetitionStateEvent(CompetitionStateEvent_CompetitionStatisticUpdated)
 
-	// call tableEngine
-	jp := pokertable.JoinPlayer{
-		PlayerID:    joinPlayer.PlayerID,
-		RedeemChips: joinPlayer.RedeemChips,
-		Seat:        pokertable.UnsetValue,
+	// do logic: 牌局進行中時等到該手結算後 (兩手之間) 再套用
+	ce.mu.Lock()
+	if _, exist := ce.pendingAddons[joinPlayer.PlayerID]; exist {
+		ce.mu.Unlock()
+		return ErrCompetitionAddonRejected
 	}
-	if err := ce.tableManagerBackend.PlayerRedeemChips(tableID, jp); err != nil {
-		return err
+	ce.pendingAddons[joinPlayer.PlayerID] = pendingAddon{
+		tableID:    tableID,
+		joinPlayer: joinPlayer,
 	}
+	ce.mu.Unlock()
 
+	ce.applyPendingAddon(ctx, joinPlayer.PlayerID)
 	return nil
 }
 
 func (ce *competitionEngine) PlayerRefund(playerID string) error {
+	return ce.playerRefundContext(context.Background(), playerID)
+}
+
+func (ce *competitionEngine) playerRefundContext(ctx context.Context, playerID string) error {
 	// validate refund conditions
 	playerIdx := ce.competition.FindPlayerIdx(func(player *CompetitionPlayer) bool {
 		return player.PlayerID == playerID
 	})
 	if playerIdx == UnsetValue {
-		return ErrCompetitionRefundRejected
-	}
-
-	// 盲注啟動後就不能退賽
-	if ce.blind.IsStarted() {
+		// 候補玩家取消候補
+		if ce.withdrawAlternate(playerID) {
+			return nil
+		}
 		return ErrCompetitionRefundRejected
 	}
 
 	// 取得玩家資訊
 	player := ce.competition.State.Players[playerIdx]
 
+	// 盲注啟動後依退賽規則判斷，已入座玩家先離開桌次
+	isSeated := false
 	playerTableID := ""
-	if ce.competition.Meta.Mode == CompetitionMode_CT {
+	if ce.blind.IsStarted() {
+		seated, err := ce.validateRefundAfterStart(player)
+		if err != nil {
+			return err
+		}
+		isSeated = seated
+
+		if err := ce.leaveRefundPlayer(ctx, player, isSeated); err != nil {
+			return err
+		}
+	} else if ce.competition.Meta.Mode == CompetitionMode_CT {
 		playerTableID = player.CurrentTableID
 	}
 
+	// 退賽後釋出名額給候補玩家 (需在 unlock 之後執行)
+	defer ce.promoteAlternates(ctx)
+
 	// refund logic
 	ce.mu.Lock()
+	ce.addRefundRecord(player, isSeated)
 	if ce.competition.IsModeCTorMTT() {
 		ce.competition.State.Statistic.TotalBuyInCount -= player.TotalBuyInUnits
-		ce.competition.State.Players[playerIdx].TotalBuyInUnits = 0
+		player.TotalBuyInUnits = 0
+	}
+	if playerIdx = ce.competition.FindPlayerIdx(func(cp *CompetitionPlayer) bool {
+		return cp.PlayerID == playerID
+	}); playerIdx != UnsetValue {
+		ce.deletePlayer(playerIdx)
 	}
-	ce.deletePlayer(playerIdx)
+	delete(ce.pendingForfeits, playerID)
+	ce.refreshPlayerStatusStatistics()
+	ce.refreshPlayerCompetitionRanks()
 	defer ce.mu.Unlock()
 
 	// emit events
 	ce.emitEvent("PlayerRefund", playerID)
 	ce.emitCompetitionStateEvent(CompetitionStateEvent_CompetitionStatisticUpdated)
+	ce.emitTypedEvent(EventType_PlayerRefunded, PlayerRefundedPayload{
+		Record: *ce.competition.State.Refunds[len(ce.competition.State.Refunds)-1],
+	})
 
 	waitingPlayers := make([]string, 0)
 	for _, waitingPlayerID := range ce.waitingPlayers {
@@ -641,7 +879,7 @@ func (ce *competitionEngine) PlayerRefund(playerID string) error {
 
 	// call tableEngine
 	if playerTableID != "" {
-		if err := ce.tableManagerBackend.PlayersLeave(playerTableID, []string{playerID}); err != nil {
+		if err := ce.backend(ctx).PlayersLeave(playerTableID, []string{playerID}); err != nil {
 			return err
 		}
 	}
@@ -650,6 +888,10 @@ func (ce *competitionEngine) PlayerRefund(playerID string) error {
 }
 
 func (ce *competitionEngine) PlayerCashOut(tableID, playerID string) error {
+	return ce.playerCashOutContext(context.Background(), tableID, playerID)
+}
+
+func (ce *competitionEngine) playerCashOutContext(ctx context.Context, tableID, playerID string) error {
 	// validate leave conditions
 	playerIdx := ce.competition.FindPlayerIdx(func(player *CompetitionPlayer) bool {
 		return player.PlayerID == playerID
@@ -681,19 +923,28 @@ func (ce *competitionEngine) PlayerCashOut(tableID, playerID string) error {
 			playerID: playerIdx,
 		}
 		leavePlayerIDs := []string{playerID}
-		ce.handleCashOut(tableID, leavePlayerIndexes, leavePlayerIDs)
+		ce.handleCashOut(ctx, tableID, leavePlayerIndexes, leavePlayerIDs)
 	}
 
 	return nil
 }
 
 func (ce *competitionEngine) PlayerQuit(tableID, playerID string) error {
-	// validate quit conditions
-	// CT Only
-	if ce.competition.Meta.Mode != CompetitionMode_CT {
-		return ErrCompetitionQuitRejected
+	return ce.playerQuitContext(context.Background(), tableID, playerID)
+}
+
+func (ce *competitionEngine) playerQuitContext(ctx context.Context, tableID, playerID string) error {
+	switch ce.competition.Meta.Mode {
+	case CompetitionMode_MTT:
+		// MTT 棄賽: 依棄賽籌碼處理方式淘汰
+		return ce.playerForfeit(ctx, playerID)
+	case CompetitionMode_Cash:
+		// 現金桌: 離桌結算
+		return ce.playerCashOutContext(ctx, tableID, playerID)
 	}
 
+	// validate quit conditions
+
 	playerIdx := ce.competition.FindPlayerIdx(func(player *CompetitionPlayer) bool {
 		return player.PlayerID == playerID
 	})
@@ -713,7 +964,7 @@ func (ce *competitionEngine) PlayerQuit(tableID, playerID string) error {
 		return ErrCompetitionQuitRejected
 	}
 
-	if err := ce.tableManagerBackend.PlayersLeave(tableID, []string{playerID}); err != nil {
+	if err := ce.backend(ctx).PlayersLeave(tableID, []string{playerID}); err != nil {
 		fmt.Printf("[DEBUG#PlayerQuit] PlayersLeave Error: %+v. CompetitionID: %s, TableID: %s, PlayerID: %s", err, ce.competition.ID, tableID, playerID)
 		// ce.emitErrorEvent("Player Quit Knockout Players -> PlayersLeave", playerID, err)
 	} else {
@@ -733,6 +984,81 @@ func (ce *competitionEngine) PlayerQuit(tableID, playerID string) error {
 	return nil
 }
 
+/*
+GetAlternatePosition 取得玩家候補順位
+  - @return 順位 (從 1 開始)
+*/
+func (ce *competitionEngine) GetAlternatePosition(playerID string) (int, error) {
+	ce.mu.RLock()
+	defer ce.mu.RUnlock()
+
+	position := ce.competition.AlternatePosition(playerID)
+	if position == UnsetValue {
+		return UnsetValue, ErrCompetitionAlternateNotFound
+	}
+	return position, nil
+}
+
+/*
+SetTableFeatured 設定焦點桌
+  - 焦點桌不會被拆桌，且需要補人時最後補人
+*/
+func (ce *competitionEngine) SetTableFeatured(tableID string, isFeatured bool) error {
+	if ce.competition.Meta.Mode != CompetitionMode_MTT {
+		return ErrCompetitionTableNotFound
+	}
+
+	ce.mu.Lock()
+	info := ce.competition.FindTableInfo(tableID)
+	if info == nil {
+		ce.mu.Unlock()
+		return ErrCompetitionTableNotFound
+	}
+	info.IsFeatured = isFeatured
+	ce.mu.Unlock()
+
+	ce.emitEvent(fmt.Sprintf("SetTableFeatured -> table (%s) featured: %+v", tableID, isFeatured), "")
+	ce.emitCompetitionStateEvent(CompetitionStateEvent_TableUpdated)
+	return nil
+}
+
+/*
+MovePlayerToFeaturedTable 將玩家移至焦點桌
+  - 玩家所在桌次該手結束後才會移動
+*/
+func (ce *competitionEngine) MovePlayerToFeaturedTable(playerID, tableID string) error {
+	if ce.competition.Meta.Mode != CompetitionMode_MTT {
+		return ErrCompetitionPlayerMoveRejected
+	}
+
+	ce.mu.Lock()
+	defer ce.mu.Unlock()
+
+	info := ce.competition.FindTableInfo(tableID)
+	if info == nil {
+		return ErrCompetitionTableNotFound
+	}
+	if !info.IsFeatured {
+		return ErrCompetitionTableNotFeatured
+	}
+
+	player := ce.competition.findPlayer(playerID)
+	if player == nil {
+		return ErrCompetitionPlayerNotFound
+	}
+	if player.Status != CompetitionPlayerStatus_Playing || player.CurrentTableID == "" || player.CurrentTableID == tableID {
+		return ErrCompetitionPlayerMoveRejected
+	}
+
+	ce.playerMoves[playerID] = playerMove{
+		tableID: tableID,
+		seat:    UnsetValue,
+		reason:  PlayerMoveReason_FeaturedTable,
+	}
+	ce.emitEvent(fmt.Sprintf("MovePlayerToFeaturedTable -> player (%s) will move to table (%s)", playerID, tableID), playerID)
+	return nil
+}
+
 func (ce *competitionEngine) UpdateReserveTablePlayerState(tableID string, playerState *pokertable.TablePlayerState) {
 	// 更新玩家狀態
 	playerIdx, exist := ce.competition.GetPlayerIndexMap()[playerState.PlayerID]
@@ -745,10 +1071,15 @@ func (ce *competitionEngine) UpdateReserveTablePlayerState(tableID string, playe
 	cp.CurrentTableID = tableID
 	cp.Status = CompetitionPlayerStatus_Playing
 	ce.emitPlayerEvent("[UpdateReserveTablePlayerState] player table seat updated", cp)
+	ce.commitPlayerMove(cp)
 	ce.emitEvent(fmt.Sprintf("[UpdateReserveTablePlayerState] player (%s) is reserved to table (%s) at seat (%d)", cp.PlayerID, cp.CurrentTableID, cp.CurrentSeat), cp.PlayerID)
 }
 
 func (ce *competitionEngine) AutoGameOpenEnd(tableID string) error {
+	return ce.autoGameOpenEndContext(context.Background(), tableID)
+}
+
+func (ce *competitionEngine) autoGameOpenEndContext(ctx context.Context, tableID string) error {
 	tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
 		return tableID == t.ID
 	})
@@ -758,20 +1089,29 @@ func (ce *competitionEngine) AutoGameOpenEnd(tableID string) error {
 
 	// CT 停止買入且賽事沒有繼續自動開桌，則自動結束賽事
 	if ce.competition.State.Tables[tableIdx].Meta.Mode == string(CompetitionMode_CT) && ce.competition.State.Status == CompetitionStateStatus_StoppedBuyIn {
-		_ = ce.CloseCompetition(CompetitionStateStatus_End)
+		_ = ce.closeCompetitionContext(ctx, CompetitionStateStatus_End)
 	}
 
 	return nil
 }
 
 func (ce *competitionEngine) ReleaseTables() error {
+	return ce.releaseTablesContext(context.Background())
+}
+
+func (ce *competitionEngine) releaseTablesContext(ctx context.Context) error {
 	for _, table := range ce.competition.State.Tables {
-		_ = ce.tableManagerBackend.ReleaseTable(table.ID)
+		_ = ce.backend(ctx).ReleaseTable(table.ID)
+		ce.emitTypedEvent(EventType_TableClosed, TableClosedPayload{TableID: table.ID})
 	}
 	return nil
 }
 
 func (ce *competitionEngine) UpdateTable(table *pokertable.Table) {
+	ce.updateTableContext(context.Background(), table)
+}
+
+func (ce *competitionEngine) updateTableContext(ctx context.Context, table *pokertable.Table) {
 	tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
 		return table.ID == t.ID
 	})
@@ -793,8 +1133,11 @@ func (ce *competitionEngine) UpdateTable(table *pokertable.Table) {
 	}
 	ce.competition.State.Tables[tableIdx] = &cloneTable
 
+	// 處罰中玩家自動棄牌
+	ce.handlePenalizedPlayerTurn(ctx, cloneTable)
+
 	// 處理因 table status 產生的變化
-	tableStatusHandlerMap := map[pokertable.TableStateStatus]func(pokertable.Table, int){
+	tableStatusHandlerMap := map[pokertable.TableStateStatus]func(context.Context, pokertable.Table, int){
 		pokertable.TableStateStatus_TableCreated:     ce.handleCompetitionTableCreated,
 		pokertable.TableStateStatus_TablePausing:     ce.updatePauseCompetition,
 		pokertable.TableStateStatus_TableClosed:      ce.closeCompetitionTable,
@@ -804,10 +1147,14 @@ func (ce *competitionEngine) UpdateTable(table *pokertable.Table) {
 	if !ok {
 		return
 	}
-	handler(cloneTable, tableIdx)
+	handler(ctx, cloneTable, tableIdx)
 }
 
 func (ce *competitionEngine) ReadyFirstTableGame(tableID string, gameCount int, players []*pokertable.TablePlayerState) error {
+	return ce.readyFirstTableGameContext(context.Background(), tableID, gameCount, players)
+}
+
+func (ce *competitionEngine) readyFirstTableGameContext(ctx context.Context, tableID string, gameCount int, players []*pokertable.TablePlayerState) error {
 	participants := ce.generateAliveParticipants(players)
-	return ce.tableManagerBackend.SetUpTableGame(tableID, gameCount, participants)
+	return ce.backend(ctx).SetUpTableGame(tableID, gameCount, participants)
 }
@@ -1,6 +1,7 @@
 package pokercompetition
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -15,6 +16,18 @@ import (
 	"github.com/weedbox/timebank"
 )
 
+/*
+backend 取得本次呼叫使用的 TableManagerBackend
+  - ctx 帶有 Manager 呼叫的關聯 ID (見 withAuditCorrelation)，稽核用的 backend 依此記錄 backend 呼叫的來源
+  - 計時器、桌次回呼等非 Manager 呼叫觸發的操作使用 context.Background()
+*/
+func (ce *competitionEngine) backend(ctx context.Context) TableManagerBackend {
+	if b, ok := ce.tableManagerBackend.(contextTableManagerBackend); ok {
+		return b.withContext(ctx)
+	}
+	return ce.tableManagerBackend
+}
+
 func (ce *competitionEngine) newDefaultCompetitionPlayerData(tableID, playerID string, redeemChips int64, playerStatus CompetitionPlayerStatus, buyInUnit int) CompetitionPlayer {
 	return CompetitionPlayer{
 		PlayerID:            playerID,
@@ -31,6 +44,7 @@ func (ce *competitionEngine) newDefaultCompetitionPlayerData(tableID, playerID s
 		IsReBuying:          false,
 		ReBuyEndAt:          UnsetValue,
 		ReBuyTimes:          0,
+		ReBuyUnits:          0,
 		AddonTimes:          0,
 		TotalBuyInUnits:     buyInUnit,
 		BestWinningPotChips: 0,
@@ -60,7 +74,7 @@ func (ce *competitionEngine) delay(interval time.Duration, fn func() error) erro
 	return err
 }
 
-func (ce *competitionEngine) handleCompetitionTableCreated(table pokertable.Table, tableIdx int) {
+func (ce *competitionEngine) handleCompetitionTableCreated(ctx context.Context, table pokertable.Table, tableIdx int) {
 	switch ce.competition.Meta.Mode {
 	case CompetitionMode_CT:
 		if !ce.canStartCT() {
@@ -68,7 +82,7 @@ func (ce *competitionEngine) handleCompetitionTableCreated(table pokertable.Tabl
 		}
 
 		// auto start game if condition is reached
-		if _, err := ce.StartCompetition(); err != nil {
+		if _, err := ce.startCompetitionContext(ctx); err != nil {
 			ce.emitErrorEvent("CT Auto StartCompetition", "", err)
 			return
 		}
@@ -80,9 +94,10 @@ func (ce *competitionEngine) handleCompetitionTableCreated(table pokertable.Tabl
 			return
 		}
 
-		ce.updateTableBlind(table.ID)
+		ce.updateTableRule(ctx, table.ID)
+		ce.updateTableBlind(ctx, table.ID)
 
-		if err := ce.tableManagerBackend.StartTableGame(table.ID); err != nil {
+		if err := ce.backend(ctx).StartTableGame(table.ID); err != nil {
 			ce.emitErrorEvent("CT Auto StartTableGame", "", err)
 			return
 		}
@@ -93,7 +108,7 @@ func (ce *competitionEngine) handleCompetitionTableCreated(table pokertable.Tabl
 		}
 
 		// auto start game if condition is reached
-		if _, err := ce.StartCompetition(); err != nil {
+		if _, err := ce.startCompetitionContext(ctx); err != nil {
 			ce.emitErrorEvent("Cash Auto StartCompetition", "", err)
 			return
 		}
@@ -105,16 +120,17 @@ func (ce *competitionEngine) handleCompetitionTableCreated(table pokertable.Tabl
 			return
 		}
 
-		ce.updateTableBlind(table.ID)
+		ce.updateTableRule(ctx, table.ID)
+		ce.updateTableBlind(ctx, table.ID)
 
-		if err := ce.tableManagerBackend.StartTableGame(table.ID); err != nil {
+		if err := ce.backend(ctx).StartTableGame(table.ID); err != nil {
 			ce.emitErrorEvent("Cash Auto StartTableGame", "", err)
 			return
 		}
 	}
 }
 
-func (ce *competitionEngine) updatePauseCompetition(table pokertable.Table, tableIdx int) {
+func (ce *competitionEngine) updatePauseCompetition(ctx context.Context, table pokertable.Table, tableIdx int) {
 	shouldReOpenGame := false
 	readyPlayersCount := 0
 	aliveParticipants := ce.generateAliveParticipants(table.State.PlayerStates)
@@ -138,27 +154,39 @@ func (ce *competitionEngine) updatePauseCompetition(table pokertable.Table, tabl
 	// re-open game
 	if shouldReOpenGame && !ce.competition.IsBreaking() {
 		nextGameCount := table.State.GameCount + 1
-		ce.tableManagerBackend.SetUpTableGame(table.ID, nextGameCount, aliveParticipants)
+		ce.backend(ctx).SetUpTableGame(table.ID, nextGameCount, aliveParticipants)
 		ce.emitEvent("Game Reopen:", "")
 	}
 }
 
-func (ce *competitionEngine) addCompetitionTable(tableSetting TableSetting, blind pokertable.TableBlindState) (string, error) {
+func (ce *competitionEngine) addCompetitionTable(ctx context.Context, tableSetting TableSetting, blind pokertable.TableBlindState) (string, error) {
 	// create table
-	setting := NewPokerTableSetting(ce.competition.ID, ce.competition.Meta, tableSetting, blind)
-	table, err := ce.tableManagerBackend.CreateTable(ce.tableOptions, setting)
+	meta := ce.competition.Meta
+	meta.Rule = ce.competition.TableSeatingRule()
+	meta.MinChipUnit = ce.competition.CurrentMinChipUnit()
+	setting := NewPokerTableSetting(ce.competition.ID, meta, tableSetting, blind)
+	table, err := ce.backend(ctx).CreateTable(ce.tableOptions, setting)
 	if err != nil {
 		return "", err
 	}
 
+	// 前注模式: 建桌後、第一手開局前設定
+	if err := ce.backend(ctx).UpdateTableAnteMode(table.ID, ce.competition.CurrentAnteMode()); err != nil {
+		ce.emitErrorEvent("update table ante mode", "", err)
+	}
+
 	if table.State.Status == pokertable.TableStateStatus_TablePausing && ce.competition.IsBreaking() {
-		ce.handleBreaking(table.ID)
+		ce.handleBreaking(ctx, table.ID)
 	}
 
 	// add table
 	ce.competition.State.Tables = append(ce.competition.State.Tables, table)
+	ce.addTableInfo(table.ID, meta.Rule)
+	ce.updateTableRule(ctx, table.ID)
+	ce.updateTableBettingStructure(ctx, table.ID)
 	ce.emitCompetitionStateEvent(CompetitionStateEvent_TableUpdated)
 	ce.emitEvent("[addCompetitionTable]", "")
+	ce.emitTypedEvent(EventType_TableCreated, TableCreatedPayload{TableID: table.ID})
 
 	// TODO: Test Only
 	ce.onTableCreated(table)
@@ -186,12 +214,17 @@ func (ce *competitionEngine) settleCompetition(endCompetitionStatus CompetitionS
 	// Emit event
 	ce.emitEvent("settleCompetition", "")
 	ce.emitCompetitionStateEvent(CompetitionStateEvent_Settled)
+	ce.emitTypedEvent(EventType_CompetitionEnded, CompetitionEndedPayload{
+		Status: endCompetitionStatus,
+		EndAt:  ce.competition.State.EndAt,
+	})
 
 	// clear caches
 	ce.gameSettledRecords = sync.Map{}
 }
 
 func (ce *competitionEngine) deleteTable(tableIdx int) {
+	ce.deleteTableInfo(ce.competition.State.Tables[tableIdx].ID)
 	ce.competition.State.Tables = append(ce.competition.State.Tables[:tableIdx], ce.competition.State.Tables[tableIdx+1:]...)
 }
 
@@ -203,7 +236,7 @@ func (ce *competitionEngine) deletePlayer(playerIdx int) {
 closeCompetitionTable 桌次關閉
   - 適用時機: 桌次結束已發生
 */
-func (ce *competitionEngine) closeCompetitionTable(table pokertable.Table, tableIdx int) {
+func (ce *competitionEngine) closeCompetitionTable(ctx context.Context, table pokertable.Table, tableIdx int) {
 	// clean data
 	delete(ce.breakingPauseResumeStates, table.ID)
 
@@ -211,9 +244,10 @@ func (ce *competitionEngine) closeCompetitionTable(table pokertable.Table, table
 	ce.deleteTable(tableIdx)
 	ce.emitEvent("closeCompetitionTable", "")
 	ce.emitCompetitionStateEvent(CompetitionStateEvent_TableUpdated)
+	ce.emitTypedEvent(EventType_TableClosed, TableClosedPayload{TableID: table.ID})
 
 	if len(ce.competition.State.Tables) == 0 && !ce.isEndStatus() {
-		ce.CloseCompetition(CompetitionStateStatus_End)
+		ce.closeCompetitionContext(ctx, CompetitionStateStatus_End)
 	}
 }
 
@@ -221,7 +255,7 @@ func (ce *competitionEngine) closeCompetitionTable(table pokertable.Table, table
 settleCompetitionTable 桌次結算
   - 適用時機: 每手結束
 */
-func (ce *competitionEngine) settleCompetitionTable(table pokertable.Table, tableIdx int) {
+func (ce *competitionEngine) settleCompetitionTable(ctx context.Context, table pokertable.Table, tableIdx int) {
 	gameSettledRecordID := fmt.Sprintf("%s.%d", table.ID, table.State.GameCount)
 	ce.mu.Lock()
 	if isGameSettled, ok := ce.gameSettledRecords.Load(gameSettledRecordID); ok && isGameSettled.(bool) {
@@ -233,9 +267,10 @@ func (ce *competitionEngine) settleCompetitionTable(table pokertable.Table, tabl
 
 	// 更新玩家相關賽事數據
 	ce.updatePlayerCompetitionTableRecords(table)
+	ce.updatePlayerPenalties(table)
 
 	// 根據是否達到停止買入做處理
-	ce.handleReBuy(table)
+	ce.handleReBuy(ctx, table)
 
 	// 處理淘汰玩家
 	knockoutPlayerIDs := ce.handleTableKnockoutPlayers(table)
@@ -244,17 +279,46 @@ func (ce *competitionEngine) settleCompetitionTable(table pokertable.Table, tabl
 	shouldCloseCompetition := false
 	switch ce.competition.Meta.Mode {
 	case CompetitionMode_CT:
-		ce.handleCTTableSettlement(knockoutPlayerIDs, table)
+		ce.handleCTTableSettlement(ctx, knockoutPlayerIDs, table)
 		shouldCloseCompetition = ce.shouldCloseCTCompetition(table.State.StartAt, len(table.AlivePlayers()))
 	case CompetitionMode_Cash:
-		ce.handleCashTableSettlement(table)
+		ce.handleCashTableSettlement(ctx, table)
 		shouldCloseCompetition = ce.shouldCloseCashCompetition(table.State.StartAt)
 	case CompetitionMode_MTT:
-		shouldCloseCompetition = ce.handleMTTTableSettlement(table)
+		shouldCloseCompetition = ce.handleMTTTableSettlement(ctx, table)
+
+		// 玩家淘汰後釋出名額給候補玩家
+		ce.promoteAlternates(ctx)
+	}
+
+	// 以手數計算的盲注等級: 回報完成手數
+	if !ce.isEndStatus() {
+		activeTableIDs := make([]string, 0, len(ce.competition.State.Tables))
+		for _, t := range ce.competition.State.Tables {
+			activeTableIDs = append(activeTableIDs, t.ID)
+		}
+		if err := ce.blind.RecordHand(table.ID, activeTableIDs); err != nil {
+			ce.emitErrorEvent("Table Settlement -> Record Blind Hand", table.ID, err)
+		}
 	}
 
 	// 中場休息處理
-	ce.handleBreaking(table.ID)
+	ce.handleBreaking(ctx, table.ID)
+
+	// 籌碼升級、補碼加入籌碼: 兩手之間處理
+	if !ce.isEndStatus() && ce.competition.IsTableExist(table.ID) {
+		ce.handleColorUp(ctx, table.ID)
+		ce.handlePendingReBuys(ctx, table.ID)
+		ce.handlePendingAddons(ctx, table.ID)
+		ce.handlePendingForfeits(ctx, table.ID)
+		ce.handlePendingChipAdjustments(ctx, table.ID)
+	}
+
+	// 混合賽制: 兩手之間輪替規則
+	if len(ce.competition.Meta.RuleRotation.Games) > 0 && !ce.isEndStatus() && ce.competition.IsTableExist(table.ID) {
+		ce.updateTableRule(ctx, table.ID)
+		ce.updateTableBlind(ctx, table.ID)
+	}
 
 	ce.refreshPlayerStatusStatistics()
 	ce.refreshPlayerCompetitionRanks()
@@ -282,16 +346,16 @@ func (ce *competitionEngine) settleCompetitionTable(table pokertable.Table, tabl
 	})
 }
 
-func (ce *competitionEngine) handleCTTableSettlement(knockoutPlayerIDs []string, table pokertable.Table) {
+func (ce *competitionEngine) handleCTTableSettlement(ctx context.Context, knockoutPlayerIDs []string, table pokertable.Table) {
 	// TableEngine Player Leave
 	if len(knockoutPlayerIDs) > 0 {
-		if err := ce.tableManagerBackend.PlayersLeave(table.ID, knockoutPlayerIDs); err != nil {
+		if err := ce.backend(ctx).PlayersLeave(table.ID, knockoutPlayerIDs); err != nil {
 			ce.emitErrorEvent("Table Settlement Knockout Players -> PlayersLeave", strings.Join(knockoutPlayerIDs, ","), err)
 		}
 	}
 }
 
-func (ce *competitionEngine) handleCashTableSettlement(table pokertable.Table) {
+func (ce *competitionEngine) handleCashTableSettlement(ctx context.Context, table pokertable.Table) {
 	// Cash Out 處理
 	leavePlayerIDs := make([]string, 0)
 	leavePlayerIndexes := make(map[string]int)
@@ -303,11 +367,11 @@ func (ce *competitionEngine) handleCashTableSettlement(table pokertable.Table) {
 	}
 
 	if len(leavePlayerIDs) > 0 {
-		ce.handleCashOut(table.ID, leavePlayerIndexes, leavePlayerIDs)
+		ce.handleCashOut(ctx, table.ID, leavePlayerIndexes, leavePlayerIDs)
 	}
 }
 
-func (ce *competitionEngine) handleMTTTableSettlement(table pokertable.Table) bool {
+func (ce *competitionEngine) handleMTTTableSettlement(ctx context.Context, table pokertable.Table) bool {
 	zeroChipPlayerIDs := make([]string, 0)
 	alivePlayerIDs := make([]string, 0)
 	for _, p := range table.State.PlayerStates {
@@ -320,28 +384,21 @@ func (ce *competitionEngine) handleMTTTableSettlement(table pokertable.Table) bo
 
 	// 更新晉級條件 (停買後更新)
 	shouldCloseCompetition := false
-	if ce.competition.State.AdvanceState.Status == CompetitionAdvanceStatus_Updating {
-		// 晉級計算
+	isAdvanceUpdating := ce.competition.State.AdvanceState.Status == CompetitionAdvanceStatus_Updating
+	if isAdvanceUpdating && ce.competition.Meta.AdvanceSetting.Rule == CompetitionAdvanceRule_PlayerCount && ce.competition.Meta.AdvanceSetting.PlayerCount > 0 {
+		// 晉級計算: M 取 N
+		shouldCloseCompetition = ce.handleAdvanceByPlayerCount(ctx, table, alivePlayerIDs, zeroChipPlayerIDs)
+	} else if isAdvanceUpdating && ce.competition.Meta.AdvanceSetting.Rule == CompetitionAdvanceRule_BlindLevel {
+		// 晉級計算: 盲注等級
 		ce.competition.State.AdvanceState.TotalTables = len(ce.competition.State.Tables)
 		shouldAdvancePauseTableGame := false
 
-		switch ce.competition.Meta.AdvanceSetting.Rule {
-		case CompetitionAdvanceRule_BlindLevel:
-			if ce.competition.CurrentBlindLevel().Level >= ce.competition.Meta.AdvanceSetting.BlindLevel {
-				shouldAdvancePauseTableGame = true
-			}
-		case CompetitionAdvanceRule_PlayerCount:
-			possibleAdvancePlayerCount := ce.competition.PlayingPlayerCount()
-			finalAdvancePlayerCount := ce.competition.Meta.AdvanceSetting.PlayerCount
-			// 如果要取晉級人數 n 人 (finalAdvancePlayerCount)，必須要活著的人數 (possibleAdvancePlayerCount) 小於 n 人才暫停該桌
-			// TODO: 如果 M 取 N 剛好整除，要是 possibleAdvancePlayerCount <= finalAdvancePlayerCount，沒整除才是 possibleAdvancePlayerCount < finalAdvancePlayerCount
-			if possibleAdvancePlayerCount < finalAdvancePlayerCount {
-				shouldAdvancePauseTableGame = true
-			}
+		if ce.competition.CurrentBlindLevel().Level >= ce.competition.Meta.AdvanceSetting.BlindLevel {
+			shouldAdvancePauseTableGame = true
 		}
 
 		if shouldAdvancePauseTableGame {
-			if err := ce.tableManagerBackend.PauseTable(table.ID); err != nil {
+			if err := ce.backend(ctx).PauseTable(table.ID); err != nil {
 				ce.emitErrorEvent(fmt.Sprintf("[%s][%d] Advance Pause Table", table.ID, table.State.GameCount), "", err)
 			} else {
 				ce.competition.State.AdvanceState.UpdatedTables++
@@ -349,17 +406,17 @@ func (ce *competitionEngine) handleMTTTableSettlement(table pokertable.Table) bo
 
 				// 晉級條件達成: 結束賽事
 				if ce.competition.State.AdvanceState.TotalTables == ce.competition.State.AdvanceState.UpdatedTables {
-					ce.competition.State.AdvanceState.Status = CompetitionAdvanceStatus_End
+					ce.settleAdvancePlayers([]advanceElimination{})
 					shouldCloseCompetition = true
 				}
 			}
 		} else {
-			ce.handleMTTTableSettlementNextStep(table, alivePlayerIDs, zeroChipPlayerIDs)
+			ce.handleMTTTableSettlementNextStep(ctx, table, alivePlayerIDs, zeroChipPlayerIDs)
 		}
 	} else {
 		// 無晉級計算
 		// 拆併桌更新桌次狀態
-		ce.handleMTTTableSettlementNextStep(table, alivePlayerIDs, zeroChipPlayerIDs)
+		ce.handleMTTTableSettlementNextStep(ctx, table, alivePlayerIDs, zeroChipPlayerIDs)
 
 		// 判斷是否要關閉賽事
 		shouldCloseCompetition = !ce.isEndStatus() && ce.competition.State.BlindState.IsStopBuyIn() && len(alivePlayerIDs) == 1 && len(ce.competition.State.Tables) == 1
@@ -368,9 +425,14 @@ func (ce *competitionEngine) handleMTTTableSettlement(table pokertable.Table) bo
 	return shouldCloseCompetition
 }
 
-func (ce *competitionEngine) handleMTTTableSettlementNextStep(table pokertable.Table, alivePlayerIDs, zeroChipPlayerIDs []string) {
+func (ce *competitionEngine) handleMTTTableSettlementNextStep(ctx context.Context, table pokertable.Table, alivePlayerIDs, zeroChipPlayerIDs []string) {
 	// 拆併桌監管器更新狀態
-	releaseCount, newPlayerIDs, err := ce.regulator.SyncState(table.ID, len(zeroChipPlayerIDs))
+	var releaseCount int
+	var newPlayerIDs []string
+	err := ce.regulate(ctx, func() (err error) {
+		releaseCount, newPlayerIDs, err = ce.regulator.SyncState(table.ID, len(zeroChipPlayerIDs))
+		return err
+	})
 	if err != nil {
 		ce.emitErrorEvent(fmt.Sprintf("[%s][%d] MTT Regulator Sync State", table.ID, table.State.GameCount), "", err)
 		return
@@ -403,14 +465,14 @@ func (ce *competitionEngine) handleMTTTableSettlementNextStep(table pokertable.T
 	leavePlayerIDs := make([]string, 0) // leavePlayerIDs = releasePlayerIDs + zeroChipPlayerIDs
 
 	if releaseCount > 0 {
-		// pick release players from table
-		for idx, playerID := range table.State.NextBBOrderPlayerIDs {
-			if idx < releaseCount {
-				releasePlayerIDs = append(releasePlayerIDs, playerID)
-			} else {
-				break
+		// pick release players from table (依拆併桌策略從下一手 BB 順序選出存活玩家)
+		nextBBOrderAlivePlayerIDs := make([]string, 0)
+		for _, playerID := range table.State.NextBBOrderPlayerIDs {
+			if funk.ContainsString(alivePlayerIDs, playerID) {
+				nextBBOrderAlivePlayerIDs = append(nextBBOrderAlivePlayerIDs, playerID)
 			}
 		}
+		releasePlayerIDs = ce.balancingStrategy.SelectMovingPlayers(nextBBOrderAlivePlayerIDs, releaseCount)
 
 		// leave players
 		leavePlayerIDs = append(leavePlayerIDs, releasePlayerIDs...)
@@ -458,10 +520,15 @@ func (ce *competitionEngine) handleMTTTableSettlementNextStep(table pokertable.T
 		}
 	}
 
+	// 檢查座位限制 (指定座位、同桌衝突)
+	if len(newJoinPlayers) > 0 {
+		newJoinPlayers = ce.newSeatingJoinPlayers(table.ID, newJoinPlayers, leavePlayerIDs)
+	}
+
 	// balance table players (有 newJoinPlayers && leavePlayerIDs)
 	currentTablePlayerCount := len(table.State.PlayerStates)
 	if !(len(newJoinPlayers) == 0 && len(leavePlayerIDs) == 0) {
-		if tablePlayerSeatMap, err := ce.tableManagerBackend.UpdateTablePlayers(table.ID, newJoinPlayers, leavePlayerIDs); err != nil {
+		if tablePlayerSeatMap, err := ce.backend(ctx).UpdateTablePlayers(table.ID, newJoinPlayers, leavePlayerIDs); err != nil {
 			ce.emitErrorEvent(fmt.Sprintf("[%s][%d] UpdateTablePlayers", table.ID, table.State.GameCount), "", err)
 		} else {
 			currentTablePlayerCount = len(tablePlayerSeatMap)
@@ -469,8 +536,13 @@ func (ce *competitionEngine) handleMTTTableSettlementNextStep(table pokertable.T
 			// 玩家進等待區
 			if len(releasePlayerIDs) > 0 {
 				// release 玩家進等待區
+				moveReason := PlayerMoveReason_Balancing
+				if len(releasePlayerIDs) >= len(alivePlayerIDs) {
+					moveReason = PlayerMoveReason_TableBreak
+				}
 				for _, playerIdx := range releaseCompetitionPlayerIndices {
 					releasePlayer := ce.competition.State.Players[playerIdx]
+					ce.startPlayerMove(releasePlayer, moveReason)
 					releasePlayer.CurrentTableID = ""
 					releasePlayer.CurrentSeat = UnsetValue
 					releasePlayer.Status = CompetitionPlayerStatus_WaitingTableBalancing
@@ -478,7 +550,9 @@ func (ce *competitionEngine) handleMTTTableSettlementNextStep(table pokertable.T
 				}
 
 				// 拆併桌監管器釋放玩家
-				if err := ce.regulator.ReleasePlayers(table.ID, releasePlayerIDs); err != nil {
+				if err := ce.regulate(ctx, func() error {
+					return ce.regulator.ReleasePlayers(table.ID, releasePlayerIDs)
+				}); err != nil {
 					ce.emitErrorEvent(fmt.Sprintf("[%s][%d] MTT Regulator Release Players", table.ID, table.State.GameCount), strings.Join(releasePlayerIDs, ","), err)
 				} else {
 					fmt.Printf("[MTT#DEBUG#handleMTTTableSettlementNextStep] regulator release (%d) players %+v at table (%s)\n", len(releasePlayerIDs), releasePlayerIDs, table.ID)
@@ -493,14 +567,26 @@ func (ce *competitionEngine) handleMTTTableSettlementNextStep(table pokertable.T
 					newJoinCompetitionPlayer.Status = CompetitionPlayerStatus_Playing
 					newJoinCompetitionPlayer.CurrentSeat = seat
 					ce.emitPlayerEvent("[UpdateTablePlayers] reserve table", newJoinCompetitionPlayer)
+					ce.commitPlayerMove(newJoinCompetitionPlayer)
 				}
 			}
 		}
 	}
 
+	// 指定移動的玩家 (ex: 移至焦點桌)
+	if len(ce.playerMoves) > 0 {
+		movablePlayerIDs := make([]string, 0)
+		for _, playerID := range alivePlayerIDs {
+			if !funk.ContainsString(releasePlayerIDs, playerID) {
+				movablePlayerIDs = append(movablePlayerIDs, playerID)
+			}
+		}
+		currentTablePlayerCount -= ce.handlePlayerMoves(ctx, table, movablePlayerIDs)
+	}
+
 	if currentTablePlayerCount <= 0 {
 		// close table
-		if err := ce.tableManagerBackend.CloseTable(table.ID); err != nil {
+		if err := ce.backend(ctx).CloseTable(table.ID); err != nil {
 			ce.emitErrorEvent("Table Settlement -> MTT Close Table", "", err)
 		}
 	}
@@ -524,16 +610,21 @@ func (ce *competitionEngine) handleMTTTableSettlementNextStep(table pokertable.T
 	)
 }
 
-func (ce *competitionEngine) handleCashOut(tableID string, leavePlayerIndexes map[string]int, leavePlayerIDs []string) {
+func (ce *competitionEngine) handleCashOut(ctx context.Context, tableID string, leavePlayerIndexes map[string]int, leavePlayerIDs []string) {
 	// TableEngine Player Leave
-	if err := ce.tableManagerBackend.PlayersLeave(tableID, leavePlayerIDs); err != nil {
+	if err := ce.backend(ctx).PlayersLeave(tableID, leavePlayerIDs); err != nil {
 		ce.emitErrorEvent("handleCashOut -> PlayersLeave", strings.Join(leavePlayerIDs, ","), err)
 	}
 
 	// Cash Out
 	for _, leavePlayerID := range leavePlayerIDs {
 		if playerIdx, exist := leavePlayerIndexes[leavePlayerID]; exist {
-			ce.onCompetitionPlayerCashOut(ce.competition.ID, ce.competition.State.Players[playerIdx])
+			cp := ce.competition.State.Players[playerIdx]
+			ce.emitTypedEvent(EventType_PlayerCashedOut, PlayerCashedOutPayload{
+				PlayerID: cp.PlayerID,
+				TableID:  tableID,
+				Chips:    cp.Chips,
+			})
 		}
 	}
 
@@ -550,7 +641,7 @@ func (ce *competitionEngine) handleCashOut(tableID string, leavePlayerIndexes ma
 	ce.emitCompetitionStateEvent(CompetitionStateEvent_CashOutPlayers)
 }
 
-func (ce *competitionEngine) handleBreaking(tableID string) {
+func (ce *competitionEngine) handleBreaking(ctx context.Context, tableID string) {
 	if !ce.competition.IsBreaking() {
 		fmt.Println("[DEBUG#handleBreaking] is not breaking. TableID:", tableID)
 		return
@@ -603,16 +694,21 @@ func (ce *competitionEngine) handleBreaking(tableID string) {
 				return
 			}
 
+			// 暫停中的桌次沒有進行中的牌局，於恢復開局前更新規則、籌碼升級
+			ce.updateTableRule(context.Background(), tableID)
+			ce.updateTableBlind(context.Background(), tableID)
+			ce.handleColorUp(context.Background(), tableID)
+
 			if t.State.GameCount > 0 {
 				nextGameCount := t.State.GameCount + 1
 				participants := ce.generateAliveParticipants(t.State.PlayerStates)
-				ce.tableManagerBackend.SetUpTableGame(tableID, nextGameCount, participants)
+				ce.backend(context.Background()).SetUpTableGame(tableID, nextGameCount, participants)
 				ce.breakingPauseResumeStates[tableID][ce.competition.State.BlindState.CurrentLevelIndex] = true
 			} else if t.State.GameCount == 0 {
 				participants := ce.generateAliveParticipants(t.State.PlayerStates)
 				ce.breakingPauseResumeStates[tableID][ce.competition.State.BlindState.CurrentLevelIndex] = true
 				if len(participants) >= ce.competition.Meta.TableMinPlayerCount {
-					if err := ce.tableManagerBackend.StartTableGame(tableID); err != nil {
+					if err := ce.backend(context.Background()).StartTableGame(tableID); err != nil {
 						ce.emitErrorEvent("resume game from breaking & auto start game", "", err)
 					}
 				}
@@ -659,13 +755,17 @@ func (ce *competitionEngine) handleTableKnockoutPlayers(table pokertable.Table)
 	return knockoutPlayerIDs
 }
 
-func (ce *competitionEngine) handleReBuy(table pokertable.Table) {
+func (ce *competitionEngine) handleReBuy(ctx context.Context, table pokertable.Table) {
 	if ce.competition.State.BlindState.IsStopBuyIn() {
 		return
 	}
 
 	// 延遲買入: 處理可補碼玩家
 	reBuyEndAt := time.Now().Add(time.Second * time.Duration(ce.competition.Meta.ReBuySetting.WaitingTime)).Unix()
+	mttReBuyEndAt := int64(UnsetValue)
+	if ce.competition.Meta.ReBuySetting.MTTWaitingTime > 0 {
+		mttReBuyEndAt = time.Now().Add(time.Second * time.Duration(ce.competition.Meta.ReBuySetting.MTTWaitingTime)).Unix()
+	}
 	reBuyPlayerIDs := make([]string, 0)
 	for _, player := range table.State.PlayerStates {
 		if player.Bankroll > 0 {
@@ -681,6 +781,10 @@ func (ce *competitionEngine) handleReBuy(table pokertable.Table) {
 		}
 
 		cp := ce.competition.State.Players[rebuyPlayerIdx]
+		if cp.IsForfeited {
+			// 已棄賽玩家不可補碼
+			continue
+		}
 		if !cp.IsReBuying {
 			if cp.ReBuyTimes < ce.competition.Meta.ReBuySetting.MaxTime {
 				cp.Status = CompetitionPlayerStatus_ReBuyWaiting
@@ -689,7 +793,7 @@ func (ce *competitionEngine) handleReBuy(table pokertable.Table) {
 				cp.ReBuyEndAt = reBuyEndAt
 				if ce.competition.Meta.Mode == CompetitionMode_MTT {
 					cp.CurrentSeat = UnsetValue
-					cp.ReBuyEndAt = UnsetValue
+					cp.ReBuyEndAt = mttReBuyEndAt
 				}
 
 				reBuyPlayerIDs = append(reBuyPlayerIDs, player.PlayerID)
@@ -699,6 +803,14 @@ func (ce *competitionEngine) handleReBuy(table pokertable.Table) {
 		}
 	}
 
+	// MTT 補碼時間到後淘汰
+	if ce.competition.Meta.Mode == CompetitionMode_MTT {
+		if len(reBuyPlayerIDs) > 0 && mttReBuyEndAt != UnsetValue {
+			ce.scheduleMTTReBuyKnockout(reBuyPlayerIDs, mttReBuyEndAt)
+		}
+		return
+	}
+
 	// CT/Cash 保留座位時間到後處理
 	keepSeatModes := []CompetitionMode{
 		CompetitionMode_CT,
@@ -761,11 +873,11 @@ func (ce *competitionEngine) handleReBuy(table pokertable.Table) {
 				ce.emitEvent("re buy leave", strings.Join(leavePlayerIDs, ","))
 				switch ce.competition.Meta.Mode {
 				case CompetitionMode_CT:
-					if err := ce.tableManagerBackend.PlayersLeave(table.ID, leavePlayerIDs); err != nil {
+					if err := ce.backend(context.Background()).PlayersLeave(table.ID, leavePlayerIDs); err != nil {
 						ce.emitErrorEvent("Re Buy Leave Players -> Table PlayersLeave", strings.Join(leavePlayerIDs, ","), err)
 					}
 				case CompetitionMode_Cash:
-					ce.handleCashOut(table.ID, leavePlayerIndexes, leavePlayerIDs)
+					ce.handleCashOut(context.Background(), table.ID, leavePlayerIndexes, leavePlayerIDs)
 				}
 			}
 		}); err != nil {
@@ -774,6 +886,94 @@ func (ce *competitionEngine) handleReBuy(table pokertable.Table) {
 	}
 }
 
+/*
+scheduleMTTReBuyKnockout MTT 補碼時間到後淘汰未補碼玩家
+  - 即使仍在買入期間，超過補碼時間的玩家也會淘汰 (見 knockoutReBuyExpiredPlayers)
+*/
+func (ce *competitionEngine) scheduleMTTReBuyKnockout(reBuyPlayerIDs []string, reBuyEndAt int64) {
+	bufferSeconds := 1 // 確保 time.Now() 已超過 ReBuyEndAt
+	reBuyEndAtTime := time.Unix(reBuyEndAt, 0).Add(time.Second * time.Duration(bufferSeconds))
+	if err := timebank.NewTimeBank().NewTaskWithDeadline(reBuyEndAtTime, func(isCancelled bool) {
+		if isCancelled {
+			return
+		}
+		ce.knockoutReBuyExpiredPlayers(reBuyPlayerIDs)
+	}); err != nil {
+		ce.emitErrorEvent("MTT ReBuy Add Timer", "", err)
+	}
+}
+
+/*
+knockoutReBuyExpiredPlayers 淘汰超過補碼時間的玩家
+  - 買入期間參賽人數仍會增加，排名於停止買入或賽事結算時才決定 (見 rankReBuyExpiredPlayers)
+  - 於鎖內更新玩家狀態，解鎖後才發送事件
+*/
+func (ce *competitionEngine) knockoutReBuyExpiredPlayers(reBuyPlayerIDs []string) {
+	ce.mu.Lock()
+	if ce.isEndStatus() {
+		ce.mu.Unlock()
+		return
+	}
+
+	now := time.Now().Unix()
+	knockoutPlayers := make([]CompetitionPlayer, 0)
+	for _, reBuyPlayerID := range reBuyPlayerIDs {
+		cp := ce.competition.findPlayer(reBuyPlayerID)
+		if cp == nil {
+			continue
+		}
+
+		// 已補碼、已淘汰或重新開始計時 (再次補碼後又沒籌碼) 的玩家不處理
+		if cp.Chips > 0 || cp.Status != CompetitionPlayerStatus_ReBuyWaiting || !cp.IsReBuying {
+			continue
+		}
+		if cp.ReBuyEndAt == UnsetValue || now <= cp.ReBuyEndAt {
+			continue
+		}
+
+		cp.Status = CompetitionPlayerStatus_Knockout
+		cp.KnockoutAt = now
+		cp.IsReBuying = false
+		cp.ReBuyEndAt = UnsetValue
+		cp.CurrentSeat = UnsetValue
+		knockoutPlayers = append(knockoutPlayers, *cp)
+	}
+	if len(knockoutPlayers) == 0 {
+		ce.mu.Unlock()
+		return
+	}
+
+	ce.refreshPlayerStatusStatistics()
+	ce.refreshPlayerCompetitionRanks()
+	ce.mu.Unlock()
+
+	knockoutPlayerIDs := make([]string, 0, len(knockoutPlayers))
+	for idx := range knockoutPlayers {
+		ce.emitPlayerEvent("re buy expired knockout", &knockoutPlayers[idx])
+		knockoutPlayerIDs = append(knockoutPlayerIDs, knockoutPlayers[idx].PlayerID)
+	}
+	ce.emitEvent("Re Buy Expired Knockout Players", strings.Join(knockoutPlayerIDs, ","))
+	ce.emitCompetitionStateEvent(CompetitionStateEvent_KnockoutPlayers)
+}
+
+/*
+rankReBuyExpiredPlayers 記錄買入期間超過補碼時間而淘汰的玩家排名
+  - 適用時機: 停止買入、賽事結算 (參賽人數不再增加)
+  - 排名: 在仍有籌碼與仍可補碼的玩家之後，越早淘汰排名越後面
+*/
+func (ce *competitionEngine) rankReBuyExpiredPlayers() {
+	knockoutPlayerRankings := ce.GetSortedReBuyExpiredKnockoutPlayerRankings()
+	remainingPlayerCount := ce.competition.PlayingPlayerCount() + ce.competition.GetPlayerCountByStatus(CompetitionPlayerStatus_ReBuyWaiting)
+	for idx, knockoutPlayerID := range knockoutPlayerRankings {
+		ce.competition.State.Rankings = append(ce.competition.State.Rankings, &CompetitionRank{
+			PlayerID:   knockoutPlayerID,
+			FinalChips: 0,
+		})
+		rank := remainingPlayerCount + (len(knockoutPlayerRankings) - idx)
+		ce.emitCompetitionStateFinalPlayerRankEvent(knockoutPlayerID, rank)
+	}
+}
+
 func (ce *competitionEngine) updatePlayerCompetitionTableRecords(table pokertable.Table) {
 	// 更新玩家統計數據
 	gamePlayerPreflopFoldTimes := 0
@@ -927,6 +1127,9 @@ func (ce *competitionEngine) updatePlayerFinalRankings() {
 		CompetitionStateStatus_StoppedBuyIn,
 	}
 	if funk.Contains(settleStatuses, ce.competition.State.Status) {
+		// 買入期間補碼逾時淘汰的玩家尚未記錄排名
+		ce.rankReBuyExpiredPlayers()
+
 		finalRankings := ce.GetParticipatedPlayerCompetitionRankingData(ce.competition.ID, ce.competition.State.Players)
 		// 名次由後面到前面 insert 至 Rankings
 		for i := len(finalRankings) - 1; i >= 0; i-- {
@@ -984,13 +1187,162 @@ func (ce *competitionEngine) shouldCloseCashCompetition(tableStartAt int64) bool
 	return time.Now().Unix() > tableEndAt
 }
 
-func (ce *competitionEngine) updateTableBlind(tableID string) {
-	level, ante, dealer, sb, bb := ce.competition.CurrentBlindData()
-	if err := ce.tableManagerBackend.UpdateBlind(tableID, level, ante, dealer, sb, bb); err != nil {
+func (ce *competitionEngine) updateTableBlind(ctx context.Context, tableID string) {
+	tableGameCount := 0
+	if tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
+		return t.ID == tableID
+	}); tableIdx != UnsetValue {
+		tableGameCount = ce.competition.State.Tables[tableIdx].State.GameCount
+	}
+
+	level, ante, dealer, sb, bb := ce.competition.CurrentTableBlindData(tableGameCount)
+	if err := ce.backend(ctx).UpdateBlind(tableID, level, ante, dealer, sb, bb); err != nil {
 		ce.emitErrorEvent("update blind", "", err)
 	}
 }
 
+/*
+updateTableRule 混合賽制: 更新桌次規則與下注結構
+  - 適用時機: 兩手之間 (桌次建立後開局前、每手結算後、中場休息結束恢復開局前)
+  - 桌次於下一手建立牌局時套用規則，盲注等級變更時該桌可能有進行中的牌局，因此不處理
+  - 桌次當前規則記錄於 TableInfo.Rule，桌次 Meta.Rule 為建桌規則 (決定座位管理)
+*/
+func (ce *competitionEngine) updateTableRule(ctx context.Context, tableID string) {
+	if len(ce.competition.Meta.RuleRotation.Games) == 0 {
+		return
+	}
+
+	ce.updateTableBettingStructure(ctx, tableID)
+
+	tableInfo := ce.competition.FindTableInfo(tableID)
+	if tableInfo == nil {
+		return
+	}
+
+	tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
+		return t.ID == tableID
+	})
+	if tableIdx == UnsetValue {
+		return
+	}
+
+	rule := ce.competition.CurrentTableRule(ce.competition.State.Tables[tableIdx].State.GameCount)
+	if rule == tableInfo.Rule {
+		return
+	}
+
+	if err := ce.backend(ctx).UpdateTableRule(tableID, string(rule)); err != nil {
+		ce.emitErrorEvent("update table rule", "", err)
+		return
+	}
+	tableInfo.Rule = rule
+}
+
+/*
+updateTableBettingStructure 更新桌次下注結構 (依輪替賽制或賽事設定)
+  - 適用時機: 桌次建立後、兩手之間 (見 updateTableRule)
+  - 桌次當前下注結構記錄於 TableInfo.BettingStructure
+*/
+func (ce *competitionEngine) updateTableBettingStructure(ctx context.Context, tableID string) {
+	tableInfo := ce.competition.FindTableInfo(tableID)
+	if tableInfo == nil {
+		return
+	}
+
+	tableGameCount := 0
+	if tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
+		return t.ID == tableID
+	}); tableIdx != UnsetValue {
+		tableGameCount = ce.competition.State.Tables[tableIdx].State.GameCount
+	}
+
+	structure := ce.competition.CurrentTableBettingStructure(tableGameCount)
+	if structure == tableInfo.BettingStructure {
+		return
+	}
+
+	if err := ce.backend(ctx).UpdateTableBettingStructure(tableID, structure); err != nil {
+		ce.emitErrorEvent("update table betting structure", "", err)
+		return
+	}
+	tableInfo.BettingStructure = structure
+}
+
+/*
+handleColorUp 籌碼升級處理
+  - 適用時機: 兩手之間 (每手結算後、中場休息結束恢復開局前)，由呼叫端保證該桌沒有進行中的牌局
+  - 桌次最小單位籌碼量小於當前設定時，移除玩家零碎籌碼，並以補碼方式 (PlayerRedeemChips) 將籌碼差額套用到桌次
+*/
+func (ce *competitionEngine) handleColorUp(ctx context.Context, tableID string) {
+	colorUp, ok := ce.competition.CurrentColorUp()
+	if !ok {
+		return
+	}
+
+	tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
+		return t.ID == tableID
+	})
+	if tableIdx == UnsetValue {
+		return
+	}
+
+	table := ce.competition.State.Tables[tableIdx]
+	previousMinChipUnit := ce.competition.TableMinChipUnit(tableID)
+	minChipUnit := ce.competition.CurrentMinChipUnit()
+	if previousMinChipUnit >= minChipUnit {
+		return
+	}
+
+	playerChips := make(map[string]int64)
+	for _, ps := range table.State.PlayerStates {
+		if ps.Bankroll > 0 {
+			playerChips[ps.PlayerID] = ps.Bankroll
+		}
+	}
+
+	// 套用籌碼差額，失敗的玩家維持原籌碼
+	results := make([]ColorUpPlayerResult, 0, len(playerChips))
+	for _, result := range ColorUpChips(colorUp.Method, previousMinChipUnit, minChipUnit, playerChips) {
+		if delta := result.Chips - playerChips[result.PlayerID]; delta != 0 {
+			if err := ce.backend(ctx).PlayerRedeemChips(tableID, pokertable.JoinPlayer{
+				PlayerID:    result.PlayerID,
+				RedeemChips: delta,
+			}); err != nil {
+				ce.emitErrorEvent("color up table -> PlayerRedeemChips", result.PlayerID, err)
+				continue
+			}
+		}
+		results = append(results, result)
+	}
+
+	record := &ColorUpRecord{
+		TableID:             tableID,
+		BlindLevelIndex:     ce.competition.State.BlindState.CurrentLevelIndex,
+		PreviousMinChipUnit: previousMinChipUnit,
+		MinChipUnit:         minChipUnit,
+		Method:              colorUp.Method,
+		Players:             results,
+		CreatedAt:           time.Now().Unix(),
+	}
+
+	// 更新賽事玩家籌碼
+	playerIdxMap := ce.competition.GetPlayerIndexMap()
+	for _, result := range results {
+		if idx := table.FindPlayerIdx(result.PlayerID); idx != UnsetValue {
+			table.State.PlayerStates[idx].Bankroll = result.Chips
+		}
+		if playerIdx, exist := playerIdxMap[result.PlayerID]; exist {
+			cp := ce.competition.State.Players[playerIdx]
+			cp.Chips = result.Chips
+			ce.emitPlayerEvent("color up", cp)
+		}
+	}
+
+	ce.competition.State.ColorUps = append(ce.competition.State.ColorUps, record)
+	ce.emitCompetitionStateEvent(CompetitionStateEvent_ChipsColoredUp)
+	ce.emitEvent(fmt.Sprintf("Color Up Table (%s) -> min chip unit: %d", tableID, minChipUnit), "")
+}
+
 func (ce *competitionEngine) isEndStatus() bool {
 	endStatuses := []CompetitionStateStatus{
 		CompetitionStateStatus_End,
@@ -1000,11 +1352,12 @@ func (ce *competitionEngine) isEndStatus() bool {
 	return funk.Contains(endStatuses, ce.competition.State.Status)
 }
 
-func (ce *competitionEngine) initBlind(meta CompetitionMeta) {
+func (ce *competitionEngine) initBlind(ctx context.Context, meta CompetitionMeta) {
 	options := &pokerblind.BlindOptions{
 		ID:                   meta.Blind.ID,
 		InitialLevel:         meta.Blind.InitialLevel,
 		FinalBuyInLevelIndex: meta.Blind.FinalBuyInLevelIndex,
+		HandCountMode:        pokerblind.HandCountMode(meta.Blind.HandCountMode),
 		Levels: funk.Map(meta.Blind.Levels, func(bl BlindLevel) pokerblind.BlindLevel {
 			dealer := int64(0)
 			if meta.Rule == CompetitionRule_ShortDeck {
@@ -1018,7 +1371,8 @@ func (ce *competitionEngine) initBlind(meta CompetitionMeta) {
 					SB:     bl.SB,
 					BB:     bl.BB,
 				},
-				Duration: bl.Duration,
+				Duration:  bl.Duration,
+				HandCount: bl.HandCount,
 			}
 		}).([]pokerblind.BlindLevel),
 	}
@@ -1029,14 +1383,20 @@ func (ce *competitionEngine) initBlind(meta CompetitionMeta) {
 		}
 
 		ce.competition.State.BlindState.CurrentLevelIndex = bs.Status.CurrentLevelIndex
+		// 以手數計算的等級升級後才會決定後續等級結束時間
+		copy(ce.competition.State.BlindState.EndAts, bs.Status.LevelEndAts)
 		// fmt.Println("[DEBUG#initBlind] BlindState.CurrentLevelIndex:", ce.competition.State.BlindState.CurrentLevelIndex)
 		for _, table := range ce.competition.State.Tables {
-			ce.updateTableBlind(table.ID)
-			ce.handleBreaking(table.ID)
+			ce.updateTableBlind(context.Background(), table.ID)
+			ce.handleBreaking(context.Background(), table.ID)
 		}
 
 		ce.emitCompetitionStateEvent(CompetitionStateEvent_BlindUpdated) // change CurrentLevelIndex
 		ce.emitEvent("Blind CurrentLevelIndex Update", "")
+		ce.emitBlindLevelChangedEvent()
+
+		// 增購等級開始: 發送增購邀請
+		ce.offerAddons()
 
 		// 更新賽事狀態: 停止買入
 		if ce.competition.State.BlindState.IsStopBuyIn() {
@@ -1045,12 +1405,19 @@ func (ce *competitionEngine) initBlind(meta CompetitionMeta) {
 				ce.initAdvancement()
 
 				ce.competition.State.Status = CompetitionStateStatus_StoppedBuyIn
+				ce.expireAlternates()
 
 				// MTT 在停止買入階段，更新拆併桌監管器狀態
 				if ce.competition.Meta.Mode == CompetitionMode_MTT {
-					ce.regulator.SetStatus(regulator.CompetitionStatus_AfterRegDeadline)
+					_ = ce.regulate(context.Background(), func() error {
+						ce.regulator.SetStatus(regulator.CompetitionStatus_AfterRegDeadline)
+						return nil
+					})
 				}
 
+				// 買入期間補碼逾時淘汰的玩家排名在停止買入淘汰的玩家之後
+				ce.rankReBuyExpiredPlayers()
+
 				// 淘汰沒資格玩家
 				playerIdxMap := ce.competition.GetPlayerIndexMap()
 				knockoutPlayerRankings := ce.GetSortedStopBuyInKnockoutPlayerRankings()
@@ -1077,7 +1444,7 @@ func (ce *competitionEngine) initBlind(meta CompetitionMeta) {
 
 					// 玩家離座 (CT only), 因為 MTT 在結算沒籌碼時就已經離開該桌次了
 					if isCTReBuying && len(ce.competition.State.Tables) > 0 {
-						if err := ce.tableManagerBackend.PlayersLeave(ce.competition.State.Tables[0].ID, []string{knockoutPlayerID}); err != nil {
+						if err := ce.backend(context.Background()).PlayersLeave(ce.competition.State.Tables[0].ID, []string{knockoutPlayerID}); err != nil {
 							ce.emitErrorEvent("Stopped BuyIn Knockout Players -> PlayersLeave", knockoutPlayerID, err)
 						}
 					}
@@ -1130,6 +1497,7 @@ func (ce *competitionEngine) activateBlind() error {
 
 	if ce.competition.Meta.Blind.FinalBuyInLevelIndex == UnsetValue || ce.competition.Meta.Blind.FinalBuyInLevelIndex < NoStopBuyInIndex {
 		ce.competition.State.Status = CompetitionStateStatus_StoppedBuyIn
+		ce.expireAlternates()
 	} else {
 		ce.competition.State.Status = CompetitionStateStatus_DelayedBuyIn
 	}
@@ -1,6 +1,7 @@
 package pokercompetition
 
 import (
+	pokerblind "github.com/weedbox/pokercompetition/blind"
 	"github.com/weedbox/pokertable"
 )
 
@@ -18,9 +19,11 @@ type TableSetting struct {
 }
 
 type JoinPlayer struct {
-	PlayerID    string `json:"player_id"`
-	RedeemChips int64  `json:"redeem_chips"`
-	Unit        int    `json:"unit"` // 買入發數
+	PlayerID     string          `json:"player_id"`
+	RedeemChips  int64           `json:"redeem_chips"`
+	Unit         int             `json:"unit"`          // 買入發數
+	Seating      SeatingMetadata `json:"seating"`       // 座位限制資訊 (MTT 配桌使用)
+	AddonPackage string          `json:"addon_package"` // 增購方案名稱 (增購使用，未指定時依兌換籌碼數對應方案)
 }
 
 func NewPokerTableSetting(competitionID string, competitionMeta CompetitionMeta, tableSetting TableSetting, blind pokertable.TableBlindState) pokertable.TableSetting {
@@ -40,3 +43,31 @@ func NewPokerTableSetting(competitionID string, competitionMeta CompetitionMeta,
 		Blind:       blind,
 	}
 }
+
+/*
+NewBlindFromOptions 將 pokerblind 盲注結構 (ex: pokerblind.Generate 產生的結構) 轉換成賽事盲注資訊
+  - allowAddonOnBreaks: 中場休息等級是否允許增購
+*/
+func NewBlindFromOptions(options pokerblind.BlindOptions, dealerBlindTime int, allowAddonOnBreaks bool) Blind {
+	levels := make([]BlindLevel, 0, len(options.Levels))
+	for _, bl := range options.Levels {
+		levels = append(levels, BlindLevel{
+			Level:      bl.Level,
+			SB:         bl.Blind.SB,
+			BB:         bl.Blind.BB,
+			Ante:       bl.Ante,
+			Duration:   bl.Duration,
+			HandCount:  bl.HandCount,
+			AllowAddon: bl.Level == -1 && allowAddonOnBreaks,
+		})
+	}
+
+	return Blind{
+		ID:                   options.ID,
+		InitialLevel:         options.InitialLevel,
+		FinalBuyInLevelIndex: options.FinalBuyInLevelIndex,
+		DealerBlindTime:      dealerBlindTime,
+		HandCountMode:        string(options.HandCountMode),
+		Levels:               levels,
+	}
+}
@@ -0,0 +1,464 @@
+package pokercompetition
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/pokertable"
+	"github.com/weedbox/timebank"
+)
+
+type DirectorAction string
+
+const (
+	DirectorAction_Disqualify  DirectorAction = "disqualify"   // 取消資格
+	DirectorAction_Penalize    DirectorAction = "penalize"     // 處罰 (暫停參與 N 圈或 N 分鐘)
+	DirectorAction_AdjustChips DirectorAction = "adjust_chips" // 調整籌碼
+	DirectorAction_MovePlayer  DirectorAction = "move_player"  // 指定換桌
+)
+
+type DirectorAuditRecord struct {
+	ID             string         `json:"id"`              // 紀錄 ID
+	CompetitionID  string         `json:"competition_id"`  // 賽事 ID
+	DirectorID     string         `json:"director_id"`     // 執行裁判 ID
+	Action         DirectorAction `json:"action"`          // 裁判操作
+	PlayerID       string         `json:"player_id"`       // 玩家 ID
+	Reason         string         `json:"reason"`          // 原因
+	TableID        string         `json:"table_id"`        // 指定桌次 ID (move_player 用)
+	Seat           int            `json:"seat"`            // 指定座位 (move_player 用)
+	Chips          int64          `json:"chips"`           // 調整籌碼數 (adjust_chips 用, 負數表示扣除)
+	PenaltyOrbits  int            `json:"penalty_orbits"`  // 處罰圈數 (penalize 用)
+	PenaltySeconds int            `json:"penalty_seconds"` // 處罰秒數 (penalize 用)
+	CreatedAt      int64          `json:"created_at"`      // 紀錄時間 (Seconds)
+}
+
+type PlayerPenalty struct {
+	Reason         string `json:"reason"`          // 處罰原因
+	RemainingHands int    `json:"remaining_hands"` // 剩餘處罰手數 (N 圈換算)
+	EndAt          int64  `json:"end_at"`          // 處罰結束時間 (Seconds, 未設定時為 UnsetValue)
+	CreatedAt      int64  `json:"created_at"`      // 處罰開始時間 (Seconds)
+}
+
+// IsActive 處罰是否仍在進行中 (手數與時間皆結束才解除)
+func (p *PlayerPenalty) IsActive(now int64) bool {
+	if p == nil {
+		return false
+	}
+	return p.RemainingHands > 0 || (p.EndAt != UnsetValue && now < p.EndAt)
+}
+
+// addDirectorAuditRecord 新增裁判操作紀錄 (需持有 ce.mu)，解鎖後再以 emitDirectorActionRecorded 發送事件
+func (ce *competitionEngine) addDirectorAuditRecord(record DirectorAuditRecord) DirectorAuditRecord {
+	record.ID = uuid.New().String()
+	record.CompetitionID = ce.competition.ID
+	record.CreatedAt = time.Now().Unix()
+	ce.competition.State.DirectorAudits = append(ce.competition.State.DirectorAudits, &record)
+	return record
+}
+
+func (ce *competitionEngine) emitDirectorActionRecorded(record DirectorAuditRecord) {
+	ce.emitTypedEvent(EventType_DirectorActionRecorded, record)
+	ce.emitCompetitionStateEvent(CompetitionStateEvent_DirectorActionRecorded)
+}
+
+/*
+DisqualifyPlayer 裁判取消玩家資格
+  - 有籌碼的玩家兩手之間離桌並移除籌碼
+  - 以當下淘汰順位排名，之後不可再補碼
+*/
+func (ce *competitionEngine) DisqualifyPlayer(directorID, playerID, reason string) error {
+	return ce.disqualifyPlayerContext(context.Background(), directorID, playerID, reason)
+}
+
+func (ce *competitionEngine) disqualifyPlayerContext(ctx context.Context, directorID, playerID, reason string) error {
+	if !ce.competition.IsModeCTorMTT() || ce.isEndStatus() {
+		return ErrCompetitionDirectorActionRejected
+	}
+
+	ce.mu.Lock()
+	cp := ce.competition.findPlayer(playerID)
+	if cp == nil {
+		ce.mu.Unlock()
+		return ErrCompetitionPlayerNotFound
+	}
+	if cp.Status == CompetitionPlayerStatus_Knockout || cp.IsDisqualified {
+		ce.mu.Unlock()
+		return ErrCompetitionDirectorActionRejected
+	}
+
+	cp.IsDisqualified = true
+	cp.IsForfeited = true
+	delete(ce.pendingReBuys, playerID)
+	delete(ce.pendingAddons, playerID)
+	delete(ce.playerMoves, playerID)
+	record := ce.addDirectorAuditRecord(DirectorAuditRecord{
+		DirectorID: directorID,
+		Action:     DirectorAction_Disqualify,
+		PlayerID:   playerID,
+		Reason:     reason,
+		Seat:       UnsetValue,
+	})
+
+	// 沒有籌碼的玩家直接淘汰 (CT 等待補碼的玩家仍在座位上，需離桌)
+	if cp.Chips <= 0 {
+		leaveTableID := ""
+		if ce.competition.Meta.Mode == CompetitionMode_CT && cp.CurrentSeat != UnsetValue {
+			leaveTableID = cp.CurrentTableID
+		}
+		rank := ce.knockoutForfeitedPlayer(cp)
+		ce.refreshPlayerStatusStatistics()
+		ce.refreshPlayerCompetitionRanks()
+		ce.mu.Unlock()
+
+		if leaveTableID != "" {
+			if err := ce.backend(ctx).PlayersLeave(leaveTableID, []string{playerID}); err != nil {
+				ce.emitErrorEvent("DisqualifyPlayer -> PlayersLeave", playerID, err)
+			}
+		}
+		ce.emitDirectorActionRecorded(record)
+		ce.emitForfeitKnockout(cp, rank)
+		ce.emitEvent(fmt.Sprintf("DisqualifyPlayer -> %s Knockout by %s", playerID, directorID), playerID)
+		ce.emitCompetitionStateEvent(CompetitionStateEvent_KnockoutPlayers)
+		return nil
+	}
+
+	ce.pendingForfeits[playerID] = true
+	ce.mu.Unlock()
+
+	ce.emitDirectorActionRecorded(record)
+	ce.emitPlayerEvent("disqualify pending", cp)
+	ce.applyPendingForfeit(ctx, playerID)
+	return nil
+}
+
+/*
+PenalizePlayer 裁判處罰玩家暫停參與
+  - 處罰期間玩家仍在座位上 (照常支付盲注與前注)，輪到玩家行動時自動棄牌
+  - orbits: 處罰圈數 (以處罰時本桌人數換算成手數), seconds: 處罰秒數，兩者皆結束才解除
+*/
+func (ce *competitionEngine) PenalizePlayer(directorID, playerID string, orbits, seconds int, reason string) error {
+	if orbits < 0 || seconds < 0 || (orbits == 0 && seconds == 0) {
+		return ErrCompetitionInvalidPenalty
+	}
+
+	ce.mu.Lock()
+	cp := ce.competition.findPlayer(playerID)
+	if cp == nil {
+		ce.mu.Unlock()
+		return ErrCompetitionPlayerNotFound
+	}
+	if cp.Chips <= 0 || cp.Status == CompetitionPlayerStatus_Knockout {
+		ce.mu.Unlock()
+		return ErrCompetitionDirectorActionRejected
+	}
+
+	handsPerOrbit := ce.competition.Meta.TableMaxSeatCount
+	tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
+		return t.ID == cp.CurrentTableID
+	})
+	if tableIdx != UnsetValue {
+		if alivePlayerCount := len(ce.competition.State.Tables[tableIdx].AlivePlayers()); alivePlayerCount > 0 {
+			handsPerOrbit = alivePlayerCount
+		}
+	}
+
+	now := time.Now().Unix()
+	penalty := &PlayerPenalty{
+		Reason:         reason,
+		RemainingHands: orbits * handsPerOrbit,
+		EndAt:          UnsetValue,
+		CreatedAt:      now,
+	}
+	if seconds > 0 {
+		penalty.EndAt = now + int64(seconds)
+	}
+	cp.Penalty = penalty
+	record := ce.addDirectorAuditRecord(DirectorAuditRecord{
+		DirectorID:     directorID,
+		Action:         DirectorAction_Penalize,
+		PlayerID:       playerID,
+		Reason:         reason,
+		Seat:           UnsetValue,
+		PenaltyOrbits:  orbits,
+		PenaltySeconds: seconds,
+	})
+	ce.mu.Unlock()
+
+	ce.emitDirectorActionRecorded(record)
+	ce.emitPlayerEvent("penalized", cp)
+	ce.emitEvent(fmt.Sprintf("PenalizePlayer -> %s by %s", playerID, directorID), playerID)
+	return nil
+}
+
+/*
+AdjustPlayerChips 裁判調整玩家籌碼
+  - chips 為調整量 (負數表示扣除)，調整後籌碼需大於 0 (移除玩家請使用 DisqualifyPlayer)
+  - 牌局進行中時等到該手結算後 (兩手之間) 才調整
+*/
+func (ce *competitionEngine) AdjustPlayerChips(directorID, playerID string, chips int64, reason string) error {
+	return ce.adjustPlayerChipsContext(context.Background(), directorID, playerID, chips, reason)
+}
+
+func (ce *competitionEngine) adjustPlayerChipsContext(ctx context.Context, directorID, playerID string, chips int64, reason string) error {
+	if chips == 0 {
+		return ErrCompetitionInvalidChipAdjustment
+	}
+
+	ce.mu.Lock()
+	cp := ce.competition.findPlayer(playerID)
+	if cp == nil {
+		ce.mu.Unlock()
+		return ErrCompetitionPlayerNotFound
+	}
+	if cp.Chips <= 0 || cp.Chips+chips <= 0 || cp.Status == CompetitionPlayerStatus_Knockout {
+		ce.mu.Unlock()
+		return ErrCompetitionInvalidChipAdjustment
+	}
+	if _, exist := ce.pendingChipAdjustments[playerID]; exist {
+		ce.mu.Unlock()
+		return ErrCompetitionDirectorActionRejected
+	}
+
+	ce.pendingChipAdjustments[playerID] = chips
+	record := ce.addDirectorAuditRecord(DirectorAuditRecord{
+		DirectorID: directorID,
+		Action:     DirectorAction_AdjustChips,
+		PlayerID:   playerID,
+		Reason:     reason,
+		Seat:       UnsetValue,
+		Chips:      chips,
+	})
+	ce.mu.Unlock()
+
+	ce.emitDirectorActionRecorded(record)
+	ce.applyPendingChipAdjustment(ctx, playerID)
+	return nil
+}
+
+/*
+MovePlayer 裁判指定玩家換桌 (MTT)
+  - seat 為 UnsetValue 時依座位限制安排
+  - 玩家於原桌次該手結算後 (兩手之間) 移動
+*/
+func (ce *competitionEngine) MovePlayer(directorID, playerID, tableID string, seat int, reason string) error {
+	if ce.competition.Meta.Mode != CompetitionMode_MTT {
+		return ErrCompetitionPlayerMoveRejected
+	}
+
+	if seat != UnsetValue && (seat < 0 || seat >= ce.competition.Meta.TableMaxSeatCount) {
+		return ErrCompetitionInvalidPinnedSeat
+	}
+
+	ce.mu.Lock()
+
+	if !ce.competition.IsTableExist(tableID) {
+		ce.mu.Unlock()
+		return ErrCompetitionTableNotFound
+	}
+
+	player := ce.competition.findPlayer(playerID)
+	if player == nil {
+		ce.mu.Unlock()
+		return ErrCompetitionPlayerNotFound
+	}
+	if player.Status != CompetitionPlayerStatus_Playing || player.CurrentTableID == "" || player.CurrentTableID == tableID {
+		ce.mu.Unlock()
+		return ErrCompetitionPlayerMoveRejected
+	}
+
+	if seat != UnsetValue {
+		seatedPlayers := ce.tableSeatedPlayers(tableID, []string{})
+		if funk.ContainsInt(funk.Values(seatedPlayers).([]int), seat) {
+			ce.mu.Unlock()
+			return ErrCompetitionPlayerMoveRejected
+		}
+	}
+
+	ce.playerMoves[playerID] = playerMove{
+		tableID: tableID,
+		seat:    seat,
+		reason:  PlayerMoveReason_Director,
+	}
+	record := ce.addDirectorAuditRecord(DirectorAuditRecord{
+		DirectorID: directorID,
+		Action:     DirectorAction_MovePlayer,
+		PlayerID:   playerID,
+		Reason:     reason,
+		TableID:    tableID,
+		Seat:       seat,
+	})
+	ce.mu.Unlock()
+
+	ce.emitDirectorActionRecorded(record)
+	ce.emitEvent(fmt.Sprintf("MovePlayer -> player (%s) will move to table (%s) seat (%d) by %s", playerID, tableID, seat, directorID), playerID)
+	return nil
+}
+
+/*
+handlePendingChipAdjustments 處理本桌等待中的籌碼調整
+  - 適用時機: 每手結算後 (兩手之間)
+*/
+func (ce *competitionEngine) handlePendingChipAdjustments(ctx context.Context, tableID string) {
+	ce.mu.RLock()
+	playerIDs := make([]string, 0)
+	for playerID := range ce.pendingChipAdjustments {
+		if cp := ce.competition.findPlayer(playerID); cp != nil && cp.CurrentTableID == tableID {
+			playerIDs = append(playerIDs, playerID)
+		}
+	}
+	ce.mu.RUnlock()
+
+	for _, playerID := range playerIDs {
+		ce.applyPendingChipAdjustment(ctx, playerID)
+	}
+}
+
+/*
+applyPendingChipAdjustment 調整玩家籌碼並更新排名
+  - 牌局進行中的桌次等到該手結算後再處理
+  - 調整後籌碼不足時取消
+  - 桌次調整籌碼失敗時還原
+*/
+func (ce *competitionEngine) applyPendingChipAdjustment(ctx context.Context, playerID string) {
+	ce.mu.Lock()
+	chips, exist := ce.pendingChipAdjustments[playerID]
+	if !exist {
+		ce.mu.Unlock()
+		return
+	}
+
+	cp := ce.competition.findPlayer(playerID)
+	if cp == nil || cp.Chips <= 0 || cp.Chips+chips <= 0 {
+		delete(ce.pendingChipAdjustments, playerID)
+		ce.mu.Unlock()
+		ce.emitErrorEvent("Apply Pending Chip Adjustment", playerID, ErrCompetitionInvalidChipAdjustment)
+		return
+	}
+
+	// 等待拆併桌的玩家入桌時帶入調整後籌碼
+	tableID := ""
+	if cp.Status == CompetitionPlayerStatus_Playing {
+		tableID = cp.CurrentTableID
+		if ce.isTableGameInProgress(tableID) {
+			ce.mu.Unlock()
+			return
+		}
+	}
+	delete(ce.pendingChipAdjustments, playerID)
+	ce.mu.Unlock()
+
+	if err := ce.redeemChips(ctx, chipRedemption{playerID: playerID, tableID: tableID, chips: chips}); err != nil {
+		ce.emitErrorEvent("Apply Pending Chip Adjustment -> PlayerRedeemChips", playerID, err)
+		return
+	}
+
+	ce.emitEvent(fmt.Sprintf("AdjustPlayerChips -> %s (%d)", playerID, chips), playerID)
+	ce.emitPlayerEvent("AdjustPlayerChips", cp)
+	ce.emitCompetitionStateEvent(CompetitionStateEvent_PlayerRankUpdated)
+}
+
+/*
+handlePenalizedPlayerTurn 處罰中或已棄賽 (blind_off、等待離桌) 的玩家輪到行動時自動棄牌
+  - 適用時機: 桌次更新 (牌局進行中)
+  - 延遲棄牌前以最新桌次資料重新確認仍輪到該玩家行動，玩家已自行行動時不棄牌
+*/
+func (ce *competitionEngine) handlePenalizedPlayerTurn(ctx context.Context, table pokertable.Table) {
+	playerID := ce.autoFoldPlayerID(table)
+	if playerID == "" {
+		return
+	}
+
+	// 同一手只自動棄牌一次
+	foldRecordID := fmt.Sprintf("%s.%d.%s", table.ID, table.State.GameCount, playerID)
+	if _, loaded := ce.penaltyFoldRecords.LoadOrStore(foldRecordID, true); loaded {
+		return
+	}
+
+	if err := timebank.NewTimeBank().NewTask(time.Millisecond*100, func(isCancelled bool) {
+		if isCancelled {
+			return
+		}
+
+		ce.mu.RLock()
+		isStillTurn := false
+		tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
+			return t.ID == table.ID
+		})
+		if tableIdx != UnsetValue {
+			latest := ce.competition.State.Tables[tableIdx]
+			isStillTurn = latest.State.GameCount == table.State.GameCount && ce.autoFoldPlayerID(*latest) == playerID
+		}
+		ce.mu.RUnlock()
+
+		// 玩家已行動: 清除紀錄，同一手再輪到玩家時重新處理
+		if !isStillTurn {
+			ce.penaltyFoldRecords.Delete(foldRecordID)
+			return
+		}
+
+		if err := ce.backend(context.Background()).PlayerFold(table.ID, playerID); err != nil {
+			ce.penaltyFoldRecords.Delete(foldRecordID)
+			ce.emitErrorEvent("Penalized Player -> PlayerFold", playerID, err)
+		}
+	}); err != nil {
+		ce.penaltyFoldRecords.Delete(foldRecordID)
+		ce.emitErrorEvent("Penalized Player -> Add Fold Timer", playerID, err)
+	}
+}
+
+// autoFoldPlayerID 桌次目前輪到行動且需自動棄牌的玩家 ID，沒有時為空字串
+func (ce *competitionEngine) autoFoldPlayerID(table pokertable.Table) string {
+	if table.State == nil || table.State.Status != pokertable.TableStateStatus_TableGamePlaying || table.State.GameState == nil {
+		return ""
+	}
+
+	gamePlayerIdx := table.State.GameState.Status.CurrentPlayer
+	if gamePlayerIdx < 0 || gamePlayerIdx >= len(table.State.GamePlayerIndexes) || gamePlayerIdx >= len(table.State.GameState.Players) {
+		return ""
+	}
+	playerIdx := table.FindPlayerIndexFromGamePlayerIndex(gamePlayerIdx)
+	if playerIdx == UnsetValue {
+		return ""
+	}
+	playerID := table.State.PlayerStates[playerIdx].PlayerID
+
+	cp := ce.competition.findPlayer(playerID)
+	if cp == nil || !cp.shouldAutoFold(time.Now().Unix()) {
+		return ""
+	}
+	if !funk.ContainsString(table.State.GameState.Players[gamePlayerIdx].AllowedActions, pokertable.WagerAction_Fold) {
+		return ""
+	}
+	return playerID
+}
+
+// shouldAutoFold 玩家輪到行動時是否自動棄牌: 處罰中或已棄賽 (棄賽玩家不再參與牌局，只支付盲注直到籌碼耗盡)
+func (cp *CompetitionPlayer) shouldAutoFold(now int64) bool {
+	return cp.Penalty.IsActive(now) || (cp.IsForfeited && cp.Status != CompetitionPlayerStatus_Knockout)
+}
+
+/*
+updatePlayerPenalties 更新本桌處罰中玩家的剩餘手數，處罰結束時解除
+  - 適用時機: 每手結算後
+*/
+func (ce *competitionEngine) updatePlayerPenalties(table pokertable.Table) {
+	now := time.Now().Unix()
+	for _, ps := range table.State.PlayerStates {
+		cp := ce.competition.findPlayer(ps.PlayerID)
+		if cp == nil || cp.Penalty == nil {
+			continue
+		}
+
+		if ps.IsParticipated && cp.Penalty.RemainingHands > 0 {
+			cp.Penalty.RemainingHands--
+		}
+
+		if !cp.Penalty.IsActive(now) {
+			cp.Penalty = nil
+			ce.emitPlayerEvent("penalty ended", cp)
+		}
+	}
+}
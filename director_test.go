@@ -0,0 +1,290 @@
+package pokercompetition
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokertable"
+)
+
+// directorTestBackend 記錄離桌、棄牌與兌換籌碼的呼叫，其他操作不應被呼叫
+type directorTestBackend struct {
+	TableManagerBackend
+	mu      sync.Mutex
+	leaves  []string
+	folds   []string
+	redeems map[string]int64
+}
+
+func (b *directorTestBackend) PlayersLeave(tableID string, playerIDs []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.leaves = append(b.leaves, playerIDs...)
+	return nil
+}
+
+func (b *directorTestBackend) PlayerFold(tableID, playerID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.folds = append(b.folds, playerID)
+	return nil
+}
+
+func (b *directorTestBackend) PlayerRedeemChips(tableID string, joinPlayer pokertable.JoinPlayer) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.redeems[joinPlayer.PlayerID] += joinPlayer.RedeemChips
+	return nil
+}
+
+func (b *directorTestBackend) foldedPlayers() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string{}, b.folds...)
+}
+
+/*
+newDirectorTestEngine 建立買入期間的 MTT 賽事
+  - 桌次 t1 兩手之間: p1、p2 有籌碼，p3 沒有籌碼等待補碼中
+  - 桌次 t2 沒有玩家
+*/
+func newDirectorTestEngine() (*competitionEngine, *directorTestBackend) {
+	p1 := newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_Playing)
+	p2 := newTestCompetitionPlayer("p2", 2000, CompetitionPlayerStatus_Playing)
+	p3 := newTestCompetitionPlayer("p3", 0, CompetitionPlayerStatus_ReBuyWaiting)
+	p3.IsReBuying = true
+
+	ce := newTestCompetitionEngine(p1, p2, p3)
+	ce.competition.State.Status = CompetitionStateStatus_DelayedBuyIn
+	ce.competition.State.BlindState = &BlindState{CurrentLevelIndex: 0}
+	addTestTable(ce, "t1", pokertable.TableStateStatus_TableGameStandby, p1, p2, p3)
+	addTestTable(ce, "t2", pokertable.TableStateStatus_TableGameStandby)
+
+	backend := &directorTestBackend{redeems: make(map[string]int64)}
+	ce.tableManagerBackend = backend
+	return ce, backend
+}
+
+// recordDirectorEvents 記錄裁判操作事件
+func recordDirectorEvents(ce *competitionEngine) *[]DirectorAuditRecord {
+	records := make([]DirectorAuditRecord, 0)
+	ce.OnEvent(func(event Event) {
+		if event.Type == EventType_DirectorActionRecorded {
+			records = append(records, event.Payload.(DirectorAuditRecord))
+		}
+	})
+	return &records
+}
+
+func Test_Director_DisqualifyPlayer(t *testing.T) {
+	ce, backend := newDirectorTestEngine()
+	records := recordDirectorEvents(ce)
+	ranks := make(map[string]int)
+	ce.OnCompetitionFinalPlayerRankUpdated(func(competitionID, playerID string, rank int) {
+		ranks[playerID] = rank
+	})
+
+	// 沒有籌碼的玩家直接淘汰
+	assert.NoError(t, ce.DisqualifyPlayer("d1", "p3", "collusion"))
+	p3 := ce.competition.findPlayer("p3")
+	assert.True(t, p3.IsDisqualified)
+	assert.Equal(t, CompetitionPlayerStatus_Knockout, p3.Status)
+	assert.False(t, p3.IsReBuying)
+	assert.Equal(t, 3, ranks["p3"])
+	assert.Empty(t, backend.leaves, "MTT player waiting for re-buy has no seat to leave")
+
+	// 有籌碼的玩家兩手之間離桌並移除籌碼
+	assert.NoError(t, ce.DisqualifyPlayer("d1", "p1", "abuse"))
+	p1 := ce.competition.findPlayer("p1")
+	assert.Equal(t, CompetitionPlayerStatus_Knockout, p1.Status)
+	assert.Equal(t, int64(0), p1.Chips)
+	assert.Equal(t, []string{"p1"}, backend.leaves)
+	assert.Equal(t, 2, ranks["p1"])
+
+	if assert.Len(t, *records, 2) {
+		record := (*records)[1]
+		assert.NotEmpty(t, record.ID)
+		assert.Equal(t, "c1", record.CompetitionID)
+		assert.Equal(t, "d1", record.DirectorID)
+		assert.Equal(t, DirectorAction_Disqualify, record.Action)
+		assert.Equal(t, "p1", record.PlayerID)
+		assert.Equal(t, "abuse", record.Reason)
+	}
+	assert.Len(t, ce.competition.State.DirectorAudits, 2)
+
+	assert.ErrorIs(t, ce.DisqualifyPlayer("d1", "p1", ""), ErrCompetitionDirectorActionRejected)
+	assert.ErrorIs(t, ce.DisqualifyPlayer("d1", "p9", ""), ErrCompetitionPlayerNotFound)
+}
+
+func Test_Director_PenalizePlayer(t *testing.T) {
+	ce, _ := newDirectorTestEngine()
+	records := recordDirectorEvents(ce)
+
+	assert.ErrorIs(t, ce.PenalizePlayer("d1", "p1", 0, 0, ""), ErrCompetitionInvalidPenalty)
+	assert.ErrorIs(t, ce.PenalizePlayer("d1", "p1", -1, 60, ""), ErrCompetitionInvalidPenalty)
+	assert.ErrorIs(t, ce.PenalizePlayer("d1", "p3", 1, 0, ""), ErrCompetitionDirectorActionRejected, "player without chips cannot be penalized")
+	assert.ErrorIs(t, ce.PenalizePlayer("d1", "p9", 1, 0, ""), ErrCompetitionPlayerNotFound)
+
+	// 一圈換算成本桌有籌碼的玩家人數
+	assert.NoError(t, ce.PenalizePlayer("d1", "p1", 1, 0, "slow play"))
+	cp := ce.competition.findPlayer("p1")
+	if assert.NotNil(t, cp.Penalty) {
+		assert.Equal(t, 2, cp.Penalty.RemainingHands)
+		assert.Equal(t, UnsetValue, int(cp.Penalty.EndAt))
+		assert.Equal(t, "slow play", cp.Penalty.Reason)
+	}
+	if assert.Len(t, *records, 1) {
+		assert.Equal(t, DirectorAction_Penalize, (*records)[0].Action)
+		assert.Equal(t, 1, (*records)[0].PenaltyOrbits)
+	}
+
+	// 只有參與牌局的手數才扣除，手數結束後解除
+	table := *ce.competition.State.Tables[0]
+	for _, ps := range table.State.PlayerStates {
+		ps.IsParticipated = ps.PlayerID == "p1"
+	}
+	ce.updatePlayerPenalties(table)
+	assert.Equal(t, 1, cp.Penalty.RemainingHands)
+	ce.updatePlayerPenalties(table)
+	assert.Nil(t, cp.Penalty)
+
+	// 依時間處罰: 手數結束後仍需等到時間結束
+	assert.NoError(t, ce.PenalizePlayer("d1", "p2", 0, 60, ""))
+	cp = ce.competition.findPlayer("p2")
+	ce.updatePlayerPenalties(table)
+	if assert.NotNil(t, cp.Penalty) {
+		assert.True(t, cp.Penalty.EndAt > time.Now().Unix())
+	}
+	cp.Penalty.EndAt = time.Now().Unix()
+	ce.updatePlayerPenalties(table)
+	assert.Nil(t, cp.Penalty)
+}
+
+func Test_Director_AdjustPlayerChips(t *testing.T) {
+	ce, backend := newDirectorTestEngine()
+	records := recordDirectorEvents(ce)
+
+	assert.ErrorIs(t, ce.AdjustPlayerChips("d1", "p1", 0, ""), ErrCompetitionInvalidChipAdjustment)
+	assert.ErrorIs(t, ce.AdjustPlayerChips("d1", "p1", -1000, ""), ErrCompetitionInvalidChipAdjustment, "use DisqualifyPlayer to remove all chips")
+	assert.ErrorIs(t, ce.AdjustPlayerChips("d1", "p9", 100, ""), ErrCompetitionPlayerNotFound)
+
+	// 兩手之間直接調整
+	assert.NoError(t, ce.AdjustPlayerChips("d1", "p1", -400, "miscount"))
+	assert.Equal(t, int64(600), ce.competition.findPlayer("p1").Chips)
+	assert.Equal(t, int64(-400), backend.redeems["p1"])
+
+	// 牌局進行中: 等到該手結算後再調整，同一玩家只能有一筆等待中的調整
+	ce.competition.State.Tables[0].State.Status = pokertable.TableStateStatus_TableGamePlaying
+	assert.NoError(t, ce.AdjustPlayerChips("d1", "p2", 500, ""))
+	assert.ErrorIs(t, ce.AdjustPlayerChips("d1", "p2", 100, ""), ErrCompetitionDirectorActionRejected)
+	assert.Equal(t, int64(2000), ce.competition.findPlayer("p2").Chips)
+	assert.NotContains(t, backend.redeems, "p2")
+
+	ce.competition.State.Tables[0].State.Status = pokertable.TableStateStatus_TableGameStandby
+	ce.handlePendingChipAdjustments(context.Background(), "t1")
+	assert.Equal(t, int64(2500), ce.competition.findPlayer("p2").Chips)
+	assert.Equal(t, int64(500), backend.redeems["p2"])
+	assert.Empty(t, ce.pendingChipAdjustments)
+
+	if assert.Len(t, *records, 2) {
+		assert.Equal(t, DirectorAction_AdjustChips, (*records)[0].Action)
+		assert.Equal(t, int64(-400), (*records)[0].Chips)
+	}
+}
+
+func Test_Director_MovePlayer(t *testing.T) {
+	ce, _ := newDirectorTestEngine()
+	records := recordDirectorEvents(ce)
+
+	assert.ErrorIs(t, ce.MovePlayer("d1", "p1", "t2", 9, ""), ErrCompetitionInvalidPinnedSeat)
+	assert.ErrorIs(t, ce.MovePlayer("d1", "p1", "t9", UnsetValue, ""), ErrCompetitionTableNotFound)
+	assert.ErrorIs(t, ce.MovePlayer("d1", "p9", "t2", UnsetValue, ""), ErrCompetitionPlayerNotFound)
+	assert.ErrorIs(t, ce.MovePlayer("d1", "p1", "t1", UnsetValue, ""), ErrCompetitionPlayerMoveRejected, "player is already at the table")
+	assert.ErrorIs(t, ce.MovePlayer("d1", "p3", "t2", UnsetValue, ""), ErrCompetitionPlayerMoveRejected, "player waiting for re-buy cannot move")
+
+	// 玩家於原桌次兩手之間移動
+	assert.NoError(t, ce.MovePlayer("d1", "p1", "t2", 4, "balance"))
+	assert.Equal(t, playerMove{tableID: "t2", seat: 4, reason: PlayerMoveReason_Director}, ce.playerMoves["p1"])
+	assert.Equal(t, "t1", ce.competition.findPlayer("p1").CurrentTableID)
+	if assert.Len(t, *records, 1) {
+		record := (*records)[0]
+		assert.Equal(t, DirectorAction_MovePlayer, record.Action)
+		assert.Equal(t, "t2", record.TableID)
+		assert.Equal(t, 4, record.Seat)
+	}
+
+	// 指定座位已有玩家
+	assert.ErrorIs(t, ce.MovePlayer("d1", "p2", "t1", 0, ""), ErrCompetitionPlayerMoveRejected)
+
+	ce.competition.Meta.Mode = CompetitionMode_CT
+	assert.ErrorIs(t, ce.MovePlayer("d1", "p2", "t2", UnsetValue, ""), ErrCompetitionPlayerMoveRejected)
+}
+
+func Test_Director_EmitsAfterUnlock(t *testing.T) {
+	actions := map[string]func(ce *competitionEngine) error{
+		"disqualify": func(ce *competitionEngine) error { return ce.DisqualifyPlayer("d1", "p3", "") },
+		"penalize":   func(ce *competitionEngine) error { return ce.PenalizePlayer("d1", "p1", 1, 0, "") },
+		"adjust":     func(ce *competitionEngine) error { return ce.AdjustPlayerChips("d1", "p1", 100, "") },
+		"move":       func(ce *competitionEngine) error { return ce.MovePlayer("d1", "p1", "t2", UnsetValue, "") },
+	}
+
+	for name, action := range actions {
+		t.Run(name, func(t *testing.T) {
+			ce, _ := newDirectorTestEngine()
+			ce.OnEvent(func(event Event) {
+				// 監聽者呼叫需要鎖的方法不會死結
+				ce.mu.Lock()
+				ce.mu.Unlock()
+			})
+
+			done := make(chan error, 1)
+			go func() {
+				done <- action(ce)
+			}()
+
+			select {
+			case err := <-done:
+				assert.NoError(t, err)
+			case <-time.After(time.Second):
+				t.Fatal("director event was emitted while holding the engine lock")
+			}
+		})
+	}
+}
+
+func Test_Director_PenaltyAutoFold(t *testing.T) {
+	ce, backend := newDirectorTestEngine()
+	assert.NoError(t, ce.PenalizePlayer("d1", "p1", 1, 0, ""))
+
+	ce.handlePenalizedPlayerTurn(context.Background(), newTestTableTurn(ce, "p1"))
+	assert.Eventually(t, func() bool {
+		return len(backend.foldedPlayers()) > 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"p1"}, backend.foldedPlayers())
+
+	// 同一手只自動棄牌一次
+	ce.handlePenalizedPlayerTurn(context.Background(), newTestTableTurn(ce, "p1"))
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, []string{"p1"}, backend.foldedPlayers())
+}
+
+func Test_Director_PenaltyAutoFoldAfterPlayerActed(t *testing.T) {
+	ce, backend := newDirectorTestEngine()
+	assert.NoError(t, ce.PenalizePlayer("d1", "p1", 1, 0, ""))
+
+	// 延遲棄牌前玩家已自行行動: 不棄牌
+	ce.handlePenalizedPlayerTurn(context.Background(), newTestTableTurn(ce, "p1"))
+	newTestTableTurn(ce, "p2")
+	time.Sleep(200 * time.Millisecond)
+	assert.Empty(t, backend.foldedPlayers())
+
+	// 同一手再輪到玩家時重新處理
+	ce.handlePenalizedPlayerTurn(context.Background(), newTestTableTurn(ce, "p1"))
+	assert.Eventually(t, func() bool {
+		return len(backend.foldedPlayers()) > 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"p1"}, backend.foldedPlayers())
+}
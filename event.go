@@ -15,6 +15,15 @@ const (
 	CompetitionStateEvent_CashOutPlayers              = "CashOutPlayers"
 	CompetitionStateEvent_PlayerRankUpdated           = "PlayerRankUpdated"
 	CompetitionStateEvent_CompetitionStatisticUpdated = "CompetitionStatisticUpdated"
+	CompetitionStateEvent_ChipsColoredUp              = "ChipsColoredUp"
+	CompetitionStateEvent_SeatingViolated             = "SeatingViolated"
+	CompetitionStateEvent_AlternatesUpdated           = "AlternatesUpdated"
+	CompetitionStateEvent_AlternateSeated             = "AlternateSeated"
+	CompetitionStateEvent_AlternatesExpired           = "AlternatesExpired"
+	CompetitionStateEvent_HandForHandStarted          = "HandForHandStarted"
+	CompetitionStateEvent_AdvancePlayersSettled       = "AdvancePlayersSettled"
+	CompetitionStateEvent_AddonOffered                = "AddonOffered"
+	CompetitionStateEvent_DirectorActionRecorded      = "DirectorActionRecorded"
 	CompetitionStateEvent_Settled                     = "Settled"
 )
 
@@ -26,6 +35,7 @@ func (ce *competitionEngine) emitEvent(eventName string, playerID string) {
 	// emit event
 	fmt.Printf("->[Competition][#%d][%s] emit Event: %s\n", ce.competition.UpdateSerial, playerID, eventName)
 	ce.onCompetitionUpdated(ce.competition)
+	ce.emitCompetitionDelta()
 }
 
 func (ce *competitionEngine) emitErrorEvent(eventName string, playerID string, err error) {
@@ -40,8 +50,46 @@ func (ce *competitionEngine) emitPlayerEvent(eventName string, player *Competiti
 }
 
 func (ce *competitionEngine) emitCompetitionStateFinalPlayerRankEvent(playerID string, rank int) {
-	// emit event
-	ce.onCompetitionFinalPlayerRankUpdated(ce.competition.ID, playerID, rank)
+	cp := ce.competition.findPlayer(playerID)
+	if cp == nil {
+		ce.emitTypedEvent(EventType_PlayerFinalRanked, PlayerFinalRankedPayload{
+			PlayerID: playerID,
+			Rank:     rank,
+		})
+		return
+	}
+	if cp.Status == CompetitionPlayerStatus_Knockout {
+		ce.emitTypedEvent(EventType_PlayerKnockedOut, PlayerKnockedOutPayload{
+			PlayerID:       playerID,
+			Rank:           rank,
+			IsForfeited:    cp.IsForfeited,
+			IsDisqualified: cp.IsDisqualified,
+			KnockoutAt:     cp.KnockoutAt,
+		})
+	} else {
+		ce.emitTypedEvent(EventType_PlayerFinalRanked, PlayerFinalRankedPayload{
+			PlayerID: playerID,
+			Rank:     rank,
+			Chips:    cp.Chips,
+		})
+	}
+}
+
+func (ce *competitionEngine) emitBlindLevelChangedEvent() {
+	levelIndex := ce.competition.State.BlindState.CurrentLevelIndex
+	if levelIndex < 0 || levelIndex >= len(ce.competition.Meta.Blind.Levels) {
+		return
+	}
+
+	endAt := int64(UnsetValue)
+	if levelIndex < len(ce.competition.State.BlindState.EndAts) && ce.competition.State.BlindState.EndAts[levelIndex] > 0 {
+		endAt = ce.competition.State.BlindState.EndAts[levelIndex]
+	}
+	ce.emitTypedEvent(EventType_BlindLevelChanged, BlindLevelChangedPayload{
+		LevelIndex: levelIndex,
+		Level:      ce.competition.Meta.Blind.Levels[levelIndex],
+		EndAt:      endAt,
+	})
 }
 
 func (ce *competitionEngine) emitCompetitionStateEvent(eventName string) {
@@ -0,0 +1,223 @@
+package pokercompetition
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	ErrEventBusSubscriberOverflow = errors.New("event bus: subscriber queue overflow")
+	ErrEventBusClosed             = errors.New("event bus: closed")
+)
+
+type OverflowPolicy string
+
+const (
+	OverflowPolicy_Drop       OverflowPolicy = "drop"       // 佇列已滿時丟棄新事件 (預設)
+	OverflowPolicy_Block      OverflowPolicy = "block"      // 佇列已滿時等待訂閱者消化 (會延遲賽事邏輯)
+	OverflowPolicy_Disconnect OverflowPolicy = "disconnect" // 佇列已滿時取消訂閱
+)
+
+const defaultSubscriptionQueueSize = 256
+
+type SubscribeOptions struct {
+	Filter         func(event Event) bool // 事件過濾 (nil 表示接收所有事件)
+	QueueSize      int                    // 佇列大小 (0 表示使用預設值)
+	OverflowPolicy OverflowPolicy         // 佇列已滿時的處理方式
+}
+
+// EventTypeFilter 只接收指定類型事件的過濾器
+func EventTypeFilter(eventTypes ...EventType) func(event Event) bool {
+	types := make(map[EventType]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		types[eventType] = true
+	}
+	return func(event Event) bool {
+		return types[event.Type]
+	}
+}
+
+/*
+Subscription 事件訂閱
+  - 每個訂閱有自己的佇列與派送 goroutine，事件依序派送
+  - Unsubscribe 後佇列內尚未派送的事件不再派送
+*/
+type Subscription struct {
+	id       int64
+	bus      *EventBus
+	handler  func(event Event)
+	filter   func(event Event) bool
+	policy   OverflowPolicy
+	queue    chan Event
+	done     chan struct{}
+	stopOnce sync.Once
+	dropped  int64
+	err      atomic.Value // 取消訂閱原因 (error)
+}
+
+// Unsubscribe 取消訂閱
+func (s *Subscription) Unsubscribe() {
+	s.stop(nil)
+}
+
+// Done 訂閱結束 (取消訂閱、溢位斷線或事件匯流排關閉) 時關閉
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err 訂閱結束原因 (主動取消訂閱時為 nil)
+func (s *Subscription) Err() error {
+	if err, ok := s.err.Load().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// DroppedCount 因佇列已滿而丟棄的事件數
+func (s *Subscription) DroppedCount() int64 {
+	return atomic.LoadInt64(&s.dropped)
+}
+
+func (s *Subscription) stop(err error) {
+	s.stopOnce.Do(func() {
+		if err != nil {
+			s.err.Store(err)
+		}
+		s.bus.remove(s.id)
+		close(s.done)
+	})
+}
+
+func (s *Subscription) dispatch() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case event := <-s.queue:
+			// 取消訂閱後不再派送
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+			s.handler(event)
+		}
+	}
+}
+
+func (s *Subscription) publish(event Event) {
+	if s.filter != nil && !s.filter(event) {
+		return
+	}
+
+	select {
+	case <-s.done:
+		return
+	case s.queue <- event:
+		return
+	default:
+	}
+
+	// 佇列已滿
+	switch s.policy {
+	case OverflowPolicy_Block:
+		select {
+		case <-s.done:
+		case s.queue <- event:
+		}
+	case OverflowPolicy_Disconnect:
+		s.stop(ErrEventBusSubscriberOverflow)
+	default:
+		atomic.AddInt64(&s.dropped, 1)
+	}
+}
+
+/*
+EventBus 多訂閱者事件匯流排
+  - 發布事件不會等待訂閱者處理 (OverflowPolicy_Block 且佇列已滿時除外)
+*/
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[int64]*Subscription
+	nextID      int64
+	isClosed    bool
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[int64]*Subscription),
+	}
+}
+
+// Subscribe 訂閱事件，回傳的 Subscription 用於取消訂閱
+func (b *EventBus) Subscribe(handler func(event Event), options SubscribeOptions) *Subscription {
+	queueSize := options.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultSubscriptionQueueSize
+	}
+
+	policy := options.OverflowPolicy
+	if policy == "" {
+		policy = OverflowPolicy_Drop
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	s := &Subscription{
+		id:      b.nextID,
+		bus:     b,
+		handler: handler,
+		filter:  options.Filter,
+		policy:  policy,
+		queue:   make(chan Event, queueSize),
+		done:    make(chan struct{}),
+	}
+
+	if b.isClosed {
+		s.err.Store(ErrEventBusClosed)
+		s.stopOnce.Do(func() { close(s.done) })
+		return s
+	}
+
+	b.subscribers[s.id] = s
+	go s.dispatch()
+	return s
+}
+
+// Publish 發布事件給所有訂閱者
+func (b *EventBus) Publish(event Event) {
+	b.mu.RLock()
+	subscribers := make([]*Subscription, 0, len(b.subscribers))
+	for _, s := range b.subscribers {
+		subscribers = append(subscribers, s)
+	}
+	b.mu.RUnlock()
+
+	for _, s := range subscribers {
+		s.publish(event)
+	}
+}
+
+// Close 關閉事件匯流排並結束所有訂閱
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	b.isClosed = true
+	subscribers := make([]*Subscription, 0, len(b.subscribers))
+	for _, s := range b.subscribers {
+		subscribers = append(subscribers, s)
+	}
+	b.mu.Unlock()
+
+	for _, s := range subscribers {
+		s.stop(ErrEventBusClosed)
+	}
+}
+
+func (b *EventBus) remove(id int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subscribers, id)
+}
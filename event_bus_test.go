@@ -0,0 +1,266 @@
+package pokercompetition
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+newBlockedTestSubscription 建立處理第一個事件後暫停的訂閱
+  - 回傳的 started 在第一個事件開始處理時關閉，關閉 release 後繼續處理
+*/
+func newBlockedTestSubscription(bus *EventBus, policy OverflowPolicy) (s *Subscription, received func() []int64, started chan struct{}, release chan struct{}) {
+	var mu sync.Mutex
+	seqs := make([]int64, 0)
+	started = make(chan struct{})
+	release = make(chan struct{})
+	var startOnce sync.Once
+
+	s = bus.Subscribe(func(event Event) {
+		startOnce.Do(func() { close(started) })
+		<-release
+		mu.Lock()
+		seqs = append(seqs, event.Seq)
+		mu.Unlock()
+	}, SubscribeOptions{QueueSize: 1, OverflowPolicy: policy})
+
+	received = func() []int64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int64{}, seqs...)
+	}
+	return s, received, started, release
+}
+
+func waitEventBusTest(t *testing.T, ch <-chan struct{}, msg string) bool {
+	select {
+	case <-ch:
+		return true
+	case <-time.After(time.Second):
+		t.Error(msg)
+		return false
+	}
+}
+
+func Test_EventBus_OverflowDrop(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+	s, received, started, release := newBlockedTestSubscription(bus, "")
+
+	bus.Publish(Event{Seq: 1})
+	if !waitEventBusTest(t, started, "first event should be dispatched") {
+		return
+	}
+	bus.Publish(Event{Seq: 2}) // 佇列
+	bus.Publish(Event{Seq: 3}) // 丟棄
+	bus.Publish(Event{Seq: 4}) // 丟棄
+	assert.Equal(t, int64(2), s.DroppedCount())
+
+	close(release)
+	assert.Eventually(t, func() bool { return len(received()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []int64{1, 2}, received())
+	assert.Nil(t, s.Err())
+}
+
+func Test_EventBus_OverflowBlock(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+	s, received, started, release := newBlockedTestSubscription(bus, OverflowPolicy_Block)
+
+	bus.Publish(Event{Seq: 1})
+	if !waitEventBusTest(t, started, "first event should be dispatched") {
+		return
+	}
+	bus.Publish(Event{Seq: 2})
+
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(Event{Seq: 3})
+		close(published)
+	}()
+
+	select {
+	case <-published:
+		t.Error("publish should block while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	waitEventBusTest(t, published, "publish should continue once the queue drains")
+	assert.Eventually(t, func() bool { return len(received()) == 3 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []int64{1, 2, 3}, received())
+	assert.Zero(t, s.DroppedCount())
+}
+
+func Test_EventBus_OverflowBlockReleasedByUnsubscribe(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+	s, _, started, release := newBlockedTestSubscription(bus, OverflowPolicy_Block)
+	defer close(release)
+
+	bus.Publish(Event{Seq: 1})
+	if !waitEventBusTest(t, started, "first event should be dispatched") {
+		return
+	}
+	bus.Publish(Event{Seq: 2})
+
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(Event{Seq: 3})
+		close(published)
+	}()
+
+	s.Unsubscribe()
+	waitEventBusTest(t, published, "unsubscribe should release a blocked publish")
+}
+
+func Test_EventBus_OverflowDisconnect(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+	s, received, started, release := newBlockedTestSubscription(bus, OverflowPolicy_Disconnect)
+
+	bus.Publish(Event{Seq: 1})
+	if !waitEventBusTest(t, started, "first event should be dispatched") {
+		return
+	}
+	bus.Publish(Event{Seq: 2})
+	bus.Publish(Event{Seq: 3})
+
+	waitEventBusTest(t, s.Done(), "subscription should be disconnected")
+	assert.Equal(t, ErrEventBusSubscriberOverflow, s.Err())
+	assert.Len(t, bus.subscribers, 0)
+
+	// 斷線後佇列內的事件不再派送
+	close(release)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, []int64{1}, received())
+}
+
+func Test_EventBus_Filter(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+
+	events := make(chan Event, 3)
+	bus.Subscribe(func(event Event) { events <- event }, SubscribeOptions{
+		Filter: EventTypeFilter(EventType_TableCreated),
+	})
+
+	bus.Publish(Event{Seq: 1, Type: EventType_CompetitionCreated})
+	bus.Publish(Event{Seq: 2, Type: EventType_TableCreated})
+
+	select {
+	case event := <-events:
+		assert.Equal(t, int64(2), event.Seq)
+	case <-time.After(time.Second):
+		t.Error("filtered event should be dispatched")
+	}
+	assert.Len(t, events, 0)
+}
+
+func Test_EventBus_UnsubscribeAndClose(t *testing.T) {
+	bus := NewEventBus()
+
+	unsubscribed := bus.Subscribe(func(event Event) {}, SubscribeOptions{})
+	unsubscribed.Unsubscribe()
+	unsubscribed.Unsubscribe()
+	waitEventBusTest(t, unsubscribed.Done(), "unsubscribe should end the subscription")
+	assert.Nil(t, unsubscribed.Err())
+
+	s := bus.Subscribe(func(event Event) {}, SubscribeOptions{})
+	bus.Close()
+	waitEventBusTest(t, s.Done(), "close should end all subscriptions")
+	assert.Equal(t, ErrEventBusClosed, s.Err())
+	assert.Len(t, bus.subscribers, 0)
+
+	// 關閉後訂閱立即結束
+	closed := bus.Subscribe(func(event Event) {}, SubscribeOptions{})
+	waitEventBusTest(t, closed.Done(), "subscribe after close should end immediately")
+	assert.Equal(t, ErrEventBusClosed, closed.Err())
+	bus.Publish(Event{Seq: 1})
+}
+
+func Test_EventBus_ConcurrentUnsubscribeAndClose(t *testing.T) {
+	// 同時發布、訂閱、取消訂閱與關閉 (搭配 -race 檢查)
+	bus := NewEventBus()
+	policies := []OverflowPolicy{OverflowPolicy_Drop, OverflowPolicy_Block, OverflowPolicy_Disconnect}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for seq := int64(1); seq <= 100; seq++ {
+				bus.Publish(Event{Seq: seq})
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			s := bus.Subscribe(func(event Event) {}, SubscribeOptions{QueueSize: 1, OverflowPolicy: policies[i%len(policies)]})
+			if i%2 == 0 {
+				s.Unsubscribe()
+			}
+		}(i)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		bus.Close()
+		close(closed)
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	waitEventBusTest(t, done, "publish should not deadlock with unsubscribe or close")
+	waitEventBusTest(t, closed, "close should not deadlock with publish")
+	assert.Len(t, bus.subscribers, 0)
+}
+
+func Test_EventBus_EngineSeqOrder(t *testing.T) {
+	ce := newTestCompetitionEngine()
+
+	var mu sync.Mutex
+	onEventSeqs := make([]int64, 0)
+	ce.OnEvent(func(event Event) {
+		mu.Lock()
+		onEventSeqs = append(onEventSeqs, event.Seq)
+		mu.Unlock()
+	})
+
+	subscribedSeqs := make(chan int64, 200)
+	ce.eventBus.Subscribe(func(event Event) { subscribedSeqs <- event.Seq }, SubscribeOptions{QueueSize: 200})
+
+	// 同時發送事件，OnEvent 與訂閱者收到的序號應依序遞增
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				ce.emitTypedEvent(EventType_BlindLevelChanged, nil)
+			}
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	assert.Len(t, onEventSeqs, 200)
+	for idx, seq := range onEventSeqs {
+		assert.Equal(t, int64(idx+1), seq)
+	}
+	mu.Unlock()
+
+	for expected := int64(1); expected <= 200; expected++ {
+		select {
+		case seq := <-subscribedSeqs:
+			assert.Equal(t, expected, seq)
+		case <-time.After(time.Second):
+			t.Fatalf("missing event seq %d", expected)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package pokercompetition
+
+import (
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokertable"
+)
+
+/*
+newTestCompetitionEngine 建立測試用的 MTT 賽事 (報名中)
+  - 賽事資料只有測試需要的欄位，各測試檔再依需求調整 Meta、State
+*/
+func newTestCompetitionEngine(players ...*CompetitionPlayer) *competitionEngine {
+	ce := NewCompetitionEngine().(*competitionEngine)
+	ce.competition = &Competition{
+		ID: "c1",
+		Meta: CompetitionMeta{
+			Mode:              CompetitionMode_MTT,
+			MinPlayerCount:    2,
+			TableMaxSeatCount: 9,
+		},
+		State: &CompetitionState{
+			Status:     CompetitionStateStatus_Registering,
+			Players:    players,
+			Rankings:   make([]*CompetitionRank, 0),
+			BlindState: &BlindState{CurrentLevelIndex: UnsetValue},
+			Statistic:  &Statistic{},
+		},
+	}
+	return ce
+}
+
+func newTestCompetitionPlayer(playerID string, chips int64, status CompetitionPlayerStatus) *CompetitionPlayer {
+	return &CompetitionPlayer{
+		PlayerID:       playerID,
+		Chips:          chips,
+		Status:         status,
+		CurrentSeat:    UnsetValue,
+		ReBuyEndAt:     UnsetValue,
+		ReBuyWaitingAt: UnsetValue,
+	}
+}
+
+/*
+addTestTable 在賽事資料中加入桌次 (桌次引擎不存在)
+  - players 依序入座，桌次籌碼與賽事籌碼相同
+*/
+func addTestTable(ce *competitionEngine, tableID string, status pokertable.TableStateStatus, players ...*CompetitionPlayer) *pokertable.Table {
+	playerStates := make([]*pokertable.TablePlayerState, 0, len(players))
+	for seat, cp := range players {
+		cp.CurrentTableID = tableID
+		cp.CurrentSeat = seat
+		playerStates = append(playerStates, &pokertable.TablePlayerState{PlayerID: cp.PlayerID, Seat: seat, Bankroll: cp.Chips})
+	}
+
+	table := &pokertable.Table{
+		ID: tableID,
+		State: &pokertable.TableState{
+			Status:       status,
+			PlayerStates: playerStates,
+		},
+	}
+	ce.competition.State.Tables = append(ce.competition.State.Tables, table)
+	ce.competition.State.TableInfos = append(ce.competition.State.TableInfos, &TableInfo{TableID: tableID, TableNumber: len(ce.competition.State.Tables)})
+	return table
+}
+
+/*
+newTestTableTurn 第一個桌次牌局進行中、輪到 playerID 行動 (同 UpdateTable 寫回賽事資料)
+  - 前兩位入座的玩家參與牌局，皆可棄牌或跟注
+*/
+func newTestTableTurn(ce *competitionEngine, playerID string) pokertable.Table {
+	ce.mu.RLock()
+	table := *ce.competition.State.Tables[0]
+	ce.mu.RUnlock()
+	state := *table.State
+	state.Status = pokertable.TableStateStatus_TableGamePlaying
+	state.GameCount = 1
+	state.GamePlayerIndexes = []int{0, 1}
+	state.GameState = &pokerface.GameState{
+		Players: []*pokerface.PlayerState{
+			{Idx: 0, AllowedActions: []string{pokertable.WagerAction_Fold, pokertable.WagerAction_Call}},
+			{Idx: 1, AllowedActions: []string{pokertable.WagerAction_Fold, pokertable.WagerAction_Call}},
+		},
+	}
+	for gamePlayerIdx, ps := range state.PlayerStates[:len(state.GamePlayerIndexes)] {
+		if ps.PlayerID == playerID {
+			state.GameState.Status.CurrentPlayer = gamePlayerIdx
+		}
+	}
+	table.State = &state
+	ce.mu.Lock()
+	ce.competition.State.Tables[0] = &table
+	ce.mu.Unlock()
+	return table
+}
@@ -0,0 +1,189 @@
+package pokercompetition
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/pokertable"
+	"github.com/weedbox/timebank"
+)
+
+type ForfeitPolicy string
+
+const (
+	ForfeitPolicy_RemoveChips ForfeitPolicy = "remove_chips" // 兩手之間移除籌碼並立即淘汰 (預設)
+	ForfeitPolicy_BlindOff    ForfeitPolicy = "blind_off"    // 留在座位上自動棄牌，直到籌碼被盲注耗盡後淘汰 (不可補碼)
+)
+
+/*
+playerForfeit MTT 玩家棄賽
+  - 等待補碼中的玩家: 放棄補碼直接淘汰
+  - remove_chips: 兩手之間離桌、移出拆併桌監管器，並以當下淘汰順位排名
+  - blind_off: 玩家留在座位上，輪到行動時自動棄牌 (見 handlePenalizedPlayerTurn) 直到沒有籌碼，之後不可補碼
+*/
+func (ce *competitionEngine) playerForfeit(ctx context.Context, playerID string) error {
+	if ce.isEndStatus() {
+		return ErrCompetitionQuitRejected
+	}
+
+	ce.mu.Lock()
+	cp := ce.competition.findPlayer(playerID)
+	if cp == nil || cp.Status == CompetitionPlayerStatus_Knockout || cp.IsForfeited {
+		ce.mu.Unlock()
+		return ErrCompetitionQuitRejected
+	}
+	cp.IsForfeited = true
+
+	if cp.Chips <= 0 {
+		rank := ce.knockoutForfeitedPlayer(cp)
+		ce.refreshPlayerStatusStatistics()
+		ce.refreshPlayerCompetitionRanks()
+		ce.mu.Unlock()
+
+		ce.emitForfeitKnockout(cp, rank)
+		ce.emitEvent(fmt.Sprintf("Player Forfeit -> %s Knockout", playerID), playerID)
+		ce.emitCompetitionStateEvent(CompetitionStateEvent_KnockoutPlayers)
+		return nil
+	}
+
+	if ce.competition.Meta.ForfeitPolicy == ForfeitPolicy_BlindOff {
+		ce.mu.Unlock()
+
+		ce.emitPlayerEvent("forfeit blind off", cp)
+		ce.emitEvent(fmt.Sprintf("Player Forfeit -> %s Blind Off", playerID), playerID)
+		return nil
+	}
+
+	ce.pendingForfeits[playerID] = true
+	ce.mu.Unlock()
+
+	ce.emitPlayerEvent("forfeit pending", cp)
+	ce.applyPendingForfeit(ctx, playerID)
+	return nil
+}
+
+/*
+handlePendingForfeits 處理本桌等待中的棄賽玩家
+  - 適用時機: 每手結算後 (兩手之間)
+*/
+func (ce *competitionEngine) handlePendingForfeits(ctx context.Context, tableID string) {
+	ce.mu.RLock()
+	playerIDs := make([]string, 0)
+	for playerID := range ce.pendingForfeits {
+		if cp := ce.competition.findPlayer(playerID); cp != nil && cp.CurrentTableID == tableID {
+			playerIDs = append(playerIDs, playerID)
+		}
+	}
+	ce.mu.RUnlock()
+
+	for _, playerID := range playerIDs {
+		ce.applyPendingForfeit(ctx, playerID)
+	}
+}
+
+/*
+applyPendingForfeit 移除棄賽玩家籌碼並淘汰
+  - 牌局進行中的桌次等到該手結算後再處理
+*/
+func (ce *competitionEngine) applyPendingForfeit(ctx context.Context, playerID string) {
+	ce.mu.Lock()
+	if !ce.pendingForfeits[playerID] {
+		ce.mu.Unlock()
+		return
+	}
+
+	cp := ce.competition.findPlayer(playerID)
+	if cp == nil {
+		delete(ce.pendingForfeits, playerID)
+		ce.mu.Unlock()
+		return
+	}
+
+	tableID := ""
+	if cp.Status == CompetitionPlayerStatus_Playing {
+		tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
+			return t.ID == cp.CurrentTableID
+		})
+		if tableIdx != UnsetValue {
+			table := ce.competition.State.Tables[tableIdx]
+			if table.State.Status == pokertable.TableStateStatus_TableGameOpened || table.State.Status == pokertable.TableStateStatus_TableGamePlaying {
+				ce.mu.Unlock()
+				return
+			}
+			tableID = table.ID
+		}
+	}
+	delete(ce.pendingForfeits, playerID)
+
+	isWaitingPlayer := funk.ContainsString(ce.waitingPlayers, playerID)
+	if isWaitingPlayer {
+		ce.waitingPlayers = funk.FilterString(ce.waitingPlayers, func(waitingPlayerID string) bool {
+			return waitingPlayerID != playerID
+		})
+	}
+	ce.mu.Unlock()
+
+	// 玩家離桌並移出拆併桌監管器
+	if tableID != "" {
+		if err := ce.backend(ctx).PlayersLeave(tableID, []string{playerID}); err != nil {
+			ce.emitErrorEvent("Player Forfeit -> PlayersLeave", playerID, err)
+			return
+		}
+	}
+	if !isWaitingPlayer && ce.isRegulatorStarted {
+		if err := ce.regulate(ctx, func() error {
+			return ce.regulator.RemovePlayers(tableID, []string{playerID})
+		}); err != nil {
+			ce.emitErrorEvent("Player Forfeit -> MTT Regulator Remove Players", playerID, err)
+		}
+	}
+
+	ce.mu.Lock()
+	cp.Chips = 0
+	rank := ce.knockoutForfeitedPlayer(cp)
+	ce.refreshPlayerStatusStatistics()
+	ce.refreshPlayerCompetitionRanks()
+	shouldCloseCompetition := !ce.isEndStatus() && ce.competition.State.BlindState.IsStopBuyIn() && ce.competition.PlayingPlayerCount() <= 1
+	ce.mu.Unlock()
+
+	ce.emitForfeitKnockout(cp, rank)
+	ce.emitEvent(fmt.Sprintf("Player Forfeit -> %s Knockout", playerID), playerID)
+	ce.emitCompetitionStateEvent(CompetitionStateEvent_KnockoutPlayers)
+
+	// 棄賽後只剩一位玩家: 結束賽事
+	if shouldCloseCompetition {
+		if err := timebank.NewTimeBank().NewTask(time.Second*3, func(isCancelled bool) {
+			if isCancelled {
+				return
+			}
+			ce.CloseCompetition(CompetitionStateStatus_End)
+		}); err != nil {
+			ce.emitErrorEvent("Player Forfeit -> Close Competition", "", err)
+		}
+	}
+}
+
+/*
+knockoutForfeitedPlayer 淘汰棄賽玩家並記錄排名 (需持有 ce.mu)，回傳名次，解鎖後再以 emitForfeitKnockout 發送事件
+  - 排名: 在仍有籌碼與仍可補碼的玩家之後
+*/
+func (ce *competitionEngine) knockoutForfeitedPlayer(cp *CompetitionPlayer) int {
+	cp.Status = CompetitionPlayerStatus_Knockout
+	cp.KnockoutAt = time.Now().Unix()
+	cp.IsReBuying = false
+	cp.ReBuyEndAt = UnsetValue
+	cp.CurrentSeat = UnsetValue
+
+	ce.competition.State.Rankings = append(ce.competition.State.Rankings, &CompetitionRank{
+		PlayerID:   cp.PlayerID,
+		FinalChips: 0,
+	})
+	return ce.competition.PlayingPlayerCount() + ce.competition.GetPlayerCountByStatus(CompetitionPlayerStatus_ReBuyWaiting) + 1
+}
+
+func (ce *competitionEngine) emitForfeitKnockout(cp *CompetitionPlayer, rank int) {
+	ce.emitPlayerEvent("forfeit knockout", cp)
+	ce.emitCompetitionStateFinalPlayerRankEvent(cp.PlayerID, rank)
+}
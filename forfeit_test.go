@@ -0,0 +1,166 @@
+package pokercompetition
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokertable"
+)
+
+// forfeitTestBackend 記錄離桌與棄牌的呼叫，其他操作不應被呼叫
+type forfeitTestBackend struct {
+	TableManagerBackend
+	mu     sync.Mutex
+	leaves []string
+	folds  []string
+}
+
+func (b *forfeitTestBackend) PlayersLeave(tableID string, playerIDs []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.leaves = append(b.leaves, playerIDs...)
+	return nil
+}
+
+func (b *forfeitTestBackend) PlayerFold(tableID, playerID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.folds = append(b.folds, playerID)
+	return nil
+}
+
+func (b *forfeitTestBackend) foldedPlayers() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string{}, b.folds...)
+}
+
+/*
+newForfeitTestEngine 建立桌次 t1 牌局進行中的 MTT 賽事
+  - p1、p2 有籌碼，p3 沒有籌碼等待補碼中
+*/
+func newForfeitTestEngine(policy ForfeitPolicy) (*competitionEngine, *forfeitTestBackend) {
+	p1 := newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_Playing)
+	p1.JoinAt = 1
+	p2 := newTestCompetitionPlayer("p2", 2000, CompetitionPlayerStatus_Playing)
+	p2.JoinAt = 2
+	p3 := newTestCompetitionPlayer("p3", 0, CompetitionPlayerStatus_ReBuyWaiting)
+	p3.JoinAt = 3
+	p3.IsReBuying = true
+
+	ce := newTestCompetitionEngine(p1, p2, p3)
+	ce.competition.Meta.ForfeitPolicy = policy
+	ce.competition.State.Status = CompetitionStateStatus_DelayedBuyIn
+	ce.competition.State.BlindState = &BlindState{CurrentLevelIndex: 0}
+	addTestTable(ce, "t1", pokertable.TableStateStatus_TableGamePlaying, p1, p2)
+
+	backend := &forfeitTestBackend{}
+	ce.tableManagerBackend = backend
+	return ce, backend
+}
+
+func Test_Forfeit_ReBuyWaitingPlayer(t *testing.T) {
+	ce, backend := newForfeitTestEngine(ForfeitPolicy_RemoveChips)
+	ranks := make(map[string]int)
+	ce.OnCompetitionFinalPlayerRankUpdated(func(competitionID, playerID string, rank int) {
+		ranks[playerID] = rank
+	})
+
+	assert.NoError(t, ce.playerForfeit(context.Background(), "p3"))
+
+	cp := ce.competition.findPlayer("p3")
+	assert.True(t, cp.IsForfeited)
+	assert.Equal(t, CompetitionPlayerStatus_Knockout, cp.Status)
+	assert.False(t, cp.IsReBuying)
+	assert.Equal(t, map[string]int{"p3": 3}, ranks)
+	assert.Empty(t, backend.leaves, "player without a seat should not leave the table")
+}
+
+func Test_Forfeit_RemoveChipsBetweenHands(t *testing.T) {
+	ce, backend := newForfeitTestEngine(ForfeitPolicy_RemoveChips)
+
+	// 牌局進行中: 等到該手結算後再處理
+	assert.NoError(t, ce.playerForfeit(context.Background(), "p1"))
+	cp := ce.competition.findPlayer("p1")
+	assert.True(t, cp.IsForfeited)
+	assert.Equal(t, int64(1000), cp.Chips)
+	assert.True(t, ce.pendingForfeits["p1"])
+	assert.Empty(t, backend.leaves)
+
+	ce.competition.State.Tables[0].State.Status = pokertable.TableStateStatus_TableGameStandby
+	ce.handlePendingForfeits(context.Background(), "t1")
+
+	assert.NotContains(t, ce.pendingForfeits, "p1")
+	assert.Equal(t, []string{"p1"}, backend.leaves)
+	assert.Equal(t, int64(0), cp.Chips)
+	assert.Equal(t, CompetitionPlayerStatus_Knockout, cp.Status)
+	if assert.Len(t, ce.competition.State.Rankings, 1) {
+		assert.Equal(t, "p1", ce.competition.State.Rankings[0].PlayerID)
+	}
+
+	// 已處理的棄賽不重複處理
+	ce.applyPendingForfeit(context.Background(), "p1")
+	assert.Equal(t, []string{"p1"}, backend.leaves)
+}
+
+func Test_Forfeit_BlindOffAutoFold(t *testing.T) {
+	ce, backend := newForfeitTestEngine(ForfeitPolicy_BlindOff)
+
+	assert.NoError(t, ce.playerForfeit(context.Background(), "p1"))
+	cp := ce.competition.findPlayer("p1")
+	assert.True(t, cp.IsForfeited)
+	assert.Equal(t, int64(1000), cp.Chips, "blinded-off player should keep the chips")
+	assert.Equal(t, CompetitionPlayerStatus_Playing, cp.Status)
+	assert.NotContains(t, ce.pendingForfeits, "p1")
+
+	// 其他玩家行動時不處理
+	ce.handlePenalizedPlayerTurn(context.Background(), newTestTableTurn(ce, "p2"))
+
+	// 輪到棄賽玩家行動時自動棄牌
+	ce.handlePenalizedPlayerTurn(context.Background(), newTestTableTurn(ce, "p1"))
+	assert.Eventually(t, func() bool {
+		return len(backend.foldedPlayers()) > 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"p1"}, backend.foldedPlayers())
+}
+
+func Test_Forfeit_Rejected(t *testing.T) {
+	ce, _ := newForfeitTestEngine(ForfeitPolicy_BlindOff)
+
+	assert.ErrorIs(t, ce.playerForfeit(context.Background(), "p9"), ErrCompetitionQuitRejected)
+
+	assert.NoError(t, ce.playerForfeit(context.Background(), "p1"))
+	assert.ErrorIs(t, ce.playerForfeit(context.Background(), "p1"), ErrCompetitionQuitRejected, "player can forfeit only once")
+
+	assert.NoError(t, ce.playerForfeit(context.Background(), "p3"))
+	assert.ErrorIs(t, ce.playerForfeit(context.Background(), "p3"), ErrCompetitionQuitRejected, "knocked out player cannot forfeit")
+
+	ce.competition.State.Status = CompetitionStateStatus_End
+	assert.ErrorIs(t, ce.playerForfeit(context.Background(), "p2"), ErrCompetitionQuitRejected)
+}
+
+func Test_Forfeit_PlayerQuitByMode(t *testing.T) {
+	// MTT: 棄賽
+	ce, _ := newForfeitTestEngine(ForfeitPolicy_BlindOff)
+	assert.NoError(t, ce.PlayerQuit("t1", "p1"))
+	assert.True(t, ce.competition.findPlayer("p1").IsForfeited)
+
+	// 現金桌: 離桌結算
+	ce, backend := newForfeitTestEngine(ForfeitPolicy_BlindOff)
+	ce.competition.Meta.Mode = CompetitionMode_Cash
+	ce.competition.State.Status = CompetitionStateStatus_Registering
+	cashOutPlayers := make([]string, 0)
+	ce.OnCompetitionPlayerCashOut(func(competitionID string, cp *CompetitionPlayer) {
+		cashOutPlayers = append(cashOutPlayers, cp.PlayerID)
+		assert.Equal(t, CompetitionPlayerStatus_CashLeaving, cp.Status)
+		assert.False(t, cp.IsForfeited)
+	})
+
+	assert.NoError(t, ce.PlayerQuit("t1", "p1"))
+	assert.Equal(t, []string{"p1"}, cashOutPlayers)
+	assert.Equal(t, []string{"p1"}, backend.leaves)
+	assert.Nil(t, ce.competition.findPlayer("p1"))
+}
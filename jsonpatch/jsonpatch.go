@@ -0,0 +1,442 @@
+package pokerjsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrPatchInvalidPath      = errors.New("jsonpatch: invalid path")
+	ErrPatchPathNotFound     = errors.New("jsonpatch: path not found")
+	ErrPatchInvalidOperation = errors.New("jsonpatch: invalid operation")
+	ErrPatchTestFailed       = errors.New("jsonpatch: test operation failed")
+)
+
+const (
+	Op_Add     = "add"
+	Op_Remove  = "remove"
+	Op_Replace = "replace"
+	Op_Move    = "move"
+	Op_Copy    = "copy"
+	Op_Test    = "test"
+)
+
+// Operation RFC 6902 JSON Patch 操作
+type Operation struct {
+	Op    string      `json:"op"`              // 操作類型
+	Path  string      `json:"path"`            // 目標路徑 (RFC 6901 JSON Pointer)
+	From  string      `json:"from,omitempty"`  // 來源路徑 (move, copy)
+	Value interface{} `json:"value,omitempty"` // 值 (add, replace, test)
+}
+
+// MarshalJSON add, replace, test 操作的值為 null 時仍需輸出 value
+func (op Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	if op.Value != nil || (op.Op != Op_Add && op.Op != Op_Replace && op.Op != Op_Test) {
+		return json.Marshal(operation(op))
+	}
+
+	return json.Marshal(struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}{
+		Op:    op.Op,
+		Path:  op.Path,
+		Value: nil,
+	})
+}
+
+/*
+Decode 將 JSON 轉為可比對與套用 patch 的文件
+  - 數字以 json.Number 保存，避免 int64 精度遺失
+*/
+func Decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+/*
+Diff 產生由 from 轉換為 to 的 patch
+  - object: 依 key 比對 (新增 add、移除 remove、其餘遞迴比對)
+  - array: 依索引值比對，多出的元素由尾端移除或新增
+  - 其他型別不相同時 replace
+*/
+func Diff(from, to interface{}) []Operation {
+	ops := make([]Operation, 0)
+	return diff(ops, "", from, to)
+}
+
+func diff(ops []Operation, path string, from, to interface{}) []Operation {
+	switch f := from.(type) {
+	case map[string]interface{}:
+		t, ok := to.(map[string]interface{})
+		if !ok {
+			return append(ops, Operation{Op: Op_Replace, Path: path, Value: to})
+		}
+
+		for _, key := range sortedKeys(f) {
+			if _, exist := t[key]; !exist {
+				ops = append(ops, Operation{Op: Op_Remove, Path: path + "/" + escapeToken(key)})
+			}
+		}
+		for _, key := range sortedKeys(t) {
+			childPath := path + "/" + escapeToken(key)
+			if fv, exist := f[key]; exist {
+				ops = diff(ops, childPath, fv, t[key])
+			} else {
+				ops = append(ops, Operation{Op: Op_Add, Path: childPath, Value: t[key]})
+			}
+		}
+		return ops
+	case []interface{}:
+		t, ok := to.([]interface{})
+		if !ok {
+			return append(ops, Operation{Op: Op_Replace, Path: path, Value: to})
+		}
+
+		commonLen := len(f)
+		if len(t) < commonLen {
+			commonLen = len(t)
+		}
+		for i := 0; i < commonLen; i++ {
+			ops = diff(ops, path+"/"+strconv.Itoa(i), f[i], t[i])
+		}
+		// 由尾端移除，避免索引值位移
+		for i := len(f) - 1; i >= commonLen; i-- {
+			ops = append(ops, Operation{Op: Op_Remove, Path: path + "/" + strconv.Itoa(i)})
+		}
+		for i := commonLen; i < len(t); i++ {
+			ops = append(ops, Operation{Op: Op_Add, Path: path + "/" + strconv.Itoa(i), Value: t[i]})
+		}
+		return ops
+	default:
+		if !Equal(from, to) {
+			ops = append(ops, Operation{Op: Op_Replace, Path: path, Value: to})
+		}
+		return ops
+	}
+}
+
+// Equal 比對兩份文件是否相同
+func Equal(a, b interface{}) bool {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, exist := bv[key]
+			if !exist || !Equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !Equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		switch bv := b.(type) {
+		case json.Number:
+			return av == bv
+		case float64:
+			f, err := av.Float64()
+			return err == nil && f == bv
+		}
+		return false
+	case float64:
+		if bv, ok := b.(json.Number); ok {
+			return Equal(bv, av)
+		}
+		bv, ok := b.(float64)
+		return ok && av == bv
+	default:
+		return a == b
+	}
+}
+
+/*
+Apply 將 patch 依序套用至文件，回傳套用後的文件
+  - 文件會被直接修改，套用失敗時文件可能只套用了部分操作
+*/
+func Apply(doc interface{}, ops []Operation) (interface{}, error) {
+	var err error
+	for _, op := range ops {
+		switch op.Op {
+		case Op_Add:
+			doc, err = add(doc, op.Path, op.Value)
+		case Op_Remove:
+			doc, _, err = remove(doc, op.Path)
+		case Op_Replace:
+			doc, _, err = remove(doc, op.Path)
+			if err == nil {
+				doc, err = add(doc, op.Path, op.Value)
+			}
+		case Op_Move:
+			var value interface{}
+			doc, value, err = remove(doc, op.From)
+			if err == nil {
+				doc, err = add(doc, op.Path, value)
+			}
+		case Op_Copy:
+			var value interface{}
+			value, err = get(doc, op.From)
+			if err == nil {
+				doc, err = add(doc, op.Path, deepCopy(value))
+			}
+		case Op_Test:
+			var value interface{}
+			value, err = get(doc, op.Path)
+			if err == nil && !Equal(value, normalize(op.Value)) {
+				err = ErrPatchTestFailed
+			}
+		default:
+			err = ErrPatchInvalidOperation
+		}
+
+		if err != nil {
+			return doc, err
+		}
+	}
+	return doc, nil
+}
+
+func parsePath(path string) ([]string, error) {
+	if path == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, ErrPatchInvalidPath
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = unescapeToken(token)
+	}
+	return tokens, nil
+}
+
+func escapeToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func unescapeToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, ErrPatchInvalidPath
+	}
+
+	maxIdx := length - 1
+	if allowEnd {
+		maxIdx = length
+	}
+	if idx > maxIdx {
+		return 0, ErrPatchPathNotFound
+	}
+	return idx, nil
+}
+
+func get(doc interface{}, path string) (interface{}, error) {
+	tokens, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return getTokens(doc, tokens)
+}
+
+// setChild 將修改後的子節點寫回父節點 (slice 長度改變時需要)
+func setChild(parent interface{}, token string, child interface{}) {
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[token] = child
+	case []interface{}:
+		if idx, err := strconv.Atoi(token); err == nil && idx >= 0 && idx < len(node) {
+			node[idx] = child
+		}
+	}
+}
+
+func add(doc interface{}, path string, value interface{}) (interface{}, error) {
+	tokens, err := parsePath(path)
+	if err != nil {
+		return doc, err
+	}
+	// 不與 patch 共用節點，避免之後套用的 patch 修改到來源資料
+	value = deepCopy(normalize(value))
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	parentPath := tokens[:len(tokens)-1]
+	parent, err := getTokens(doc, parentPath)
+	if err != nil {
+		return doc, err
+	}
+
+	last := tokens[len(tokens)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+		return doc, nil
+	case []interface{}:
+		idx, err := arrayIndex(last, len(node), true)
+		if err != nil {
+			return doc, err
+		}
+		node = append(node, nil)
+		copy(node[idx+1:], node[idx:])
+		node[idx] = value
+		return replaceTokens(doc, parentPath, node)
+	default:
+		return doc, ErrPatchPathNotFound
+	}
+}
+
+func remove(doc interface{}, path string) (interface{}, interface{}, error) {
+	tokens, err := parsePath(path)
+	if err != nil {
+		return doc, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, doc, nil
+	}
+
+	parentPath := tokens[:len(tokens)-1]
+	parent, err := getTokens(doc, parentPath)
+	if err != nil {
+		return doc, nil, err
+	}
+
+	last := tokens[len(tokens)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		value, exist := node[last]
+		if !exist {
+			return doc, nil, ErrPatchPathNotFound
+		}
+		delete(node, last)
+		return doc, value, nil
+	case []interface{}:
+		idx, err := arrayIndex(last, len(node), false)
+		if err != nil {
+			return doc, nil, err
+		}
+		value := node[idx]
+		node = append(node[:idx], node[idx+1:]...)
+		doc, err = replaceTokens(doc, parentPath, node)
+		return doc, value, err
+	default:
+		return doc, nil, ErrPatchPathNotFound
+	}
+}
+
+func getTokens(doc interface{}, tokens []string) (interface{}, error) {
+	current := doc
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, exist := node[token]
+			if !exist {
+				return nil, ErrPatchPathNotFound
+			}
+			current = value
+		case []interface{}:
+			idx, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			current = node[idx]
+		default:
+			return nil, ErrPatchPathNotFound
+		}
+	}
+	return current, nil
+}
+
+// replaceTokens 以新的節點取代指定路徑的節點
+func replaceTokens(doc interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	parent, err := getTokens(doc, tokens[:len(tokens)-1])
+	if err != nil {
+		return doc, err
+	}
+	setChild(parent, tokens[len(tokens)-1], value)
+	return doc, nil
+}
+
+// normalize 將任意值轉為 Decode 產生的型別 (ex: struct -> map, int -> json.Number)
+func normalize(value interface{}) interface{} {
+	switch value.(type) {
+	case nil, bool, string, json.Number, map[string]interface{}, []interface{}:
+		return value
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	doc, err := Decode(data)
+	if err != nil {
+		return value
+	}
+	return doc
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			m[key] = deepCopy(child)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, child := range v {
+			s[i] = deepCopy(child)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	// 固定順序，相同的輸入產生相同的 patch
+	sort.Strings(keys)
+	return keys
+}
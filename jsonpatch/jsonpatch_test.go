@@ -0,0 +1,380 @@
+package pokerjsonpatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustDecode(t *testing.T, data string) interface{} {
+	doc, err := Decode([]byte(data))
+	assert.NoError(t, err)
+	return doc
+}
+
+func Test_JSONPatch_DiffApply(t *testing.T) {
+	from := mustDecode(t, `{
+		"update_serial": 9007199254740993,
+		"state": {
+			"players": [{"player_id": "a", "chips": 100}, {"player_id": "b", "chips": 200}, {"player_id": "c", "chips": 300}],
+			"tables": [{"id": "t1"}],
+			"a/b": 1,
+			"removed": true
+		}
+	}`)
+	to := mustDecode(t, `{
+		"update_serial": 9007199254740994,
+		"state": {
+			"players": [{"player_id": "a", "chips": 150}, {"player_id": "c", "chips": 300}],
+			"tables": [{"id": "t1"}, {"id": "t2"}],
+			"a/b": null,
+			"added": {"x": [1, 2]}
+		}
+	}`)
+
+	ops := Diff(from, to)
+	assert.NotEmpty(t, ops)
+	assert.Contains(t, ops, Operation{Op: Op_Replace, Path: "/state/a~1b", Value: nil})
+	assert.Contains(t, ops, Operation{Op: Op_Remove, Path: "/state/removed"})
+
+	// 經過 JSON 編碼後套用
+	data, err := json.Marshal(ops)
+	assert.NoError(t, err)
+	var decodedOps []Operation
+	assert.NoError(t, json.Unmarshal(data, &decodedOps))
+
+	patched, err := Apply(mustDecode(t, `{
+		"update_serial": 9007199254740993,
+		"state": {
+			"players": [{"player_id": "a", "chips": 100}, {"player_id": "b", "chips": 200}, {"player_id": "c", "chips": 300}],
+			"tables": [{"id": "t1"}],
+			"a/b": 1,
+			"removed": true
+		}
+	}`), decodedOps)
+	assert.NoError(t, err)
+	assert.True(t, Equal(to, patched))
+	assert.Empty(t, Diff(to, patched))
+
+	// int64 精度
+	serial, err := patched.(map[string]interface{})["update_serial"].(json.Number).Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9007199254740994), serial)
+}
+
+func Test_JSONPatch_Operations(t *testing.T) {
+	doc := mustDecode(t, `{"foo": ["bar", "baz"], "obj": {"k": "v"}}`)
+
+	doc, err := Apply(doc, []Operation{
+		{Op: Op_Add, Path: "/foo/1", Value: "qux"},
+		{Op: Op_Add, Path: "/foo/-", Value: "end"},
+		{Op: Op_Copy, From: "/obj", Path: "/copied"},
+		{Op: Op_Move, From: "/obj/k", Path: "/moved"},
+		{Op: Op_Test, Path: "/foo", Value: []string{"bar", "qux", "baz", "end"}},
+		{Op: Op_Test, Path: "/copied/k", Value: "v"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, Equal(mustDecode(t, `{"foo": ["bar", "qux", "baz", "end"], "obj": {}, "copied": {"k": "v"}, "moved": "v"}`), doc))
+
+	_, err = Apply(doc, []Operation{{Op: Op_Remove, Path: "/missing"}})
+	assert.ErrorIs(t, err, ErrPatchPathNotFound)
+
+	_, err = Apply(doc, []Operation{{Op: Op_Replace, Path: "/foo/9", Value: 1}})
+	assert.ErrorIs(t, err, ErrPatchPathNotFound)
+
+	_, err = Apply(doc, []Operation{{Op: Op_Test, Path: "/moved", Value: "x"}})
+	assert.ErrorIs(t, err, ErrPatchTestFailed)
+
+	_, err = Apply(doc, []Operation{{Op: "unknown", Path: "/moved"}})
+	assert.ErrorIs(t, err, ErrPatchInvalidOperation)
+}
+
+func Test_JSONPatch_ApplyDoesNotShareValues(t *testing.T) {
+	to := mustDecode(t, `{"list": [{"chips": 1}]}`)
+	ops := Diff(mustDecode(t, `{}`), to)
+
+	patched, err := Apply(mustDecode(t, `{}`), ops)
+	assert.NoError(t, err)
+
+	_, err = Apply(patched, []Operation{{Op: Op_Replace, Path: "/list/0/chips", Value: 2}})
+	assert.NoError(t, err)
+	assert.True(t, Equal(mustDecode(t, `{"list": [{"chips": 1}]}`), to), "source document should not be modified")
+}
+
+func Test_JSONPatch_RFC6902Examples(t *testing.T) {
+	// RFC 6902 Appendix A
+	testCases := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+		err      error
+	}{
+		{
+			name:     "A.1 adding an object member",
+			doc:      `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/baz", "value": "qux"}]`,
+			expected: `{"baz": "qux", "foo": "bar"}`,
+		},
+		{
+			name:     "A.2 adding an array element",
+			doc:      `{"foo": ["bar", "baz"]}`,
+			patch:    `[{"op": "add", "path": "/foo/1", "value": "qux"}]`,
+			expected: `{"foo": ["bar", "qux", "baz"]}`,
+		},
+		{
+			name:     "A.3 removing an object member",
+			doc:      `{"baz": "qux", "foo": "bar"}`,
+			patch:    `[{"op": "remove", "path": "/baz"}]`,
+			expected: `{"foo": "bar"}`,
+		},
+		{
+			name:     "A.4 removing an array element",
+			doc:      `{"foo": ["bar", "qux", "baz"]}`,
+			patch:    `[{"op": "remove", "path": "/foo/1"}]`,
+			expected: `{"foo": ["bar", "baz"]}`,
+		},
+		{
+			name:     "A.5 replacing a value",
+			doc:      `{"baz": "qux", "foo": "bar"}`,
+			patch:    `[{"op": "replace", "path": "/baz", "value": "boo"}]`,
+			expected: `{"baz": "boo", "foo": "bar"}`,
+		},
+		{
+			name:     "A.6 moving a value",
+			doc:      `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			patch:    `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			expected: `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`,
+		},
+		{
+			name:     "A.7 moving an array element",
+			doc:      `{"foo": ["all", "grass", "cows", "eat"]}`,
+			patch:    `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+			expected: `{"foo": ["all", "cows", "eat", "grass"]}`,
+		},
+		{
+			name:     "A.8 testing a value: success",
+			doc:      `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+			patch:    `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`,
+			expected: `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+		},
+		{
+			name:  "A.9 testing a value: error",
+			doc:   `{"baz": "qux"}`,
+			patch: `[{"op": "test", "path": "/baz", "value": "bar"}]`,
+			err:   ErrPatchTestFailed,
+		},
+		{
+			name:     "A.10 adding a nested member object",
+			doc:      `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`,
+			expected: `{"foo": "bar", "child": {"grandchild": {}}}`,
+		},
+		{
+			name:  "A.12 adding to a nonexistent target",
+			doc:   `{"foo": "bar"}`,
+			patch: `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`,
+			err:   ErrPatchPathNotFound,
+		},
+		{
+			name:     "A.14 ~ escape ordering",
+			doc:      `{"/": 9, "~1": 10}`,
+			patch:    `[{"op": "test", "path": "/~01", "value": 10}]`,
+			expected: `{"/": 9, "~1": 10}`,
+		},
+		{
+			name:  "A.15 comparing strings and numbers",
+			doc:   `{"/": 9, "~1": 10}`,
+			patch: `[{"op": "test", "path": "/~01", "value": "10"}]`,
+			err:   ErrPatchTestFailed,
+		},
+		{
+			name:     "A.16 adding an array value",
+			doc:      `{"foo": ["bar"]}`,
+			patch:    `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`,
+			expected: `{"foo": ["bar", ["abc", "def"]]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ops []Operation
+			assert.NoError(t, json.Unmarshal([]byte(tc.patch), &ops))
+
+			doc, err := Apply(mustDecode(t, tc.doc), ops)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, Equal(mustDecode(t, tc.expected), doc), "unexpected document: %v", doc)
+		})
+	}
+}
+
+func Test_JSONPatch_ArrayEdgeCases(t *testing.T) {
+	testCases := []struct {
+		name     string
+		doc      string
+		ops      []Operation
+		expected string
+		err      error
+	}{
+		{
+			name:     "add at end index",
+			doc:      `{"foo": ["a"]}`,
+			ops:      []Operation{{Op: Op_Add, Path: "/foo/1", Value: "b"}},
+			expected: `{"foo": ["a", "b"]}`,
+		},
+		{
+			name:     "add at head",
+			doc:      `{"foo": ["a", "b"]}`,
+			ops:      []Operation{{Op: Op_Add, Path: "/foo/0", Value: "c"}},
+			expected: `{"foo": ["c", "a", "b"]}`,
+		},
+		{
+			name:     "add to empty array",
+			doc:      `{"foo": []}`,
+			ops:      []Operation{{Op: Op_Add, Path: "/foo/-", Value: 1}},
+			expected: `{"foo": [1]}`,
+		},
+		{
+			name:     "add to nested array",
+			doc:      `{"foo": [[1], [2]]}`,
+			ops:      []Operation{{Op: Op_Add, Path: "/foo/1/0", Value: 3}},
+			expected: `{"foo": [[1], [3, 2]]}`,
+		},
+		{
+			name: "add past end",
+			doc:  `{"foo": ["a"]}`,
+			ops:  []Operation{{Op: Op_Add, Path: "/foo/2", Value: "b"}},
+			err:  ErrPatchPathNotFound,
+		},
+		{
+			name: "leading zero index",
+			doc:  `{"foo": ["a", "b"]}`,
+			ops:  []Operation{{Op: Op_Add, Path: "/foo/01", Value: "c"}},
+			err:  ErrPatchInvalidPath,
+		},
+		{
+			name: "negative index",
+			doc:  `{"foo": ["a"]}`,
+			ops:  []Operation{{Op: Op_Remove, Path: "/foo/-1"}},
+			err:  ErrPatchInvalidPath,
+		},
+		{
+			name:     "remove last element",
+			doc:      `{"foo": ["a", "b"]}`,
+			ops:      []Operation{{Op: Op_Remove, Path: "/foo/1"}},
+			expected: `{"foo": ["a"]}`,
+		},
+		{
+			name:     "remove every element",
+			doc:      `{"foo": ["a", "b"]}`,
+			ops:      []Operation{{Op: Op_Remove, Path: "/foo/1"}, {Op: Op_Remove, Path: "/foo/0"}},
+			expected: `{"foo": []}`,
+		},
+		{
+			name: "remove end marker",
+			doc:  `{"foo": ["a"]}`,
+			ops:  []Operation{{Op: Op_Remove, Path: "/foo/-"}},
+			err:  ErrPatchInvalidPath,
+		},
+		{
+			name: "remove from empty array",
+			doc:  `{"foo": []}`,
+			ops:  []Operation{{Op: Op_Remove, Path: "/foo/0"}},
+			err:  ErrPatchPathNotFound,
+		},
+		{
+			name:     "remove nested array element",
+			doc:      `{"foo": [{"bar": [1, 2, 3]}]}`,
+			ops:      []Operation{{Op: Op_Remove, Path: "/foo/0/bar/0"}},
+			expected: `{"foo": [{"bar": [2, 3]}]}`,
+		},
+		{
+			name:     "replace root",
+			doc:      `{"foo": ["a"]}`,
+			ops:      []Operation{{Op: Op_Replace, Path: "", Value: []int{1, 2}}},
+			expected: `[1, 2]`,
+		},
+		{
+			name:     "root array",
+			doc:      `["a", "b"]`,
+			ops:      []Operation{{Op: Op_Remove, Path: "/0"}, {Op: Op_Add, Path: "/-", Value: "c"}},
+			expected: `["b", "c"]`,
+		},
+		{
+			name: "path without leading slash",
+			doc:  `{"foo": ["a"]}`,
+			ops:  []Operation{{Op: Op_Remove, Path: "foo/0"}},
+			err:  ErrPatchInvalidPath,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := Apply(mustDecode(t, tc.doc), tc.ops)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, Equal(mustDecode(t, tc.expected), doc), "unexpected document: %v", doc)
+		})
+	}
+}
+
+func Test_JSONPatch_DiffArrays(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from     string
+		to       string
+		expected []Operation
+	}{
+		{
+			name:     "append",
+			from:     `{"foo": [1]}`,
+			to:       `{"foo": [1, 2, 3]}`,
+			expected: []Operation{{Op: Op_Add, Path: "/foo/1", Value: json.Number("2")}, {Op: Op_Add, Path: "/foo/2", Value: json.Number("3")}},
+		},
+		{
+			name:     "shrink from the end",
+			from:     `{"foo": [1, 2, 3]}`,
+			to:       `{"foo": [1]}`,
+			expected: []Operation{{Op: Op_Remove, Path: "/foo/2"}, {Op: Op_Remove, Path: "/foo/1"}},
+		},
+		{
+			name:     "to empty",
+			from:     `{"foo": [1, 2]}`,
+			to:       `{"foo": []}`,
+			expected: []Operation{{Op: Op_Remove, Path: "/foo/1"}, {Op: Op_Remove, Path: "/foo/0"}},
+		},
+		{
+			name:     "array to object",
+			from:     `{"foo": [1]}`,
+			to:       `{"foo": {"a": 1}}`,
+			expected: []Operation{{Op: Op_Replace, Path: "/foo", Value: map[string]interface{}{"a": json.Number("1")}}},
+		},
+		{
+			name:     "unchanged",
+			from:     `{"foo": [1, [2]]}`,
+			to:       `{"foo": [1, [2]]}`,
+			expected: []Operation{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from := mustDecode(t, tc.from)
+			to := mustDecode(t, tc.to)
+			ops := Diff(from, to)
+			assert.Equal(t, tc.expected, ops)
+
+			patched, err := Apply(from, ops)
+			assert.NoError(t, err)
+			assert.True(t, Equal(to, patched))
+		})
+	}
+}
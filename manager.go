@@ -4,11 +4,13 @@ import (
 	"errors"
 	"sync"
 
+	"github.com/google/uuid"
 	"github.com/weedbox/pokertable"
 )
 
 var (
-	ErrManagerCompetitionNotFound = errors.New("manager: competition not found")
+	ErrManagerCompetitionNotFound       = errors.New("manager: competition not found")
+	ErrManagerAdvancementResultNotFound = errors.New("manager: advancement result not found")
 )
 
 type Manager interface {
@@ -20,6 +22,8 @@ type Manager interface {
 
 	// Competition Actions
 	CreateCompetition(competitionSetting CompetitionSetting, options *CompetitionEngineOptions) (*Competition, error)
+	CreateCompetitionFromAdvancement(resultIDs []string, competitionSetting CompetitionSetting, carryOverSetting CarryOverSetting, options *CompetitionEngineOptions) (*Competition, error)
+	GetAdvancementResult(resultID string) (*AdvancementResult, error)
 	UpdateCompetitionBlindInitialLevel(competitionID string, level int) error
 	CloseCompetition(competitionID string, endStatus CompetitionStateStatus) error
 	StartCompetition(competitionID string) (int64, error)
@@ -38,54 +42,168 @@ type Manager interface {
 	PlayerRefund(competitionID string, playerID string) error
 	PlayerCashOut(competitionID string, tableID, playerID string) error
 	PlayerQuit(competitionID string, tableID, playerID string) error
+	GetAlternatePosition(competitionID, playerID string) (int, error)
+
+	// Table Operations
+	SetTableFeatured(competitionID, tableID string, isFeatured bool) error
+	MovePlayerToFeaturedTable(competitionID, actorID, playerID, tableID string) error
+
+	// Director Operations
+	DisqualifyPlayer(competitionID, directorID, playerID, reason string) error
+	PenalizePlayer(competitionID, directorID, playerID string, orbits, seconds int, reason string) error
+	AdjustPlayerChips(competitionID, directorID, playerID string, chips int64, reason string) error
+	MovePlayer(competitionID, directorID, playerID, tableID string, seat int, reason string) error
+
+	// Event Subscriptions
+	SubscribeEvents(competitionID, actorID string, handler func(event Event), options SubscribeOptions) (*Subscription, error)
+	RequestDeltaSnapshot(competitionID string) error
+}
+
+type ManagerOpt func(*manager)
+
+// WithAuditSink 記錄所有 Manager 呼叫與其觸發的 backend 呼叫
+func WithAuditSink(sink AuditSink) ManagerOpt {
+	return func(m *manager) {
+		m.auditSink = sink
+	}
+}
+
+// WithAuditErrorHandler 設定寫入稽核紀錄失敗時的處理函式 (預設忽略)
+func WithAuditErrorHandler(fn AuditErrorHandler) ManagerOpt {
+	return func(m *manager) {
+		m.auditErrorHandler = fn
+	}
 }
 
 type manager struct {
 	tableOptions        *pokertable.TableEngineOptions
 	competitionEngines  sync.Map
+	advancementResults  sync.Map // key: result id, value: *AdvancementResult
 	tableManagerBackend TableManagerBackend
+	auditSink           AuditSink
+	auditErrorHandler   AuditErrorHandler
+	auditTrail          *auditTrail // 不屬於特定賽事的 Manager 呼叫
+	auditTrails         sync.Map    // key: competition id, value: *auditTrail
 }
 
-func NewManager(tableManagerBackend TableManagerBackend) Manager {
+func NewManager(tableManagerBackend TableManagerBackend, opts ...ManagerOpt) Manager {
 	tableOptions := pokertable.NewTableEngineOptions()
 	tableOptions.GameContinueInterval = 6
 	tableOptions.OpenGameTimeout = 2
 
-	return &manager{
+	m := &manager{
 		tableOptions:        tableOptions,
 		competitionEngines:  sync.Map{},
+		advancementResults:  sync.Map{},
 		tableManagerBackend: tableManagerBackend,
+		auditTrails:         sync.Map{},
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.auditTrail = newAuditTrail(m.auditSink, m.auditErrorHandler, "")
+
+	return m
+}
+
+/*
+beginAudit 開始記錄一次 Manager 呼叫
+  - 產生新的關聯 ID (由呼叫本身持有)，以 call.context() 傳給賽事引擎觸發的 backend 呼叫
+  - 沒有設定 AuditSink 時回傳 nil (end 不做任何事)
+*/
+func (m *manager) beginAudit(competitionID, actor, operation string, params auditParams) *auditCall {
+	if m.auditSink == nil {
+		return nil
+	}
+
+	trail := m.auditTrail
+	if competitionID != "" {
+		if t, exist := m.auditTrails.Load(competitionID); exist {
+			trail = t.(*auditTrail)
+		}
+	}
+
+	return newAuditCall(trail, AuditRecord{
+		CorrelationID: uuid.New().String(),
+		Source:        AuditSource_Manager,
+		Actor:         actor,
+		CompetitionID: competitionID,
+		Operation:     operation,
+		Params:        auditSnapshot(params),
+	})
 }
 
 func (m *manager) Reset() {
+	call := m.beginAudit("", AuditActor_System, "Reset", nil)
+	defer func() { call.end(nil, nil) }()
+
 	m.competitionEngines.Range(func(key, value interface{}) bool {
 		if ce, ok := value.(CompetitionEngine); ok {
 			_ = ce.CloseCompetition(CompetitionStateStatus_ForceEnd)
+			ce.CloseEventBus()
 		}
 		return true
 	})
 
 	m.competitionEngines = sync.Map{}
+	m.auditTrails = sync.Map{}
 }
 
 func (m *manager) ReleaseCompetition(competitionID string) {
+	call := m.beginAudit(competitionID, AuditActor_System, "ReleaseCompetition", nil)
+	defer func() { call.end(nil, nil) }()
+
+	m.releaseCompetition(competitionID)
+}
+
+func (m *manager) releaseCompetition(competitionID string) {
+	if competitionEngine, err := m.loadCompetitionEngine(competitionID); err == nil {
+		competitionEngine.CloseEventBus()
+	}
 	m.competitionEngines.Delete(competitionID)
+	m.auditTrails.Delete(competitionID)
+}
+
+func (m *manager) GetCompetitionEngine(competitionID string) (competitionEngine CompetitionEngine, err error) {
+	call := m.beginAudit(competitionID, AuditActor_System, "GetCompetitionEngine", nil)
+	defer func() { call.end(nil, err) }()
+
+	ce, err := m.loadCompetitionEngine(competitionID)
+	if err != nil {
+		return nil, err
+	}
+	return ce, nil
 }
 
-func (m *manager) GetCompetitionEngine(competitionID string) (CompetitionEngine, error) {
-	competitionEngine, exist := m.competitionEngines.Load(competitionID)
+func (m *manager) loadCompetitionEngine(competitionID string) (*competitionEngine, error) {
+	ce, exist := m.competitionEngines.Load(competitionID)
 	if !exist {
 		return nil, ErrManagerCompetitionNotFound
 	}
-	return competitionEngine.(CompetitionEngine), nil
+	return ce.(*competitionEngine), nil
 }
 
-func (m *manager) CreateCompetition(competitionSetting CompetitionSetting, options *CompetitionEngineOptions) (*Competition, error) {
+func (m *manager) CreateCompetition(competitionSetting CompetitionSetting, options *CompetitionEngineOptions) (competition *Competition, err error) {
+	call := m.beginAudit("", AuditActor_System, "CreateCompetition", auditParams{"competition_setting": competitionSetting})
+	defer func() { call.end(auditCompetitionResult(competition), err) }()
+
+	return m.createCompetition(competitionSetting, options, call)
+}
+
+func (m *manager) createCompetition(competitionSetting CompetitionSetting, options *CompetitionEngineOptions, call *auditCall) (*Competition, error) {
+	// 賽事的 backend 呼叫以觸發的 Manager 呼叫關聯 ID 記錄
+	tableManagerBackend := m.tableManagerBackend
+	var trail *auditTrail
+	if call != nil {
+		trail = newAuditTrail(m.auditSink, m.auditErrorHandler, "")
+		tableManagerBackend = newAuditTableManagerBackend(tableManagerBackend, trail)
+	}
+
 	competitionEngine := NewCompetitionEngine(
-		WithTableManagerBackend(m.tableManagerBackend),
+		WithTableManagerBackend(tableManagerBackend),
 		WithTableOptions(m.tableOptions),
-	)
+		WithDeltaSnapshotInterval(options.DeltaSnapshotInterval),
+	).(*competitionEngine)
 	competitionEngine.OnCompetitionUpdated(options.OnCompetitionUpdated)
 	competitionEngine.OnCompetitionErrorUpdated(options.OnCompetitionErrorUpdated)
 	competitionEngine.OnCompetitionPlayerUpdated(options.OnCompetitionPlayerUpdated)
@@ -93,17 +211,113 @@ func (m *manager) CreateCompetition(competitionSetting CompetitionSetting, optio
 	competitionEngine.OnCompetitionStateUpdated(options.OnCompetitionStateUpdated)
 	competitionEngine.OnAdvancePlayerCountUpdated(options.OnAdvancePlayerCountUpdated)
 	competitionEngine.OnCompetitionPlayerCashOut(options.OnCompetitionPlayerCashOut)
-	competition, err := competitionEngine.CreateCompetition(competitionSetting)
+	if options.OnCompetitionPlayerMoved != nil {
+		competitionEngine.OnCompetitionPlayerMoved(options.OnCompetitionPlayerMoved)
+	}
+	if options.OnCompetitionAddonOffered != nil {
+		competitionEngine.OnCompetitionAddonOffered(options.OnCompetitionAddonOffered)
+	}
+	if options.OnEvent != nil {
+		competitionEngine.OnEvent(options.OnEvent)
+	}
+	if options.OnCompetitionDeltaUpdated != nil {
+		competitionEngine.OnCompetitionDeltaUpdated(options.OnCompetitionDeltaUpdated)
+	}
+	competitionEngine.OnAdvancementResultCreated(func(result *AdvancementResult) {
+		// 保存晉級結果供下一階段賽事使用
+		m.advancementResults.Store(result.ID, result)
+		if options.OnAdvancementResultCreated != nil {
+			options.OnAdvancementResultCreated(result)
+		}
+	})
+	competition, err := competitionEngine.createCompetitionContext(call.context(), competitionSetting)
 	if err != nil {
 		return nil, err
 	}
 
+	if trail != nil {
+		trail.setCompetitionID(competition.ID)
+		m.auditTrails.Store(competition.ID, trail)
+		call.record.CompetitionID = competition.ID
+	}
 	m.competitionEngines.Store(competition.ID, competitionEngine)
 	return competition, nil
 }
 
-func (m *manager) UpdateCompetitionBlindInitialLevel(competitionID string, level int) error {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+/*
+CreateCompetitionFromAdvancement 以晉級結果建立下一階段賽事
+  - 可合併多個晉級結果 (ex: 多個 Day 1 場次晉級至 Day 2)
+  - 晉級玩家以晉級時籌碼 (可正規化) 與桌號、座位報名下一階段賽事，不受人數上限與候補名單限制
+  - 建立賽事前先驗證賽事設定與所有晉級玩家，晉級玩家報名失敗時釋放已建立的賽事
+*/
+func (m *manager) CreateCompetitionFromAdvancement(resultIDs []string, competitionSetting CompetitionSetting, carryOverSetting CarryOverSetting, options *CompetitionEngineOptions) (competition *Competition, err error) {
+	call := m.beginAudit("", AuditActor_System, "CreateCompetitionFromAdvancement", auditParams{
+		"result_ids":          resultIDs,
+		"competition_setting": competitionSetting,
+		"carry_over_setting":  carryOverSetting,
+	})
+	defer func() { call.end(auditCompetitionResult(competition), err) }()
+
+	results := make([]*AdvancementResult, 0, len(resultIDs))
+	for _, resultID := range resultIDs {
+		result, err := m.loadAdvancementResult(resultID)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return nil, ErrManagerAdvancementResultNotFound
+	}
+
+	if violations := ValidateCompetitionSetting(competitionSetting); len(violations) > 0 {
+		return nil, violations
+	}
+
+	joinPlayers := NewCarryOverJoinPlayers(results, carryOverSetting, competitionSetting.Meta)
+	if err := ValidateCarryOverJoinPlayers(joinPlayers, competitionSetting.Meta); err != nil {
+		return nil, err
+	}
+
+	competition, err = m.createCompetition(competitionSetting, options, call)
+	if err != nil {
+		return nil, err
+	}
+
+	competitionEngine, err := m.loadCompetitionEngine(competition.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := competitionEngine.playersCarryOverContext(call.context(), joinPlayers); err != nil {
+		_ = competitionEngine.closeCompetitionContext(call.context(), CompetitionStateStatus_ForceEnd)
+		m.releaseCompetition(competition.ID)
+		return nil, err
+	}
+
+	return competitionEngine.GetCompetition(), nil
+}
+
+func (m *manager) GetAdvancementResult(resultID string) (result *AdvancementResult, err error) {
+	call := m.beginAudit("", AuditActor_System, "GetAdvancementResult", auditParams{"result_id": resultID})
+	defer func() { call.end(nil, err) }()
+
+	return m.loadAdvancementResult(resultID)
+}
+
+func (m *manager) loadAdvancementResult(resultID string) (*AdvancementResult, error) {
+	result, exist := m.advancementResults.Load(resultID)
+	if !exist {
+		return nil, ErrManagerAdvancementResultNotFound
+	}
+	return result.(*AdvancementResult), nil
+}
+
+func (m *manager) UpdateCompetitionBlindInitialLevel(competitionID string, level int) (err error) {
+	call := m.beginAudit(competitionID, AuditActor_System, "UpdateCompetitionBlindInitialLevel", auditParams{"level": level})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return ErrManagerCompetitionNotFound
 	}
@@ -111,13 +325,16 @@ func (m *manager) UpdateCompetitionBlindInitialLevel(competitionID string, level
 	return competitionEngine.UpdateCompetitionBlindInitialLevel(level)
 }
 
-func (m *manager) CloseCompetition(competitionID string, endStatus CompetitionStateStatus) error {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+func (m *manager) CloseCompetition(competitionID string, endStatus CompetitionStateStatus) (err error) {
+	call := m.beginAudit(competitionID, AuditActor_System, "CloseCompetition", auditParams{"end_status": endStatus})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return ErrManagerCompetitionNotFound
 	}
 
-	if err := competitionEngine.CloseCompetition(endStatus); err != nil {
+	if err := competitionEngine.closeCompetitionContext(call.context(), endStatus); err != nil {
 		return err
 	}
 
@@ -125,35 +342,50 @@ func (m *manager) CloseCompetition(competitionID string, endStatus CompetitionSt
 	return nil
 }
 
-func (m *manager) StartCompetition(competitionID string) (int64, error) {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+func (m *manager) StartCompetition(competitionID string) (startAt int64, err error) {
+	call := m.beginAudit(competitionID, AuditActor_System, "StartCompetition", nil)
+	defer func() { call.end(auditParams{"start_at": startAt}, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return 0, ErrManagerCompetitionNotFound
 	}
 
-	return competitionEngine.StartCompetition()
+	return competitionEngine.startCompetitionContext(call.context())
 }
 
 func (m *manager) GetTableEngineOptions() *pokertable.TableEngineOptions {
+	call := m.beginAudit("", AuditActor_System, "GetTableEngineOptions", nil)
+	defer func() { call.end(nil, nil) }()
+
 	return m.tableOptions
 }
 
 func (m *manager) SetTableEngineOptions(tableOptions *pokertable.TableEngineOptions) {
+	call := m.beginAudit("", AuditActor_System, "SetTableEngineOptions", auditParams{"table_options": tableOptions})
+	defer func() { call.end(nil, nil) }()
+
 	m.tableOptions = tableOptions
 }
 
-func (m *manager) UpdateTable(competitionID string, table *pokertable.Table) error {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+func (m *manager) UpdateTable(competitionID string, table *pokertable.Table) (err error) {
+	call := m.beginAudit(competitionID, AuditActor_System, "UpdateTable", auditTableParams(table))
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return ErrManagerCompetitionNotFound
 	}
 
-	competitionEngine.UpdateTable(table)
+	competitionEngine.updateTableContext(call.context(), table)
 	return nil
 }
 
-func (m *manager) UpdateReserveTablePlayerState(competitionID, tableID string, playerState *pokertable.TablePlayerState) error {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+func (m *manager) UpdateReserveTablePlayerState(competitionID, tableID string, playerState *pokertable.TablePlayerState) (err error) {
+	call := m.beginAudit(competitionID, AuditActor_System, "UpdateReserveTablePlayerState", auditParams{"table_id": tableID, "player_state": playerState})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return ErrManagerCompetitionNotFound
 	}
@@ -162,64 +394,216 @@ func (m *manager) UpdateReserveTablePlayerState(competitionID, tableID string, p
 	return nil
 }
 
-func (m *manager) AutoGameOpenEnd(competitionID, tableID string) error {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+func (m *manager) AutoGameOpenEnd(competitionID, tableID string) (err error) {
+	call := m.beginAudit(competitionID, AuditActor_System, "AutoGameOpenEnd", auditParams{"table_id": tableID})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
+	if err != nil {
+		return ErrManagerCompetitionNotFound
+	}
+
+	return competitionEngine.autoGameOpenEndContext(call.context(), tableID)
+}
+
+func (m *manager) ReadyFirstTableGame(competitionID, tableID string, gameCount int, players []*pokertable.TablePlayerState) (err error) {
+	call := m.beginAudit(competitionID, AuditActor_System, "ReadyFirstTableGame", auditParams{"table_id": tableID, "game_count": gameCount, "players": players})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
+	if err != nil {
+		return ErrManagerCompetitionNotFound
+	}
+	return competitionEngine.readyFirstTableGameContext(call.context(), tableID, gameCount, players)
+}
+
+func (m *manager) PlayerBuyIn(competitionID string, joinPlayer JoinPlayer) (err error) {
+	call := m.beginAudit(competitionID, joinPlayer.PlayerID, "PlayerBuyIn", auditParams{"join_player": joinPlayer})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
+	if err != nil {
+		return ErrManagerCompetitionNotFound
+	}
+
+	return competitionEngine.playerBuyInContext(call.context(), joinPlayer)
+}
+
+func (m *manager) PlayerAddon(competitionID string, tableID string, joinPlayer JoinPlayer) (err error) {
+	call := m.beginAudit(competitionID, joinPlayer.PlayerID, "PlayerAddon", auditParams{"table_id": tableID, "join_player": joinPlayer})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return ErrManagerCompetitionNotFound
 	}
 
-	return competitionEngine.AutoGameOpenEnd(tableID)
+	return competitionEngine.playerAddonContext(call.context(), tableID, joinPlayer)
 }
 
-func (m *manager) ReadyFirstTableGame(competitionID, tableID string, gameCount int, players []*pokertable.TablePlayerState) error {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+func (m *manager) PlayerRefund(competitionID string, playerID string) (err error) {
+	call := m.beginAudit(competitionID, playerID, "PlayerRefund", auditParams{"player_id": playerID})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return ErrManagerCompetitionNotFound
 	}
-	return competitionEngine.ReadyFirstTableGame(tableID, gameCount, players)
+
+	return competitionEngine.playerRefundContext(call.context(), playerID)
 }
 
-func (m *manager) PlayerBuyIn(competitionID string, joinPlayer JoinPlayer) error {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+func (m *manager) PlayerCashOut(competitionID string, tableID, playerID string) (err error) {
+	call := m.beginAudit(competitionID, playerID, "PlayerCashOut", auditParams{"table_id": tableID, "player_id": playerID})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return ErrManagerCompetitionNotFound
 	}
 
-	return competitionEngine.PlayerBuyIn(joinPlayer)
+	return competitionEngine.playerCashOutContext(call.context(), tableID, playerID)
 }
 
-func (m *manager) PlayerAddon(competitionID string, tableID string, joinPlayer JoinPlayer) error {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+func (m *manager) PlayerQuit(competitionID string, tableID, playerID string) (err error) {
+	call := m.beginAudit(competitionID, playerID, "PlayerQuit", auditParams{"table_id": tableID, "player_id": playerID})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return ErrManagerCompetitionNotFound
 	}
 
-	return competitionEngine.PlayerAddon(tableID, joinPlayer)
+	return competitionEngine.playerQuitContext(call.context(), tableID, playerID)
+}
+
+func (m *manager) GetAlternatePosition(competitionID, playerID string) (position int, err error) {
+	call := m.beginAudit(competitionID, playerID, "GetAlternatePosition", auditParams{"player_id": playerID})
+	defer func() { call.end(auditParams{"position": position}, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
+	if err != nil {
+		return UnsetValue, ErrManagerCompetitionNotFound
+	}
+
+	return competitionEngine.GetAlternatePosition(playerID)
 }
 
-func (m *manager) PlayerRefund(competitionID string, playerID string) error {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+func (m *manager) SetTableFeatured(competitionID, tableID string, isFeatured bool) (err error) {
+	call := m.beginAudit(competitionID, AuditActor_System, "SetTableFeatured", auditParams{"table_id": tableID, "is_featured": isFeatured})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return ErrManagerCompetitionNotFound
 	}
 
-	return competitionEngine.PlayerRefund(playerID)
+	return competitionEngine.SetTableFeatured(tableID, isFeatured)
 }
 
-func (m *manager) PlayerCashOut(competitionID string, tableID, playerID string) error {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+func (m *manager) MovePlayerToFeaturedTable(competitionID, actorID, playerID, tableID string) (err error) {
+	call := m.beginAudit(competitionID, actorID, "MovePlayerToFeaturedTable", auditParams{"player_id": playerID, "table_id": tableID})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return ErrManagerCompetitionNotFound
 	}
 
-	return competitionEngine.PlayerCashOut(tableID, playerID)
+	return competitionEngine.MovePlayerToFeaturedTable(playerID, tableID)
 }
 
-func (m *manager) PlayerQuit(competitionID string, tableID, playerID string) error {
-	competitionEngine, err := m.GetCompetitionEngine(competitionID)
+func (m *manager) DisqualifyPlayer(competitionID, directorID, playerID, reason string) (err error) {
+	call := m.beginAudit(competitionID, directorID, "DisqualifyPlayer", auditParams{"player_id": playerID, "reason": reason})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
 	if err != nil {
 		return ErrManagerCompetitionNotFound
 	}
 
-	return competitionEngine.PlayerQuit(tableID, playerID)
+	return competitionEngine.disqualifyPlayerContext(call.context(), directorID, playerID, reason)
+}
+
+func (m *manager) PenalizePlayer(competitionID, directorID, playerID string, orbits, seconds int, reason string) (err error) {
+	call := m.beginAudit(competitionID, directorID, "PenalizePlayer", auditParams{"player_id": playerID, "orbits": orbits, "seconds": seconds, "reason": reason})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
+	if err != nil {
+		return ErrManagerCompetitionNotFound
+	}
+
+	return competitionEngine.PenalizePlayer(directorID, playerID, orbits, seconds, reason)
+}
+
+func (m *manager) AdjustPlayerChips(competitionID, directorID, playerID string, chips int64, reason string) (err error) {
+	call := m.beginAudit(competitionID, directorID, "AdjustPlayerChips", auditParams{"player_id": playerID, "chips": chips, "reason": reason})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
+	if err != nil {
+		return ErrManagerCompetitionNotFound
+	}
+
+	return competitionEngine.adjustPlayerChipsContext(call.context(), directorID, playerID, chips, reason)
+}
+
+func (m *manager) MovePlayer(competitionID, directorID, playerID, tableID string, seat int, reason string) (err error) {
+	call := m.beginAudit(competitionID, directorID, "MovePlayer", auditParams{"player_id": playerID, "table_id": tableID, "seat": seat, "reason": reason})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
+	if err != nil {
+		return ErrManagerCompetitionNotFound
+	}
+
+	return competitionEngine.MovePlayer(directorID, playerID, tableID, seat, reason)
+}
+
+func (m *manager) SubscribeEvents(competitionID, actorID string, handler func(event Event), options SubscribeOptions) (subscription *Subscription, err error) {
+	call := m.beginAudit(competitionID, actorID, "SubscribeEvents", auditParams{"queue_size": options.QueueSize, "overflow_policy": options.OverflowPolicy})
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
+	if err != nil {
+		return nil, ErrManagerCompetitionNotFound
+	}
+
+	return competitionEngine.SubscribeEvents(handler, options), nil
+}
+
+func (m *manager) RequestDeltaSnapshot(competitionID string) (err error) {
+	call := m.beginAudit(competitionID, AuditActor_System, "RequestDeltaSnapshot", nil)
+	defer func() { call.end(nil, err) }()
+
+	competitionEngine, err := m.loadCompetitionEngine(competitionID)
+	if err != nil {
+		return ErrManagerCompetitionNotFound
+	}
+
+	competitionEngine.RequestDeltaSnapshot()
+	return nil
+}
+
+// auditCompetitionResult 建立賽事的稽核結果 (只記錄賽事 ID)
+func auditCompetitionResult(competition *Competition) interface{} {
+	if competition == nil {
+		return nil
+	}
+	return auditParams{"competition_id": competition.ID}
+}
+
+// auditTableParams 桌次更新的稽核參數 (不記錄完整桌次)
+func auditTableParams(table *pokertable.Table) auditParams {
+	if table == nil {
+		return nil
+	}
+
+	params := auditParams{"table_id": table.ID, "update_serial": table.UpdateSerial}
+	if table.State != nil {
+		params["status"] = table.State.Status
+		params["game_count"] = table.State.GameCount
+	}
+	return params
 }
@@ -2,10 +2,18 @@ package pokercompetition
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/weedbox/pokertable"
 )
 
+var (
+	ErrTableRuleSeatingMismatch         = errors.New("table manager backend: rule requires a different seat manager")
+	ErrTableRuleUnsupported             = errors.New("table manager backend: rule rotation requires a table manager created by NewTableManager")
+	ErrTableAnteModeUnsupported         = errors.New("table manager backend: ante mode requires a table manager created by NewTableManager")
+	ErrTableBettingStructureUnsupported = errors.New("table manager backend: betting structure requires a table manager created by NewTableManager")
+)
+
 type TableManagerBackend interface {
 	// Events
 	OnTableUpdated(fn func(table *pokertable.Table))
@@ -19,6 +27,9 @@ type TableManagerBackend interface {
 	StartTableGame(tableID string) error
 	SetUpTableGame(tableID string, gameCount int, participants map[string]int) error
 	UpdateBlind(tableID string, level int, ante, dealer, sb, bb int64) error
+	UpdateTableRule(tableID string, rule string) error
+	UpdateTableAnteMode(tableID string, mode CompetitionAnteMode) error
+	UpdateTableBettingStructure(tableID string, structure CompetitionBettingStructure) error
 	UpdateTablePlayers(tableID string, joinPlayers []pokertable.JoinPlayer, leavePlayerIDs []string) (map[string]int, error)
 
 	// TableManager Player Table Actions
@@ -26,13 +37,18 @@ type TableManagerBackend interface {
 	PlayerJoin(tableID, playerID string) error // TODO: test only, should remove this
 	PlayerRedeemChips(tableID string, joinPlayer pokertable.JoinPlayer) error
 	PlayersLeave(tableID string, playerIDs []string) error
+	PlayerFold(tableID, playerID string) error
 
 	// Others
 	UpdateTable(table *pokertable.Table) // TODO: test only, should remove this
 	ReleaseTable(tableID string) error
 }
 
-func NewNativeTableManagerBackend(manager pokertable.Manager) TableManagerBackend {
+/*
+NewNativeTableManagerBackend 建立直接操作桌次引擎的 TableManagerBackend
+  - manager 可為 pokertable.NewManager() 或 NewTableManager() (支援前注模式)
+*/
+func NewNativeTableManagerBackend(manager TableEngineManager) TableManagerBackend {
 	backend := nativeTableManagerBackend{
 		manager:                   manager,
 		onTableUpdated:            func(t *pokertable.Table) {},
@@ -43,7 +59,7 @@ func NewNativeTableManagerBackend(manager pokertable.Manager) TableManagerBacken
 }
 
 type nativeTableManagerBackend struct {
-	manager                   pokertable.Manager
+	manager                   TableEngineManager
 	onTableUpdated            func(table *pokertable.Table)
 	onTablePlayerReserved     func(tableID string, playerState *pokertable.TablePlayerState)
 	onReadyOpenFirstTableGame func(tableID string, ganeCoubt int, players []*pokertable.TablePlayerState)
@@ -101,7 +117,11 @@ func (ntmb *nativeTableManagerBackend) CreateTable(options *pokertable.TableEngi
 }
 
 func (ntbm *nativeTableManagerBackend) PauseTable(tableID string) error {
-	return ntbm.manager.PauseTable(tableID)
+	tableEngine, err := ntbm.manager.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+	return tableEngine.PauseTable()
 }
 
 func (ntmb *nativeTableManagerBackend) CloseTable(tableID string) error {
@@ -109,35 +129,97 @@ func (ntmb *nativeTableManagerBackend) CloseTable(tableID string) error {
 }
 
 func (ntbm *nativeTableManagerBackend) StartTableGame(tableID string) error {
-	return ntbm.manager.StartTableGame(tableID)
+	tableEngine, err := ntbm.manager.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+	return tableEngine.StartTableGame()
 }
 
 func (ntbm *nativeTableManagerBackend) SetUpTableGame(tableID string, gameCount int, participants map[string]int) error {
-	return ntbm.manager.SetUpTableGame(tableID, gameCount, participants)
+	tableEngine, err := ntbm.manager.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+	tableEngine.SetUpTableGame(gameCount, participants)
+	return nil
 }
 
 func (ntbm *nativeTableManagerBackend) UpdateBlind(tableID string, level int, ante, dealer, sb, bb int64) error {
-	return ntbm.manager.UpdateBlind(tableID, level, ante, dealer, sb, bb)
+	tableEngine, err := ntbm.manager.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+	tableEngine.UpdateBlind(level, ante, dealer, sb, bb)
+	return nil
+}
+
+/*
+UpdateTableRule 更新桌次德州撲克規則
+  - 適用時機: 兩手之間 (該桌結算後、下一手開局前，或暫停中的桌次恢復開局前)，規則於下一手開局時套用
+  - 規則輪替需要使用 NewTableManager 建立的桌次管理
+*/
+func (ntbm *nativeTableManagerBackend) UpdateTableRule(tableID string, rule string) error {
+	manager, ok := ntbm.manager.(TableManager)
+	if !ok {
+		tableEngine, err := ntbm.manager.GetTableEngine(tableID)
+		if err != nil {
+			return err
+		}
+		if tableEngine.GetTable().Meta.Rule == rule {
+			return nil
+		}
+		return ErrTableRuleUnsupported
+	}
+	return manager.SetTableRule(tableID, rule)
 }
 
 func (ntbm *nativeTableManagerBackend) UpdateTablePlayers(tableID string, joinPlayers []pokertable.JoinPlayer, leavePlayerIDs []string) (map[string]int, error) {
-	return ntbm.manager.UpdateTablePlayers(tableID, joinPlayers, leavePlayerIDs)
+	tableEngine, err := ntbm.manager.GetTableEngine(tableID)
+	if err != nil {
+		return nil, err
+	}
+	return tableEngine.UpdateTablePlayers(joinPlayers, leavePlayerIDs)
 }
 
 func (ntbm *nativeTableManagerBackend) PlayerReserve(tableID string, joinPlayer pokertable.JoinPlayer) error {
-	return ntbm.manager.PlayerReserve(tableID, joinPlayer)
+	tableEngine, err := ntbm.manager.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+	return tableEngine.PlayerReserve(joinPlayer)
 }
 
 func (ntbm *nativeTableManagerBackend) PlayerJoin(tableID, playerID string) error {
-	return ntbm.manager.PlayerJoin(tableID, playerID)
+	tableEngine, err := ntbm.manager.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+	return tableEngine.PlayerJoin(playerID)
 }
 
 func (ntmb *nativeTableManagerBackend) PlayerRedeemChips(tableID string, joinPlayer pokertable.JoinPlayer) error {
-	return ntmb.manager.PlayerRedeemChips(tableID, joinPlayer)
+	tableEngine, err := ntmb.manager.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+	return tableEngine.PlayerRedeemChips(joinPlayer)
 }
 
 func (ntmb *nativeTableManagerBackend) PlayersLeave(tableID string, playerIDs []string) error {
-	return ntmb.manager.PlayersLeave(tableID, playerIDs)
+	tableEngine, err := ntmb.manager.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+	return tableEngine.PlayersLeave(playerIDs)
+}
+
+func (ntmb *nativeTableManagerBackend) PlayerFold(tableID, playerID string) error {
+	tableEngine, err := ntmb.manager.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+	return tableEngine.PlayerFold(playerID)
 }
 
 func (ntmb *nativeTableManagerBackend) UpdateTable(table *pokertable.Table) {
@@ -147,3 +229,39 @@ func (ntmb *nativeTableManagerBackend) UpdateTable(table *pokertable.Table) {
 func (ntmb *nativeTableManagerBackend) ReleaseTable(tableID string) error {
 	return ntmb.manager.ReleaseTable(tableID)
 }
+
+/*
+UpdateTableAnteMode 更新桌次前注模式
+  - 大盲前注、按鈕前注需要使用 NewTableManager 建立的桌次管理
+*/
+func (ntbm *nativeTableManagerBackend) UpdateTableAnteMode(tableID string, mode CompetitionAnteMode) error {
+	manager, ok := ntbm.manager.(TableManager)
+	if !ok {
+		if mode == "" || mode == CompetitionAnteMode_Classic {
+			return nil
+		}
+		return ErrTableAnteModeUnsupported
+	}
+	return manager.SetTableAnteMode(tableID, mode)
+}
+
+/*
+UpdateTableBettingStructure 更新桌次下注結構
+  - 適用時機: 兩手之間，下一次接受下注時生效
+  - 底池限注、固定限注需要使用 NewTableManager 建立的桌次管理 (pokertable 只支援無限注)
+*/
+func (ntbm *nativeTableManagerBackend) UpdateTableBettingStructure(tableID string, structure CompetitionBettingStructure) error {
+	manager, ok := ntbm.manager.(TableManager)
+	if !ok {
+		if structure == "" || structure == CompetitionBettingStructure_NoLimit {
+			return nil
+		}
+		return ErrTableBettingStructureUnsupported
+	}
+	return manager.SetTableBettingStructure(tableID, structure)
+}
+
+// isRuleSeatingSupported 建桌規則的座位管理是否可進行指定規則 (短牌座位沒有大小盲位置)
+func isRuleSeatingSupported(seatingRule, rule string) bool {
+	return seatingRule != pokertable.CompetitionRule_ShortDeck || rule == pokertable.CompetitionRule_ShortDeck
+}
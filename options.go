@@ -8,6 +8,12 @@ type CompetitionEngineOptions struct {
 	OnCompetitionStateUpdated           func(event string, competition *Competition)
 	OnAdvancePlayerCountUpdated         func(competitionID string, totalBuyInCount int) int
 	OnCompetitionPlayerCashOut          func(competitionID string, competitionPlayer *CompetitionPlayer)
+	OnCompetitionPlayerMoved            func(moved PlayerMoved)
+	OnAdvancementResultCreated          func(result *AdvancementResult)
+	OnCompetitionAddonOffered           func(offer AddonOffer)
+	OnEvent                             func(event Event)
+	OnCompetitionDeltaUpdated           func(delta CompetitionDelta) // 設定時發送 JSON Patch 增量更新
+	DeltaSnapshotInterval               int                          // 增量更新每 N 次發送一次完整快照 (0 表示使用預設值)
 }
 
 func NewDefaultCompetitionEngineOptions() *CompetitionEngineOptions {
@@ -19,5 +25,9 @@ func NewDefaultCompetitionEngineOptions() *CompetitionEngineOptions {
 		OnCompetitionStateUpdated:           func(event string, competition *Competition) {},
 		OnAdvancePlayerCountUpdated:         func(competitionID string, totalBuyInCount int) int { return 0 },
 		OnCompetitionPlayerCashOut:          func(competitionID string, competitionPlayer *CompetitionPlayer) {},
+		OnCompetitionPlayerMoved:            func(moved PlayerMoved) {},
+		OnAdvancementResultCreated:          func(result *AdvancementResult) {},
+		OnCompetitionAddonOffered:           func(offer AddonOffer) {},
+		OnEvent:                             func(event Event) {},
 	}
 }
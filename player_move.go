@@ -0,0 +1,69 @@
+package pokercompetition
+
+import (
+	"time"
+)
+
+type PlayerMoveReason string
+
+const (
+	PlayerMoveReason_Balancing     PlayerMoveReason = "balancing"      // 平衡各桌人數
+	PlayerMoveReason_TableBreak    PlayerMoveReason = "table_break"    // 拆桌
+	PlayerMoveReason_FeaturedTable PlayerMoveReason = "featured_table" // 移至焦點桌
+	PlayerMoveReason_Director      PlayerMoveReason = "director"       // 裁判指定換桌
+)
+
+// playerMove 下一手前要執行的玩家換桌
+type playerMove struct {
+	tableID string           // 目標桌次 ID
+	seat    int              // 指定座位 (UnsetValue 表示依座位限制安排)
+	reason  PlayerMoveReason // 移動原因
+}
+
+type PlayerMoved struct {
+	CompetitionID string           `json:"competition_id"` // 賽事 ID
+	PlayerID      string           `json:"player_id"`      // 玩家 ID
+	FromTable     string           `json:"from_table"`     // 原桌次 ID
+	FromSeat      int              `json:"from_seat"`      // 原座位
+	ToTable       string           `json:"to_table"`       // 新桌次 ID
+	ToSeat        int              `json:"to_seat"`        // 新座位
+	Reason        PlayerMoveReason `json:"reason"`         // 移動原因
+	MovedAt       int64            `json:"moved_at"`       // 入座新桌次時間 (Seconds)
+}
+
+/*
+startPlayerMove 記錄玩家離開原桌次 (等待入座新桌次)
+  - 適用時機: 拆併桌監管器移出玩家至等待區
+*/
+func (ce *competitionEngine) startPlayerMove(player *CompetitionPlayer, reason PlayerMoveReason) {
+	ce.movingPlayers.Store(player.PlayerID, &PlayerMoved{
+		CompetitionID: ce.competition.ID,
+		PlayerID:      player.PlayerID,
+		FromTable:     player.CurrentTableID,
+		FromSeat:      player.CurrentSeat,
+		ToTable:       "",
+		ToSeat:        UnsetValue,
+		Reason:        reason,
+	})
+}
+
+/*
+commitPlayerMove 玩家入座新桌次後發出 PlayerMoved 事件
+  - 非移動中的玩家 (ex: 報名、補碼後入座) 不發出事件
+*/
+func (ce *competitionEngine) commitPlayerMove(player *CompetitionPlayer) {
+	value, ok := ce.movingPlayers.LoadAndDelete(player.PlayerID)
+	if !ok {
+		return
+	}
+
+	moved := value.(*PlayerMoved)
+	moved.ToTable = player.CurrentTableID
+	moved.ToSeat = player.CurrentSeat
+	moved.MovedAt = time.Now().Unix()
+	ce.emitPlayerMovedEvent(*moved)
+}
+
+func (ce *competitionEngine) emitPlayerMovedEvent(moved PlayerMoved) {
+	ce.emitTypedEvent(EventType_PlayerMoved, moved)
+}
@@ -88,7 +88,7 @@ func (ce *competitionEngine) GetSortedTableSettlementKnockoutPlayerRankings(tabl
 		isAlreadyKnockout := false
 		if playerIdx, exist := playerIndexMap[p.PlayerID]; exist {
 			player := ce.competition.State.Players[playerIdx]
-			allowToReBuy = player.ReBuyTimes < ce.competition.Meta.ReBuySetting.MaxTime
+			allowToReBuy = player.ReBuyTimes < ce.competition.Meta.ReBuySetting.MaxTime && !player.IsForfeited
 			isAlreadyKnockout = player.Status == CompetitionPlayerStatus_Knockout
 		}
 		if !ce.competition.State.BlindState.IsStopBuyIn() && allowToReBuy {
@@ -143,6 +143,38 @@ func (ce *competitionEngine) GetSortedStopBuyInKnockoutPlayerRankings() []string
 	return playerIDs
 }
 
+/*
+GetSortedReBuyExpiredKnockoutPlayerRankings 買入期間補碼逾時淘汰、尚未記錄排名的玩家排名 (越早淘汰者排名越後面，且 index 越小)
+  - 同時淘汰時越晚加入者排名越後面
+  - @return SortedKnockoutPlayerIDs 排序過後的淘汰玩家 ID 陣列
+*/
+func (ce *competitionEngine) GetSortedReBuyExpiredKnockoutPlayerRankings() []string {
+	rankedPlayerIDs := make(map[string]bool)
+	for _, rank := range ce.competition.State.Rankings {
+		rankedPlayerIDs[rank.PlayerID] = true
+	}
+
+	sortedKnockoutPlayers := make([]CompetitionPlayer, 0)
+	for _, p := range ce.competition.State.Players {
+		if p.Status == CompetitionPlayerStatus_Knockout && !rankedPlayerIDs[p.PlayerID] {
+			sortedKnockoutPlayers = append(sortedKnockoutPlayers, *p)
+		}
+	}
+
+	sort.Slice(sortedKnockoutPlayers, func(i int, j int) bool {
+		if sortedKnockoutPlayers[i].KnockoutAt == sortedKnockoutPlayers[j].KnockoutAt {
+			return sortedKnockoutPlayers[i].JoinAt > sortedKnockoutPlayers[j].JoinAt
+		}
+		return sortedKnockoutPlayers[i].KnockoutAt < sortedKnockoutPlayers[j].KnockoutAt
+	})
+
+	playerIDs := make([]string, 0)
+	for _, p := range sortedKnockoutPlayers {
+		playerIDs = append(playerIDs, p.PlayerID)
+	}
+	return playerIDs
+}
+
 /*
 GetParticipatedPlayerCompetitionRankingData 計算賽事所有沒有被淘汰玩家最終排名
 - Algorithm:
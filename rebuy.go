@@ -0,0 +1,167 @@
+package pokercompetition
+
+import (
+	"context"
+	"fmt"
+)
+
+type ReBuyRule string
+
+const (
+	ReBuyRule_Bust           ReBuyRule = "bust"              // 沒有籌碼才能補碼 (預設)
+	ReBuyRule_AtOrBelowStack ReBuyRule = "at_or_below_stack" // 籌碼小於等於門檻 (通常為起始籌碼) 即可補碼
+)
+
+// buyInUnits 玩家報名或補碼的買入發數 (未指定時為 1 發)，於 PlayerBuyIn 統一計算
+func buyInUnits(unit int) int {
+	if unit <= 0 {
+		return 1
+	}
+	return unit
+}
+
+/*
+validateReBuyUnits 驗證補碼發數
+  - 單次補碼發數不可超過 MaxUnitsPerReBuy (0 表示不限制)
+  - 補碼次數以次數累計，不論單次補碼發數 (ex: double re-buy 計一次)
+*/
+func (ce *competitionEngine) validateReBuyUnits(cp *CompetitionPlayer, units int) error {
+	maxUnits := ce.competition.Meta.ReBuySetting.MaxUnitsPerReBuy
+	if maxUnits > 0 && units > maxUnits {
+		return ErrCompetitionExceedReBuyUnitLimit
+	}
+
+	if cp.ReBuyTimes >= ce.competition.Meta.ReBuySetting.MaxTime {
+		return ErrCompetitionExceedReBuyLimit
+	}
+	return nil
+}
+
+/*
+playerTopUpReBuy 玩家籌碼未歸零時補碼
+  - 適用條件: 補碼規則為 at_or_below_stack 且籌碼小於等於門檻
+  - 牌局進行中時等到該手結算後 (兩手之間) 才加入籌碼
+*/
+func (ce *competitionEngine) playerTopUpReBuy(ctx context.Context, cp *CompetitionPlayer, joinPlayer JoinPlayer) error {
+	setting := ce.competition.Meta.ReBuySetting
+	if setting.Rule != ReBuyRule_AtOrBelowStack || cp.Chips > setting.StackThreshold {
+		return ErrCompetitionReBuyRejected
+	}
+
+	if cp.Status != CompetitionPlayerStatus_Playing && cp.Status != CompetitionPlayerStatus_WaitingTableBalancing {
+		return ErrCompetitionReBuyRejected
+	}
+
+	if err := ce.validateReBuyUnits(cp, joinPlayer.Unit); err != nil {
+		return err
+	}
+
+	ce.mu.Lock()
+	if _, exist := ce.pendingReBuys[cp.PlayerID]; exist {
+		ce.mu.Unlock()
+		return ErrCompetitionReBuyRejected
+	}
+	ce.pendingReBuys[cp.PlayerID] = joinPlayer
+	ce.mu.Unlock()
+
+	ce.emitEvent(fmt.Sprintf("PlayerBuyIn -> %s Re Buy Pending", joinPlayer.PlayerID), joinPlayer.PlayerID)
+
+	// 桌次沒有進行中的牌局時直接加入籌碼
+	ce.applyPendingReBuy(ctx, cp.PlayerID)
+	return nil
+}
+
+/*
+handlePendingReBuys 加入本桌玩家等待中的補碼籌碼
+  - 適用時機: 每手結算後 (兩手之間)
+*/
+func (ce *competitionEngine) handlePendingReBuys(ctx context.Context, tableID string) {
+	ce.mu.RLock()
+	playerIDs := make([]string, 0)
+	for playerID := range ce.pendingReBuys {
+		if cp := ce.competition.findPlayer(playerID); cp != nil && cp.CurrentTableID == tableID {
+			playerIDs = append(playerIDs, playerID)
+		}
+	}
+	ce.mu.RUnlock()
+
+	for _, playerID := range playerIDs {
+		ce.applyPendingReBuy(ctx, playerID)
+	}
+}
+
+/*
+applyPendingReBuy 加入玩家補碼籌碼並更新補碼次數與買入發數
+  - 玩家在牌局進行中的桌次時保留等待，該手結算後再處理
+  - 玩家已沒有籌碼 (該手被淘汰) 時取消，改走一般補碼流程
+  - 桌次加入籌碼失敗時還原籌碼、補碼次數與買入發數
+*/
+func (ce *competitionEngine) applyPendingReBuy(ctx context.Context, playerID string) {
+	ce.mu.Lock()
+	joinPlayer, exist := ce.pendingReBuys[playerID]
+	if !exist {
+		ce.mu.Unlock()
+		return
+	}
+
+	cp := ce.competition.findPlayer(playerID)
+	if cp == nil || cp.Chips <= 0 {
+		delete(ce.pendingReBuys, playerID)
+		ce.mu.Unlock()
+		ce.emitErrorEvent("Apply Pending Re Buy", playerID, ErrCompetitionReBuyRejected)
+		return
+	}
+
+	// 玩家在桌次內: 牌局進行中等到該手結算後再處理
+	tableID := ""
+	if cp.Status == CompetitionPlayerStatus_Playing {
+		tableID = cp.CurrentTableID
+		if ce.isTableGameInProgress(tableID) {
+			ce.mu.Unlock()
+			return
+		}
+	}
+	delete(ce.pendingReBuys, playerID)
+	ce.mu.Unlock()
+
+	units := joinPlayer.Unit
+	isModeCTorMTT := ce.competition.IsModeCTorMTT()
+	err := ce.redeemChips(ctx, chipRedemption{
+		playerID: playerID,
+		tableID:  tableID,
+		chips:    joinPlayer.RedeemChips,
+		apply: func(cp *CompetitionPlayer) {
+			cp.ReBuyTimes++
+			cp.ReBuyUnits += units
+			cp.TotalRedeemChips += joinPlayer.RedeemChips
+			if isModeCTorMTT {
+				ce.competition.State.Statistic.TotalBuyInCount += units
+				cp.TotalBuyInUnits += units
+			}
+		},
+		revert: func(cp *CompetitionPlayer) {
+			cp.ReBuyTimes--
+			cp.ReBuyUnits -= units
+			cp.TotalRedeemChips -= joinPlayer.RedeemChips
+			if isModeCTorMTT {
+				ce.competition.State.Statistic.TotalBuyInCount -= units
+				cp.TotalBuyInUnits -= units
+			}
+		},
+	})
+	if err != nil {
+		ce.emitErrorEvent("Apply Pending Re Buy -> PlayerRedeemChips", playerID, err)
+		return
+	}
+
+	ce.emitEvent(fmt.Sprintf("PlayerBuyIn -> %s Re Buy (%d units)", playerID, units), playerID)
+	ce.emitPlayerEvent("PlayerBuyIn -> Re Buy", cp)
+	ce.emitCompetitionStateEvent(CompetitionStateEvent_CompetitionStatisticUpdated)
+	ce.emitTypedEvent(EventType_PlayerReBought, PlayerReBoughtPayload{
+		PlayerID:    playerID,
+		RedeemChips: joinPlayer.RedeemChips,
+		Units:       units,
+		ReBuyTimes:  cp.ReBuyTimes,
+		Chips:       cp.Chips,
+	})
+}
@@ -0,0 +1,162 @@
+package pokercompetition
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+newReBuyTestEngine 建立買入期間的 MTT 賽事
+  - p1 仍有籌碼，p2、p3 等待補碼中 (補碼期限已過)
+*/
+func newReBuyTestEngine() *competitionEngine {
+	expiredAt := time.Now().Add(-time.Minute).Unix()
+	p1 := newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_Playing)
+	p1.JoinAt = 1
+	players := []*CompetitionPlayer{p1}
+	for idx, playerID := range []string{"p2", "p3"} {
+		cp := newTestCompetitionPlayer(playerID, 0, CompetitionPlayerStatus_ReBuyWaiting)
+		cp.JoinAt = int64(idx + 2)
+		cp.IsReBuying = true
+		cp.ReBuyEndAt = expiredAt
+		players = append(players, cp)
+	}
+
+	ce := newTestCompetitionEngine(players...)
+	ce.competition.State.Status = CompetitionStateStatus_DelayedBuyIn
+	return ce
+}
+
+func Test_ReBuy_ExpiredKnockoutDefersRanks(t *testing.T) {
+	ce := newReBuyTestEngine()
+	ranks := make(map[string]int)
+	ce.OnCompetitionFinalPlayerRankUpdated(func(competitionID, playerID string, rank int) {
+		ranks[playerID] = rank
+	})
+	ce.competition.findPlayer("p3").ReBuyEndAt = time.Now().Add(time.Minute).Unix()
+
+	ce.knockoutReBuyExpiredPlayers([]string{"p2", "p3"})
+
+	p2 := ce.competition.findPlayer("p2")
+	assert.Equal(t, CompetitionPlayerStatus_Knockout, p2.Status)
+	assert.False(t, p2.IsReBuying)
+	assert.Equal(t, UnsetValue, int(p2.ReBuyEndAt))
+	assert.Equal(t, CompetitionPlayerStatus_ReBuyWaiting, ce.competition.findPlayer("p3").Status, "player within the re-buy window should keep waiting")
+
+	// 買入期間參賽人數仍會增加，不記錄排名
+	assert.Empty(t, ce.competition.State.Rankings)
+	assert.Empty(t, ranks)
+
+	// 停止買入時才記錄排名: 排在仍可補碼的玩家之後
+	ce.competition.State.Status = CompetitionStateStatus_StoppedBuyIn
+	ce.rankReBuyExpiredPlayers()
+	assert.Equal(t, map[string]int{"p2": 3}, ranks)
+	if assert.Len(t, ce.competition.State.Rankings, 1) {
+		assert.Equal(t, "p2", ce.competition.State.Rankings[0].PlayerID)
+	}
+
+	// 已記錄排名的玩家不重複記錄
+	ce.rankReBuyExpiredPlayers()
+	assert.Len(t, ce.competition.State.Rankings, 1)
+}
+
+func Test_ReBuy_ExpiredKnockoutRankOrder(t *testing.T) {
+	ce := newReBuyTestEngine()
+	ce.knockoutReBuyExpiredPlayers([]string{"p2", "p3"})
+
+	// 越早淘汰排名越後面，同時淘汰時越晚加入排名越後面
+	ce.competition.findPlayer("p2").KnockoutAt--
+	assert.Equal(t, []string{"p2", "p3"}, ce.GetSortedReBuyExpiredKnockoutPlayerRankings())
+
+	ce.competition.findPlayer("p2").KnockoutAt++
+	assert.Equal(t, []string{"p3", "p2"}, ce.GetSortedReBuyExpiredKnockoutPlayerRankings())
+
+	// 賽事結算時一併記錄排名
+	ce.updatePlayerFinalRankings()
+	assert.Equal(t, 1, ce.competition.findPlayer("p1").CompetitionRank)
+	assert.Equal(t, 2, ce.competition.findPlayer("p2").CompetitionRank)
+	assert.Equal(t, 3, ce.competition.findPlayer("p3").CompetitionRank)
+}
+
+func Test_ReBuy_ExpiredKnockoutEmitsAfterUnlock(t *testing.T) {
+	ce := newReBuyTestEngine()
+	done := make(chan struct{})
+	ce.OnCompetitionStateUpdated(func(event string, competition *Competition) {
+		if event != CompetitionStateEvent_KnockoutPlayers {
+			return
+		}
+		// 監聽者呼叫需要鎖的方法不會死結
+		ce.mu.Lock()
+		ce.mu.Unlock()
+		close(done)
+	})
+
+	go ce.knockoutReBuyExpiredPlayers([]string{"p2"})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("knockout event was emitted while holding the engine lock")
+	}
+}
+
+func Test_ReBuy_Units(t *testing.T) {
+	testCases := []struct {
+		name       string
+		unit       int
+		reBuyUnits int
+	}{
+		{name: "unset unit counts as one", unit: 0, reBuyUnits: 1},
+		{name: "single re-buy", unit: 1, reBuyUnits: 1},
+		{name: "double re-buy", unit: 2, reBuyUnits: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ce := newReBuyTestEngine()
+			ce.competition.Meta.ReBuySetting = ReBuySetting{MaxTime: 1, MaxUnitsPerReBuy: 2}
+			ce.competition.findPlayer("p2").ReBuyEndAt = time.Now().Add(time.Minute).Unix()
+
+			assert.NoError(t, ce.PlayerBuyIn(JoinPlayer{PlayerID: "p2", RedeemChips: 1000, Unit: tc.unit}))
+
+			cp := ce.competition.findPlayer("p2")
+			assert.Equal(t, 1, cp.ReBuyTimes, "re-buy times should count times, not units")
+			assert.Equal(t, tc.reBuyUnits, cp.ReBuyUnits)
+			assert.Equal(t, tc.reBuyUnits, cp.TotalBuyInUnits)
+			assert.Equal(t, tc.reBuyUnits, ce.competition.State.Statistic.TotalBuyInCount)
+		})
+	}
+}
+
+func Test_ReBuy_UnitLimits(t *testing.T) {
+	ce := newReBuyTestEngine()
+	ce.competition.Meta.ReBuySetting = ReBuySetting{MaxTime: 1, MaxUnitsPerReBuy: 2}
+	ce.competition.findPlayer("p2").ReBuyEndAt = time.Now().Add(time.Minute).Unix()
+
+	err := ce.PlayerBuyIn(JoinPlayer{PlayerID: "p2", RedeemChips: 3000, Unit: 3})
+	assert.ErrorIs(t, err, ErrCompetitionExceedReBuyUnitLimit)
+
+	// double re-buy 只計一次補碼
+	cp := ce.competition.findPlayer("p2")
+	assert.NoError(t, ce.validateReBuyUnits(cp, 2))
+	cp.ReBuyTimes = 1
+	assert.ErrorIs(t, ce.validateReBuyUnits(cp, 1), ErrCompetitionExceedReBuyLimit)
+}
+
+func Test_ReBuy_BuyInUnits(t *testing.T) {
+	ce := newReBuyTestEngine()
+	ce.competition.State.Status = CompetitionStateStatus_Registering
+
+	// 報名與補碼使用相同的發數計算
+	assert.NoError(t, ce.PlayerBuyIn(JoinPlayer{PlayerID: "p4", RedeemChips: 1000, Unit: 0}))
+	assert.Equal(t, 1, ce.competition.findPlayer("p4").TotalBuyInUnits)
+	assert.Equal(t, 1, ce.competition.State.Statistic.TotalBuyInCount)
+
+	// 晉級玩家不計買入發數
+	assert.NoError(t, ce.playerBuyIn(context.Background(), JoinPlayer{PlayerID: "p5", RedeemChips: 1000, Unit: 0}, true))
+	assert.Equal(t, 0, ce.competition.findPlayer("p5").TotalBuyInUnits)
+	assert.Equal(t, 1, ce.competition.State.Statistic.TotalBuyInCount)
+}
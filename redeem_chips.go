@@ -0,0 +1,99 @@
+package pokercompetition
+
+import (
+	"context"
+	"github.com/weedbox/pokertable"
+)
+
+/*
+chipRedemption 兩手之間要加入玩家的籌碼 (補碼、增購、裁判調整)
+  - tableID: 籌碼加入的桌次 (空字串表示玩家等待拆併桌，入桌時帶入新籌碼)
+  - apply/revert: 籌碼以外要一併更新與還原的玩家資料、統計
+*/
+type chipRedemption struct {
+	playerID string
+	tableID  string
+	chips    int64
+	apply    func(cp *CompetitionPlayer)
+	revert   func(cp *CompetitionPlayer)
+}
+
+// isTableGameInProgress 桌次是否有進行中的牌局 (呼叫前需持有 ce.mu)
+func (ce *competitionEngine) isTableGameInProgress(tableID string) bool {
+	tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
+		return t.ID == tableID
+	})
+	if tableIdx == UnsetValue {
+		return false
+	}
+
+	status := ce.competition.State.Tables[tableIdx].State.Status
+	return status == pokertable.TableStateStatus_TableGameOpened || status == pokertable.TableStateStatus_TableGamePlaying
+}
+
+/*
+redeemChips 將籌碼加入玩家並同步至桌次
+  - 適用時機: 兩手之間 (呼叫端需確認桌次沒有進行中的牌局)
+  - 先更新賽事資料再呼叫 PlayerRedeemChips，失敗時還原賽事資料並回傳錯誤
+*/
+func (ce *competitionEngine) redeemChips(ctx context.Context, r chipRedemption) error {
+	ce.mu.Lock()
+	cp := ce.competition.findPlayer(r.playerID)
+	if cp == nil {
+		ce.mu.Unlock()
+		return ErrCompetitionPlayerNotFound
+	}
+
+	var table *pokertable.Table
+	if r.tableID != "" {
+		tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
+			return t.ID == r.tableID
+		})
+		if tableIdx != UnsetValue {
+			table = ce.competition.State.Tables[tableIdx]
+		}
+	}
+
+	ce.updateRedeemedChips(cp, table, r.chips)
+	if r.apply != nil {
+		r.apply(cp)
+	}
+	ce.refreshPlayerCompetitionRanks()
+	ce.mu.Unlock()
+
+	if table == nil {
+		return nil
+	}
+
+	jp := pokertable.JoinPlayer{
+		PlayerID:    r.playerID,
+		RedeemChips: r.chips,
+		Seat:        pokertable.UnsetValue,
+	}
+	if err := ce.backend(ctx).PlayerRedeemChips(table.ID, jp); err != nil {
+		ce.mu.Lock()
+		ce.updateRedeemedChips(cp, table, -r.chips)
+		if r.revert != nil {
+			r.revert(cp)
+		}
+		ce.refreshPlayerCompetitionRanks()
+		ce.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// updateRedeemedChips 更新玩家賽事籌碼與桌次籌碼 (呼叫前需持有 ce.mu)
+func (ce *competitionEngine) updateRedeemedChips(cp *CompetitionPlayer, table *pokertable.Table, chips int64) {
+	cp.Chips += chips
+	if table == nil {
+		return
+	}
+
+	for _, ps := range table.State.PlayerStates {
+		if ps.PlayerID == cp.PlayerID {
+			ps.Bankroll += chips
+			break
+		}
+	}
+}
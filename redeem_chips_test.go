@@ -0,0 +1,75 @@
+package pokercompetition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokertable"
+)
+
+/*
+newRedeemChipsTestEngine 建立玩家 p1 坐在桌次 t1 的賽事
+  - 桌次只存在賽事資料中，PlayerRedeemChips 一律失敗
+*/
+func newRedeemChipsTestEngine(chips int64, tableStatus pokertable.TableStateStatus) (*competitionEngine, *CompetitionPlayer) {
+	cp := newTestCompetitionPlayer("p1", chips, CompetitionPlayerStatus_Playing)
+	ce := newTestCompetitionEngine(cp)
+	ce.competition.Meta.ReBuySetting = ReBuySetting{MaxTime: 3}
+	ce.tableManagerBackend = NewNativeTableManagerBackend(NewTableManager())
+	addTestTable(ce, "t1", tableStatus, cp)
+	return ce, cp
+}
+
+func Test_RedeemChips_ReBuyRollback(t *testing.T) {
+	ce, cp := newRedeemChipsTestEngine(500, pokertable.TableStateStatus_TableGameStandby)
+	ce.pendingReBuys["p1"] = JoinPlayer{PlayerID: "p1", RedeemChips: 1000, Unit: 2}
+
+	ce.applyPendingReBuy(context.Background(), "p1")
+
+	assert.Equal(t, int64(500), cp.Chips)
+	assert.Equal(t, 0, cp.ReBuyTimes)
+	assert.Equal(t, int64(0), cp.TotalRedeemChips)
+	assert.Equal(t, 0, cp.TotalBuyInUnits)
+	assert.Equal(t, 0, ce.competition.State.Statistic.TotalBuyInCount)
+	assert.Equal(t, int64(500), ce.competition.State.Tables[0].State.PlayerStates[0].Bankroll)
+	assert.NotContains(t, ce.pendingReBuys, "p1")
+}
+
+func Test_RedeemChips_WaitsForHand(t *testing.T) {
+	ce, cp := newRedeemChipsTestEngine(500, pokertable.TableStateStatus_TableGamePlaying)
+	ce.pendingReBuys["p1"] = JoinPlayer{PlayerID: "p1", RedeemChips: 1000, Unit: 1}
+	ce.pendingChipAdjustments["p1"] = 100
+
+	ce.applyPendingReBuy(context.Background(), "p1")
+	ce.applyPendingChipAdjustment(context.Background(), "p1")
+
+	assert.Equal(t, int64(500), cp.Chips)
+	assert.Contains(t, ce.pendingReBuys, "p1")
+	assert.Contains(t, ce.pendingChipAdjustments, "p1")
+}
+
+func Test_RedeemChips_AddonRollback(t *testing.T) {
+	ce, cp := newRedeemChipsTestEngine(500, pokertable.TableStateStatus_TableGameStandby)
+	ce.pendingAddons["p1"] = pendingAddon{tableID: "t1", joinPlayer: JoinPlayer{PlayerID: "p1", RedeemChips: 800, Unit: 1}}
+
+	ce.applyPendingAddon(context.Background(), "p1")
+
+	assert.Equal(t, int64(500), cp.Chips)
+	assert.Equal(t, 0, cp.AddonTimes)
+	assert.Equal(t, int64(0), cp.TotalRedeemChips)
+	assert.Equal(t, 0, ce.competition.State.Statistic.TotalAddonCount)
+	assert.Equal(t, "t1", cp.CurrentTableID)
+	assert.NotContains(t, ce.pendingAddons, "p1")
+}
+
+func Test_RedeemChips_ChipAdjustmentRollback(t *testing.T) {
+	ce, cp := newRedeemChipsTestEngine(500, pokertable.TableStateStatus_TableGameStandby)
+	ce.pendingChipAdjustments["p1"] = -200
+
+	ce.applyPendingChipAdjustment(context.Background(), "p1")
+
+	assert.Equal(t, int64(500), cp.Chips)
+	assert.Equal(t, int64(500), ce.competition.State.Tables[0].State.PlayerStates[0].Bankroll)
+	assert.NotContains(t, ce.pendingChipAdjustments, "p1")
+}
@@ -0,0 +1,145 @@
+package pokercompetition
+
+import (
+	"context"
+	"time"
+
+	"github.com/thoas/go-funk"
+)
+
+type RefundPolicy struct {
+	MaxBlindLevel  int  `json:"max_blind_level"`  // 開賽後可退賽的最大盲注等級 (0 表示開賽後不可退賽)
+	IsUnseatedOnly bool `json:"is_unseated_only"` // 開賽後僅限尚未入座的玩家退賽
+	RefundPercent  int  `json:"refund_percent"`   // 開賽後退款比例 (1~100, 0 表示全額退款)
+}
+
+type RefundRecord struct {
+	PlayerID      string `json:"player_id"`      // 玩家 ID
+	Units         int    `json:"units"`          // 退還買入發數
+	Chips         int64  `json:"chips"`          // 移除籌碼
+	RefundPercent int    `json:"refund_percent"` // 退款比例 (1~100)
+	BlindLevel    int    `json:"blind_level"`    // 退賽時盲注等級 (開賽前為 0)
+	IsSeated      bool   `json:"is_seated"`      // 退賽時是否已入座
+	CreatedAt     int64  `json:"created_at"`     // 退賽時間 (Seconds)
+}
+
+/*
+currentPlayingBlindLevel 取得當前 (中場休息時為前一個) 非中場休息的盲注等級
+*/
+func (c Competition) currentPlayingBlindLevel() int {
+	for idx := c.State.BlindState.CurrentLevelIndex; idx >= 0 && idx < len(c.Meta.Blind.Levels); idx-- {
+		if level := c.Meta.Blind.Levels[idx].Level; level != -1 {
+			return level
+		}
+	}
+	return 0
+}
+
+// refundPercent 開賽後退款比例
+func (p RefundPolicy) refundPercent() int {
+	if p.RefundPercent <= 0 || p.RefundPercent > 100 {
+		return 100
+	}
+	return p.RefundPercent
+}
+
+// isPlayerSeated 玩家是否已入座桌次
+func (ce *competitionEngine) isPlayerSeated(player *CompetitionPlayer) bool {
+	return player.Status == CompetitionPlayerStatus_Playing && player.CurrentTableID != "" && ce.competition.IsTableExist(player.CurrentTableID)
+}
+
+/*
+validateRefundAfterStart 依退賽規則驗證開賽後退賽
+  - 盲注等級不可超過 MaxBlindLevel
+  - 玩家需仍有籌碼且沒有等待中的補碼、增購或棄賽
+  - 已入座玩家需在兩手之間退賽
+  - @return 玩家是否已入座
+*/
+func (ce *competitionEngine) validateRefundAfterStart(player *CompetitionPlayer) (bool, error) {
+	ce.mu.RLock()
+	defer ce.mu.RUnlock()
+
+	policy := ce.competition.Meta.RefundPolicy
+	if policy.MaxBlindLevel <= 0 || ce.competition.currentPlayingBlindLevel() > policy.MaxBlindLevel {
+		return false, ErrCompetitionRefundRejected
+	}
+
+	if player.Chips <= 0 || player.Status == CompetitionPlayerStatus_Knockout || player.IsForfeited {
+		return false, ErrCompetitionRefundRejected
+	}
+
+	if _, exist := ce.pendingReBuys[player.PlayerID]; exist {
+		return false, ErrCompetitionRefundRejected
+	}
+	if _, exist := ce.pendingAddons[player.PlayerID]; exist {
+		return false, ErrCompetitionRefundRejected
+	}
+	if ce.pendingForfeits[player.PlayerID] {
+		return false, ErrCompetitionRefundRejected
+	}
+
+	if !ce.isPlayerSeated(player) {
+		return false, nil
+	}
+
+	if policy.IsUnseatedOnly {
+		return true, ErrCompetitionRefundRejected
+	}
+
+	if ce.isTableGameInProgress(player.CurrentTableID) {
+		return true, ErrCompetitionRefundDuringHand
+	}
+	return true, nil
+}
+
+/*
+leaveRefundPlayer 退賽玩家離開桌次與拆併桌監管器
+  - CT: 離開桌次
+  - MTT: 已入座玩家離開桌次並更新監管器該桌人數，等待拆併桌的玩家移出等待區
+*/
+func (ce *competitionEngine) leaveRefundPlayer(ctx context.Context, player *CompetitionPlayer, isSeated bool) error {
+	switch ce.competition.Meta.Mode {
+	case CompetitionMode_CT:
+		if player.CurrentTableID != "" {
+			return ce.backend(ctx).PlayersLeave(player.CurrentTableID, []string{player.PlayerID})
+		}
+	case CompetitionMode_MTT:
+		if funk.ContainsString(ce.waitingPlayers, player.PlayerID) || !ce.isRegulatorStarted {
+			return nil
+		}
+
+		tableID := ""
+		if isSeated {
+			tableID = player.CurrentTableID
+			if err := ce.backend(ctx).PlayersLeave(tableID, []string{player.PlayerID}); err != nil {
+				return err
+			}
+		}
+		if err := ce.regulate(ctx, func() error {
+			return ce.regulator.RemovePlayers(tableID, []string{player.PlayerID})
+		}); err != nil {
+			ce.emitErrorEvent("PlayerRefund -> MTT Regulator Remove Players", player.PlayerID, err)
+		}
+	}
+	return nil
+}
+
+// addRefundRecord 記錄退賽並更新統計
+func (ce *competitionEngine) addRefundRecord(player *CompetitionPlayer, isSeated bool) {
+	record := &RefundRecord{
+		PlayerID:      player.PlayerID,
+		Units:         player.TotalBuyInUnits,
+		Chips:         player.Chips,
+		RefundPercent: 100,
+		BlindLevel:    0,
+		IsSeated:      isSeated,
+		CreatedAt:     time.Now().Unix(),
+	}
+	if ce.blind.IsStarted() {
+		record.RefundPercent = ce.competition.Meta.RefundPolicy.refundPercent()
+		record.BlindLevel = ce.competition.currentPlayingBlindLevel()
+	}
+	ce.competition.State.Refunds = append(ce.competition.State.Refunds, record)
+	ce.competition.State.Statistic.TotalRefundCount++
+	ce.competition.State.Statistic.TotalRefundUnits += player.TotalBuyInUnits
+}
@@ -0,0 +1,121 @@
+package pokercompetition
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokertable"
+)
+
+/*
+newRefundTestEngine 建立玩家 p1 坐在桌次 t1 (兩手之間) 的開賽後賽事
+  - 盲注等級: 1, 中場休息, 2
+*/
+func newRefundTestEngine(policy RefundPolicy, levelIdx int) (*competitionEngine, *CompetitionPlayer) {
+	cp := newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_Playing)
+	ce := newTestCompetitionEngine(cp)
+	ce.competition.Meta.RefundPolicy = policy
+	ce.competition.Meta.Blind.Levels = []BlindLevel{
+		{Level: 1, SB: 10, BB: 20, Duration: 60},
+		{Level: -1, Duration: 60},
+		{Level: 2, SB: 20, BB: 40, Duration: 60},
+	}
+	ce.competition.State.BlindState = &BlindState{CurrentLevelIndex: levelIdx}
+	addTestTable(ce, "t1", pokertable.TableStateStatus_TableGameStandby, cp)
+	return ce, cp
+}
+
+func Test_Refund_MaxBlindLevel(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   RefundPolicy
+		levelIdx int
+		err      error
+	}{
+		{name: "not allowed after start", policy: RefundPolicy{}, levelIdx: 0, err: ErrCompetitionRefundRejected},
+		{name: "within max level", policy: RefundPolicy{MaxBlindLevel: 1}, levelIdx: 0},
+		{name: "break counts as previous level", policy: RefundPolicy{MaxBlindLevel: 1}, levelIdx: 1},
+		{name: "over max level", policy: RefundPolicy{MaxBlindLevel: 1}, levelIdx: 2, err: ErrCompetitionRefundRejected},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ce, cp := newRefundTestEngine(tc.policy, tc.levelIdx)
+			_, err := ce.validateRefundAfterStart(cp)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Refund_UnseatedOnly(t *testing.T) {
+	ce, cp := newRefundTestEngine(RefundPolicy{MaxBlindLevel: 2, IsUnseatedOnly: true}, 0)
+
+	isSeated, err := ce.validateRefundAfterStart(cp)
+	assert.True(t, isSeated)
+	assert.ErrorIs(t, err, ErrCompetitionRefundRejected)
+
+	// 等待拆併桌的玩家可退賽
+	cp.Status = CompetitionPlayerStatus_WaitingTableBalancing
+	cp.CurrentTableID = ""
+	isSeated, err = ce.validateRefundAfterStart(cp)
+	assert.False(t, isSeated)
+	assert.NoError(t, err)
+}
+
+func Test_Refund_DuringHand(t *testing.T) {
+	ce, cp := newRefundTestEngine(RefundPolicy{MaxBlindLevel: 2}, 0)
+
+	ce.competition.State.Tables[0].State.Status = pokertable.TableStateStatus_TableGamePlaying
+	isSeated, err := ce.validateRefundAfterStart(cp)
+	assert.True(t, isSeated)
+	assert.ErrorIs(t, err, ErrCompetitionRefundDuringHand)
+
+	// 兩手之間可退賽
+	ce.competition.State.Tables[0].State.Status = pokertable.TableStateStatus_TableGameStandby
+	isSeated, err = ce.validateRefundAfterStart(cp)
+	assert.True(t, isSeated)
+	assert.NoError(t, err)
+}
+
+func Test_Refund_PendingChips(t *testing.T) {
+	ce, cp := newRefundTestEngine(RefundPolicy{MaxBlindLevel: 2}, 0)
+
+	ce.pendingReBuys[cp.PlayerID] = JoinPlayer{PlayerID: cp.PlayerID, RedeemChips: 1000}
+	_, err := ce.validateRefundAfterStart(cp)
+	assert.ErrorIs(t, err, ErrCompetitionRefundRejected)
+	delete(ce.pendingReBuys, cp.PlayerID)
+
+	ce.pendingAddons[cp.PlayerID] = pendingAddon{tableID: "t1"}
+	_, err = ce.validateRefundAfterStart(cp)
+	assert.ErrorIs(t, err, ErrCompetitionRefundRejected)
+	delete(ce.pendingAddons, cp.PlayerID)
+
+	ce.pendingForfeits[cp.PlayerID] = true
+	_, err = ce.validateRefundAfterStart(cp)
+	assert.ErrorIs(t, err, ErrCompetitionRefundRejected)
+}
+
+func Test_Refund_ValidateWhilePendingUpdated(t *testing.T) {
+	ce, cp := newRefundTestEngine(RefundPolicy{MaxBlindLevel: 2}, 0)
+
+	// 與補碼等待資料同時存取 (搭配 -race 檢查)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			ce.mu.Lock()
+			ce.pendingReBuys["p2"] = JoinPlayer{PlayerID: "p2"}
+			delete(ce.pendingReBuys, "p2")
+			ce.mu.Unlock()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_, _ = ce.validateRefundAfterStart(cp)
+		}
+	}()
+	wg.Wait()
+}
@@ -1,19 +1,37 @@
 package pokercompetition
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/google/uuid"
+	pokerbalancing "github.com/weedbox/pokercompetition/balancing"
 	"github.com/weedbox/pokerface/regulator"
 	"github.com/weedbox/pokertable"
 )
 
+/*
+regulate 以 ctx 執行拆併桌監管器操作
+  - 監管器在操作中同步觸發的建立桌次、分配玩家 (regulatorCreateAndDistributePlayers、regulatorDistributePlayers) 使用同一個 ctx
+  - 監管器操作依序執行 (監管器本身也以鎖保護)，fn 內不可再呼叫 regulate
+*/
+func (ce *competitionEngine) regulate(ctx context.Context, fn func() error) error {
+	ce.regulatorMu.Lock()
+	defer ce.regulatorMu.Unlock()
+
+	ce.regulatorCtx = ctx
+	defer func() {
+		ce.regulatorCtx = context.Background()
+	}()
+	return fn()
+}
+
 /*
 regulatorCreateAndDistributePlayers 建立新桌次並分配玩家至該桌次
 - 適用時機: 拆併桌監管器自動觸發
 */
-func (ce *competitionEngine) regulatorCreateAndDistributePlayers(playerIDs []string) (string, error) {
+func (ce *competitionEngine) regulatorCreateAndDistributePlayers(ctx context.Context, playerIDs []string) (string, error) {
 	joinPlayers := make([]pokertable.JoinPlayer, 0)
 	for _, playerID := range playerIDs {
 		playerIdx := ce.competition.FindPlayerIdx(func(cp *CompetitionPlayer) bool {
@@ -35,11 +53,12 @@ func (ce *competitionEngine) regulatorCreateAndDistributePlayers(playerIDs []str
 		})
 	}
 
+	tableID := uuid.New().String()
 	tableSetting := TableSetting{
-		TableID:     uuid.New().String(),
-		JoinPlayers: joinPlayers,
+		TableID:     tableID,
+		JoinPlayers: ce.newSeatingJoinPlayers(tableID, joinPlayers, []string{}),
 	}
-	level, ante, dealer, sb, bb := ce.competition.CurrentBlindData()
+	level, ante, dealer, sb, bb := ce.competition.CurrentTableBlindData(0)
 	blind := pokertable.TableBlindState{
 		Level:  level,
 		Ante:   ante,
@@ -47,8 +66,7 @@ func (ce *competitionEngine) regulatorCreateAndDistributePlayers(playerIDs []str
 		SB:     sb,
 		BB:     bb,
 	}
-	tableID, err := ce.addCompetitionTable(tableSetting, blind)
-	if err != nil {
+	if _, err := ce.addCompetitionTable(ctx, tableSetting, blind); err != nil {
 		return "", err
 	}
 
@@ -60,7 +78,7 @@ func (ce *competitionEngine) regulatorCreateAndDistributePlayers(playerIDs []str
 regulatorDistributePlayers 分配玩家至某桌次
 - 適用時機: 拆併桌監管器自動觸發
 */
-func (ce *competitionEngine) regulatorDistributePlayers(tableID string, playerIDs []string) error {
+func (ce *competitionEngine) regulatorDistributePlayers(ctx context.Context, tableID string, playerIDs []string) error {
 	joinPlayers := make([]pokertable.JoinPlayer, 0)
 	for _, playerID := range playerIDs {
 		playerIdx := ce.competition.FindPlayerIdx(func(cp *CompetitionPlayer) bool {
@@ -80,7 +98,8 @@ func (ce *competitionEngine) regulatorDistributePlayers(tableID string, playerID
 			Seat:        UnsetValue,
 		})
 	}
-	if _, err := ce.tableManagerBackend.UpdateTablePlayers(tableID, joinPlayers, []string{}); err != nil {
+	joinPlayers = ce.newSeatingJoinPlayers(tableID, joinPlayers, []string{})
+	if _, err := ce.backend(ctx).UpdateTablePlayers(tableID, joinPlayers, []string{}); err != nil {
 		return err
 	}
 
@@ -88,6 +107,20 @@ func (ce *competitionEngine) regulatorDistributePlayers(tableID string, playerID
 	return nil
 }
 
+/*
+NewBalancingStrategy 依設定取得 MTT 拆併桌策略 (未設定時為 TDA 標準)
+*/
+func NewBalancingStrategy(strategy CompetitionBalancingStrategy) pokerbalancing.Strategy {
+	switch strategy {
+	case CompetitionBalancingStrategy_BreakHighestTable:
+		return pokerbalancing.NewBreakHighestTableStrategy()
+	case CompetitionBalancingStrategy_MinimizeMoves:
+		return pokerbalancing.NewMinimizeMovesStrategy()
+	default:
+		return pokerbalancing.NewTDAStrategy()
+	}
+}
+
 func (ce *competitionEngine) shouldActivateRegulator() bool {
 	if ce.isRegulatorStarted {
 		return false
@@ -100,7 +133,7 @@ func (ce *competitionEngine) shouldActivateRegulator() bool {
 	return len(ce.competition.State.Players) >= ce.competition.Meta.MinPlayerCount
 }
 
-func (ce *competitionEngine) activateRegulator() {
+func (ce *competitionEngine) activateRegulator(ctx context.Context) {
 	// MTT 啟動盲注
 	if !ce.blind.IsStarted() {
 		err := ce.activateBlind()
@@ -110,18 +143,23 @@ func (ce *competitionEngine) activateRegulator() {
 	}
 
 	//  把等待區玩家加入拆併桌程式
-	if err := ce.regulatorAddPlayers(ce.waitingPlayers); err != nil {
+	if err := ce.regulatorAddPlayers(ctx, ce.waitingPlayers); err != nil {
 		ce.emitErrorEvent("MTT Regulator Add Players Error", strings.Join(ce.waitingPlayers, ","), err)
 	}
 	ce.waitingPlayers = make([]string, 0)
 
 	// 啟動拆併桌程式
-	ce.regulator.SetStatus(regulator.CompetitionStatus_Normal)
+	_ = ce.regulate(ctx, func() error {
+		ce.regulator.SetStatus(regulator.CompetitionStatus_Normal)
+		return nil
+	})
 	ce.isRegulatorStarted = true
 }
 
-func (ce *competitionEngine) regulatorAddPlayers(playerIDs []string) error {
-	if err := ce.regulator.AddPlayers(playerIDs); err != nil {
+func (ce *competitionEngine) regulatorAddPlayers(ctx context.Context, playerIDs []string) error {
+	if err := ce.regulate(ctx, func() error {
+		return ce.regulator.AddPlayers(playerIDs)
+	}); err != nil {
 		return err
 	}
 
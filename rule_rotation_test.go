@@ -0,0 +1,306 @@
+package pokercompetition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokertable"
+)
+
+func newRuleRotationCompetition(rotation RuleRotation) *Competition {
+	return &Competition{
+		ID: "c1",
+		Meta: CompetitionMeta{
+			Rule: CompetitionRule_Default,
+			Blind: Blind{
+				DealerBlindTime: 2,
+				Levels: []BlindLevel{
+					{Level: 1, Ante: 10, SB: 10, BB: 20},
+					{Level: -1},
+					{Level: 2, Ante: 20, SB: 20, BB: 40},
+					{Level: 3, Ante: 30, SB: 30, BB: 60},
+				},
+			},
+			RuleRotation: rotation,
+		},
+		State: &CompetitionState{
+			BlindState: &BlindState{CurrentLevelIndex: 0},
+		},
+	}
+}
+
+func Test_RuleRotation_CurrentTableRule(t *testing.T) {
+	games := []RuleRotationGame{{Rule: CompetitionRule_Default}, {Rule: CompetitionRule_ShortDeck}, {Rule: CompetitionRule_Omaha}}
+	testCases := []struct {
+		name           string
+		rotation       RuleRotation
+		levelIdx       int
+		tableGameCount int
+		rule           CompetitionRule
+	}{
+		{name: "no rotation", rotation: RuleRotation{}, levelIdx: 2, tableGameCount: 5, rule: CompetitionRule_Default},
+		{name: "first blind level", rotation: RuleRotation{Trigger: CompetitionRuleRotationTrigger_BlindLevel, Games: games}, levelIdx: 0, rule: CompetitionRule_Default},
+		// 中場休息不計入輪替
+		{name: "break keeps previous game", rotation: RuleRotation{Trigger: CompetitionRuleRotationTrigger_BlindLevel, Games: games}, levelIdx: 1, rule: CompetitionRule_ShortDeck},
+		{name: "blind level after break", rotation: RuleRotation{Trigger: CompetitionRuleRotationTrigger_BlindLevel, Games: games}, levelIdx: 2, rule: CompetitionRule_ShortDeck},
+		{name: "third blind level", rotation: RuleRotation{Trigger: CompetitionRuleRotationTrigger_BlindLevel, Games: games}, levelIdx: 3, rule: CompetitionRule_Omaha},
+		{name: "within hand count", rotation: RuleRotation{Trigger: CompetitionRuleRotationTrigger_HandCount, HandCount: 2, Games: games}, tableGameCount: 1, rule: CompetitionRule_Default},
+		{name: "hand count reached", rotation: RuleRotation{Trigger: CompetitionRuleRotationTrigger_HandCount, HandCount: 2, Games: games}, tableGameCount: 2, rule: CompetitionRule_ShortDeck},
+		{name: "hand count wraps around", rotation: RuleRotation{Trigger: CompetitionRuleRotationTrigger_HandCount, HandCount: 2, Games: games}, tableGameCount: 6, rule: CompetitionRule_Default},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newRuleRotationCompetition(tc.rotation)
+			c.State.BlindState.CurrentLevelIndex = tc.levelIdx
+			assert.Equal(t, tc.rule, c.CurrentTableRule(tc.tableGameCount))
+		})
+	}
+}
+
+func Test_RuleRotation_CurrentTableBlindData(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		game                 RuleRotationGame
+		ante, dealer, sb, bb int64
+	}{
+		{name: "unchanged", game: RuleRotationGame{Rule: CompetitionRule_Default}, ante: 20, sb: 20, bb: 40},
+		{name: "scaled blinds", game: RuleRotationGame{Rule: CompetitionRule_Omaha, BlindPercent: 50, AntePercent: 200}, ante: 40, sb: 10, bb: 20},
+		{name: "dropped ante", game: RuleRotationGame{Rule: CompetitionRule_Default, AntePercent: UnsetValue}, ante: 0, sb: 20, bb: 40},
+		// 短牌只收 Dealer 盲注
+		{name: "short deck", game: RuleRotationGame{Rule: CompetitionRule_ShortDeck}, ante: 20, dealer: 20},
+		{name: "short deck dropped ante", game: RuleRotationGame{Rule: CompetitionRule_ShortDeck, AntePercent: UnsetValue}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newRuleRotationCompetition(RuleRotation{Trigger: CompetitionRuleRotationTrigger_BlindLevel, Games: []RuleRotationGame{tc.game}})
+			c.State.BlindState.CurrentLevelIndex = 2
+
+			level, ante, dealer, sb, bb := c.CurrentTableBlindData(0)
+			assert.Equal(t, 2, level)
+			assert.Equal(t, []int64{tc.ante, tc.dealer, tc.sb, tc.bb}, []int64{ante, dealer, sb, bb})
+		})
+	}
+}
+
+func Test_RuleRotation_TableSeatingRule(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rule  CompetitionRule
+		games []RuleRotationGame
+		want  CompetitionRule
+	}{
+		{name: "no rotation", rule: CompetitionRule_ShortDeck, want: CompetitionRule_ShortDeck},
+		{name: "short deck only", rule: CompetitionRule_Default, games: []RuleRotationGame{{Rule: CompetitionRule_ShortDeck}}, want: CompetitionRule_ShortDeck},
+		{name: "starts with omaha", rule: CompetitionRule_ShortDeck, games: []RuleRotationGame{{Rule: CompetitionRule_Omaha}, {Rule: CompetitionRule_ShortDeck}}, want: CompetitionRule_Omaha},
+		// 第一個輪替為短牌時仍需可進行其他規則的座位
+		{name: "starts with short deck", rule: CompetitionRule_Default, games: []RuleRotationGame{{Rule: CompetitionRule_ShortDeck}, {Rule: CompetitionRule_Omaha}}, want: CompetitionRule_Default},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			trigger := CompetitionRuleRotationTrigger("")
+			if len(tc.games) > 0 {
+				trigger = CompetitionRuleRotationTrigger_BlindLevel
+			}
+			c := newRuleRotationCompetition(RuleRotation{Trigger: trigger, Games: tc.games})
+			c.Meta.Rule = tc.rule
+			assert.Equal(t, tc.want, c.TableSeatingRule())
+		})
+	}
+}
+
+func Test_RuleRotation_UpdateTable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rotation RuleRotation
+		levelIdx int
+		// 依序設定的桌次手數與預期的規則、盲注 (ante, dealer, sb, bb)
+		steps []struct {
+			gameCount int
+			rule      CompetitionRule
+			blind     []int64
+		}
+	}{
+		{
+			name: "hand count",
+			rotation: RuleRotation{Trigger: CompetitionRuleRotationTrigger_HandCount, HandCount: 2, Games: []RuleRotationGame{
+				{Rule: CompetitionRule_Default},
+				{Rule: CompetitionRule_ShortDeck, AntePercent: UnsetValue},
+				{Rule: CompetitionRule_Omaha, BlindPercent: 200},
+			}},
+			steps: []struct {
+				gameCount int
+				rule      CompetitionRule
+				blind     []int64
+			}{
+				{gameCount: 1, rule: CompetitionRule_Default, blind: []int64{10, 0, 10, 20}},
+				{gameCount: 2, rule: CompetitionRule_ShortDeck, blind: []int64{0, 0, 0, 0}},
+				{gameCount: 4, rule: CompetitionRule_Omaha, blind: []int64{10, 0, 20, 40}},
+				{gameCount: 6, rule: CompetitionRule_Default, blind: []int64{10, 0, 10, 20}},
+			},
+		},
+		{
+			name:     "blind level",
+			levelIdx: 2,
+			rotation: RuleRotation{Trigger: CompetitionRuleRotationTrigger_BlindLevel, Games: []RuleRotationGame{
+				{Rule: CompetitionRule_Omaha},
+				{Rule: CompetitionRule_ShortDeck, AntePercent: 50},
+			}},
+			steps: []struct {
+				gameCount int
+				rule      CompetitionRule
+				blind     []int64
+			}{
+				{gameCount: 3, rule: CompetitionRule_ShortDeck, blind: []int64{10, 10, 0, 0}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			manager := NewTableManager()
+			ce := NewCompetitionEngine().(*competitionEngine)
+			ce.tableManagerBackend = NewNativeTableManagerBackend(manager)
+			ce.competition = newRuleRotationCompetition(tc.rotation)
+
+			meta := ce.competition.Meta
+			meta.Rule = ce.competition.TableSeatingRule()
+			table, err := ce.tableManagerBackend.CreateTable(pokertable.NewTableEngineOptions(), NewPokerTableSetting(ce.competition.ID, meta, TableSetting{TableID: "t1"}, pokertable.TableBlindState{}))
+			assert.NoError(t, err, "create table failed")
+			ce.competition.State.Tables = []*pokertable.Table{table}
+			ce.addTableInfo(table.ID, meta.Rule)
+
+			gameBackend, err := manager.(*tableManager).gameBackend(table.ID)
+			assert.NoError(t, err, "game backend not found")
+			tableEngine, err := manager.GetTableEngine(table.ID)
+			assert.NoError(t, err, "table engine not found")
+
+			ce.competition.State.BlindState.CurrentLevelIndex = tc.levelIdx
+			for _, step := range tc.steps {
+				table.State.GameCount = step.gameCount
+				ce.updateTableRule(context.Background(), table.ID)
+				ce.updateTableBlind(context.Background(), table.ID)
+
+				assert.Equal(t, step.rule, ce.competition.FindTableInfo(table.ID).Rule, "game count %d", step.gameCount)
+				// 牌局後端未設定規則時使用建桌規則
+				rule := gameBackend.Rule()
+				if rule == "" {
+					rule = string(meta.Rule)
+				}
+				assert.Equal(t, string(step.rule), rule, "game count %d", step.gameCount)
+				blind := tableEngine.GetTable().State.BlindState
+				assert.Equal(t, step.blind, []int64{blind.Ante, blind.Dealer, blind.SB, blind.BB}, "game count %d", step.gameCount)
+
+				// 建桌規則 (座位管理) 不變
+				assert.Equal(t, string(meta.Rule), tableEngine.GetTable().Meta.Rule)
+			}
+		})
+	}
+}
+
+func Test_RuleRotation_SeatingMismatch(t *testing.T) {
+	manager := NewTableManager()
+	backend := NewNativeTableManagerBackend(manager)
+	table, err := backend.CreateTable(pokertable.NewTableEngineOptions(), pokertable.TableSetting{
+		TableID: "t1",
+		Meta:    pokertable.TableMeta{Rule: pokertable.CompetitionRule_ShortDeck, TableMaxSeatCount: 6},
+	})
+	assert.NoError(t, err, "create table failed")
+
+	assert.NoError(t, backend.UpdateTableRule(table.ID, pokertable.CompetitionRule_ShortDeck))
+	assert.ErrorIs(t, backend.UpdateTableRule(table.ID, pokertable.CompetitionRule_Omaha), ErrTableRuleSeatingMismatch)
+
+	// pokertable.Manager 建立的桌次無法輪替規則
+	nativeBackend := NewNativeTableManagerBackend(pokertable.NewManager())
+	table, err = nativeBackend.CreateTable(pokertable.NewTableEngineOptions(), pokertable.TableSetting{
+		TableID: "t2",
+		Meta:    pokertable.TableMeta{Rule: pokertable.CompetitionRule_Default, TableMaxSeatCount: 6},
+	})
+	assert.NoError(t, err, "create table failed")
+	assert.NoError(t, nativeBackend.UpdateTableRule(table.ID, pokertable.CompetitionRule_Default))
+	assert.ErrorIs(t, nativeBackend.UpdateTableRule(table.ID, pokertable.CompetitionRule_Omaha), ErrTableRuleUnsupported)
+}
+
+func Test_TableGameBackend_CreateGameWithRule(t *testing.T) {
+	testCases := []struct {
+		rule           string
+		deckSize       int
+		holeCardsCount int
+		positions      [][]string
+		blind          pokerface.BlindSetting
+	}{
+		{rule: pokertable.CompetitionRule_Default, deckSize: 52, holeCardsCount: 2, positions: [][]string{{"dealer"}, {"sb"}, {"bb"}}, blind: pokerface.BlindSetting{Dealer: 20, SB: 10, BB: 20}},
+		{rule: pokertable.CompetitionRule_Omaha, deckSize: 52, holeCardsCount: 4, positions: [][]string{{"dealer"}, {"sb"}, {"bb"}}, blind: pokerface.BlindSetting{Dealer: 20, SB: 10, BB: 20}},
+		{rule: pokertable.CompetitionRule_ShortDeck, deckSize: 36, holeCardsCount: 2, positions: [][]string{{"dealer"}, {}, {}}, blind: pokerface.BlindSetting{Dealer: 20}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rule, func(t *testing.T) {
+			backend := newTableGameBackend()
+			backend.SetRule(tc.rule)
+
+			// 桌次引擎以常牌座位準備牌局設定
+			opts := pokerface.NewStardardGameOptions()
+			opts.Deck = pokerface.NewStandardDeckCards()
+			opts.Blind = pokerface.BlindSetting{Dealer: 20, SB: 10, BB: 20}
+			for _, positions := range [][]string{{"dealer"}, {"sb"}, {"bb"}} {
+				opts.Players = append(opts.Players, &pokerface.PlayerSetting{Bankroll: 1000, Positions: positions})
+			}
+
+			gs, err := backend.CreateGame(opts)
+			assert.NoError(t, err, "create game failed")
+			assert.Len(t, gs.Meta.Deck, tc.deckSize)
+			assert.Equal(t, tc.holeCardsCount, gs.Meta.HoleCardsCount)
+			assert.Equal(t, tc.blind, gs.Meta.Blind)
+			for idx, p := range gs.Players {
+				assert.ElementsMatch(t, tc.positions[idx], p.Positions)
+			}
+		})
+	}
+}
+
+func Test_RuleRotation_BettingStructure(t *testing.T) {
+	rotation := RuleRotation{Trigger: CompetitionRuleRotationTrigger_HandCount, HandCount: 1, Games: []RuleRotationGame{
+		{Rule: CompetitionRule_Default},
+		{Rule: CompetitionRule_Omaha, BettingStructure: CompetitionBettingStructure_PotLimit},
+	}}
+
+	manager := NewTableManager()
+	ce := NewCompetitionEngine().(*competitionEngine)
+	ce.tableManagerBackend = NewNativeTableManagerBackend(manager)
+	ce.competition = newRuleRotationCompetition(rotation)
+	ce.competition.Meta.BettingStructure = CompetitionBettingStructure_FixedLimit
+
+	table, err := ce.tableManagerBackend.CreateTable(pokertable.NewTableEngineOptions(), NewPokerTableSetting(ce.competition.ID, ce.competition.Meta, TableSetting{TableID: "t1"}, pokertable.TableBlindState{}))
+	assert.NoError(t, err, "create table failed")
+	ce.competition.State.Tables = []*pokertable.Table{table}
+	ce.addTableInfo(table.ID, ce.competition.Meta.Rule)
+	gameBackend, err := manager.(*tableManager).gameBackend(table.ID)
+	assert.NoError(t, err, "game backend not found")
+
+	// 輪替賽制未設定下注結構時使用賽事設定
+	for gameCount, structure := range []CompetitionBettingStructure{
+		CompetitionBettingStructure_FixedLimit,
+		CompetitionBettingStructure_PotLimit,
+		CompetitionBettingStructure_FixedLimit,
+	} {
+		table.State.GameCount = gameCount
+		if gameCount == 0 {
+			ce.updateTableBettingStructure(context.Background(), table.ID)
+		} else {
+			ce.updateTableRule(context.Background(), table.ID)
+		}
+
+		assert.Equal(t, structure, ce.competition.CurrentTableBettingStructure(gameCount), "game count %d", gameCount)
+		assert.Equal(t, structure, ce.competition.FindTableInfo(table.ID).BettingStructure, "game count %d", gameCount)
+		assert.Equal(t, structure, gameBackend.BettingStructure(), "game count %d", gameCount)
+	}
+
+	// 未設定任何下注結構時為無限注
+	ce.competition.Meta.BettingStructure = ""
+	assert.Equal(t, CompetitionBettingStructure_NoLimit, ce.competition.CurrentTableBettingStructure(0))
+}
@@ -0,0 +1,144 @@
+package pokercompetition
+
+import "time"
+
+type Schedule struct {
+	CompetitionID           string          `json:"competition_id"`             // 賽事 ID
+	CurrentLevelIndex       int             `json:"current_level_index"`        // 現在盲注等級級別索引值 (-1 表示尚未開始)
+	Levels                  []ScheduleLevel `json:"levels"`                     // 每個等級預估時程
+	NextBreakAt             int64           `json:"next_break_at"`              // 下次中場休息開始時間 (Seconds, -1 表示無或無法預估)
+	LateRegistrationCloseAt int64           `json:"late_registration_close_at"` // 延遲買入截止時間 (Seconds, -1 表示無法預估或永不截止)
+	IsLateRegistrationOpen  bool            `json:"is_late_registration_open"`  // 是否仍可延遲買入
+	Advance                 ScheduleAdvance `json:"advance"`                    // 晉級條件
+	GeneratedAt             int64           `json:"generated_at"`               // 時程產生時間 (Seconds)
+}
+
+type ScheduleLevel struct {
+	Index       int   `json:"index"`        // 盲注等級索引值
+	Level       int   `json:"level"`        // 盲注等級 (-1 表示中場休息)
+	SB          int64 `json:"sb"`           // 小盲籌碼量
+	BB          int64 `json:"bb"`           // 大盲籌碼量
+	Ante        int64 `json:"ante"`         // 前注籌碼量
+	IsBreak     bool  `json:"is_break"`     // 是否為中場休息
+	IsUnlimited bool  `json:"is_unlimited"` // 是否無時間限制
+	HandCount   int   `json:"hand_count"`   // 以手數計算時的等級持續手數 (0 表示以時間計算)
+	StartAt     int64 `json:"start_at"`     // (預估) 開始時間 (Seconds, -1 表示無法預估)
+	EndAt       int64 `json:"end_at"`       // (預估) 結束時間 (Seconds, -1 表示無法預估或無時間限制)
+	IsProjected bool  `json:"is_projected"` // 時間是否為預估值 (尚未開始的等級)
+	IsPassed    bool  `json:"is_passed"`    // 是否已結束
+}
+
+type ScheduleAdvance struct {
+	Rule        CompetitionAdvanceRule `json:"rule"`         // 晉級方式
+	PlayerCount int                    `json:"player_count"` // 晉級人數
+	BlindLevel  int                    `json:"blind_level"`  // 晉級盲注級別
+	TriggerAt   int64                  `json:"trigger_at"`   // 晉級盲注級別 (預估) 開始時間 (Seconds, -1 表示無法預估)
+}
+
+/*
+NewSchedule 依賽事當前盲注狀態產生賽事時程
+  - 已開始的等級使用盲注實際結束時間，之後的等級依持續時間往後推算
+  - 無時間限制 (-1) 或以手數計算的等級之後，時間皆無法預估 (-1)
+  - 賽事尚未開始時，以開賽時間 (StartAt) 從起始盲注等級開始推算
+*/
+func NewSchedule(c *Competition) *Schedule {
+	schedule := &Schedule{
+		CompetitionID:           c.ID,
+		CurrentLevelIndex:       c.State.BlindState.CurrentLevelIndex,
+		Levels:                  make([]ScheduleLevel, 0, len(c.Meta.Blind.Levels)),
+		NextBreakAt:             UnsetValue,
+		LateRegistrationCloseAt: UnsetValue,
+		IsLateRegistrationOpen:  !c.State.BlindState.IsStopBuyIn(),
+		Advance: ScheduleAdvance{
+			Rule:        c.Meta.AdvanceSetting.Rule,
+			PlayerCount: c.Meta.AdvanceSetting.PlayerCount,
+			BlindLevel:  c.Meta.AdvanceSetting.BlindLevel,
+			TriggerAt:   UnsetValue,
+		},
+		GeneratedAt: time.Now().Unix(),
+	}
+
+	// 推算起點
+	firstLevelIdx := c.State.BlindState.CurrentLevelIndex
+	startAt := int64(UnsetValue)
+	if firstLevelIdx == UnsetValue {
+		for idx, bl := range c.Meta.Blind.Levels {
+			if bl.Level == c.Meta.Blind.InitialLevel {
+				firstLevelIdx = idx
+				break
+			}
+		}
+		startAt = c.State.StartAt
+	} else {
+		startAt = c.State.StartAt
+		if level := c.Meta.Blind.Levels[firstLevelIdx]; level.HandCount == 0 && level.Duration > 0 {
+			startAt = c.State.BlindState.EndAts[firstLevelIdx] - int64(level.Duration)
+		} else if firstLevelIdx > 0 && c.State.BlindState.EndAts[firstLevelIdx-1] > 0 {
+			startAt = c.State.BlindState.EndAts[firstLevelIdx-1]
+		}
+	}
+
+	for idx, bl := range c.Meta.Blind.Levels {
+		sl := ScheduleLevel{
+			Index:       idx,
+			Level:       bl.Level,
+			SB:          bl.SB,
+			BB:          bl.BB,
+			Ante:        bl.Ante,
+			IsBreak:     bl.Level == -1,
+			IsUnlimited: bl.HandCount == 0 && bl.Duration == UnsetValue,
+			HandCount:   bl.HandCount,
+			StartAt:     UnsetValue,
+			EndAt:       UnsetValue,
+		}
+
+		switch {
+		case firstLevelIdx == UnsetValue || idx < firstLevelIdx:
+			// 已結束或未使用的等級
+			sl.IsPassed = c.State.BlindState.CurrentLevelIndex != UnsetValue
+			if sl.IsPassed && c.State.BlindState.EndAts[idx] > 0 {
+				sl.EndAt = c.State.BlindState.EndAts[idx]
+			}
+		default:
+			sl.IsProjected = idx != c.State.BlindState.CurrentLevelIndex
+			sl.StartAt = startAt
+			if startAt != UnsetValue && bl.HandCount == 0 && bl.Duration >= 0 {
+				sl.EndAt = startAt + int64(bl.Duration)
+			}
+			startAt = sl.EndAt
+		}
+
+		schedule.Levels = append(schedule.Levels, sl)
+	}
+
+	// 修正已結束等級開始時間
+	for idx := 1; idx < len(schedule.Levels); idx++ {
+		if schedule.Levels[idx].IsPassed && schedule.Levels[idx].StartAt == UnsetValue {
+			schedule.Levels[idx].StartAt = schedule.Levels[idx-1].EndAt
+		}
+	}
+
+	for _, sl := range schedule.Levels {
+		if sl.IsPassed {
+			continue
+		}
+
+		if sl.IsBreak && sl.IsProjected && schedule.NextBreakAt == UnsetValue {
+			schedule.NextBreakAt = sl.StartAt
+		}
+
+		if c.Meta.AdvanceSetting.Rule == CompetitionAdvanceRule_BlindLevel && sl.Level == c.Meta.AdvanceSetting.BlindLevel && schedule.Advance.TriggerAt == UnsetValue {
+			schedule.Advance.TriggerAt = sl.StartAt
+		}
+	}
+
+	// 延遲買入截止時間: 最後買入等級結束時
+	finalBuyInIdx := c.Meta.Blind.FinalBuyInLevelIndex
+	if finalBuyInIdx == UnsetValue {
+		schedule.LateRegistrationCloseAt = c.State.StartAt
+	} else if finalBuyInIdx >= 0 && finalBuyInIdx < len(schedule.Levels) {
+		schedule.LateRegistrationCloseAt = schedule.Levels[finalBuyInIdx].EndAt
+	}
+
+	return schedule
+}
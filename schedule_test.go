@@ -0,0 +1,176 @@
+package pokercompetition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+newScheduleTestCompetition 建立 1000 秒開賽的賽事
+  - 盲注等級: 1 (600s), 2 (600s), 中場休息 (300s), 3 (20 手), 4 (600s)
+  - 最後買入等級為等級 2，等級 3 開始時晉級
+*/
+func newScheduleTestCompetition(currentLevelIdx int, endAts []int64) *Competition {
+	return &Competition{
+		ID: "c1",
+		Meta: CompetitionMeta{
+			Blind: Blind{
+				InitialLevel:         1,
+				FinalBuyInLevelIndex: 1,
+				Levels: []BlindLevel{
+					{Level: 1, SB: 10, BB: 20, Duration: 600},
+					{Level: 2, SB: 20, BB: 40, Duration: 600},
+					{Level: -1, Duration: 300},
+					{Level: 3, SB: 30, BB: 60, Ante: 10, HandCount: 20},
+					{Level: 4, SB: 50, BB: 100, Duration: 600},
+				},
+			},
+			AdvanceSetting: AdvanceSetting{
+				Rule:       CompetitionAdvanceRule_BlindLevel,
+				BlindLevel: 3,
+			},
+		},
+		State: &CompetitionState{
+			StartAt: 1000,
+			BlindState: &BlindState{
+				FinalBuyInLevelIndex: 1,
+				CurrentLevelIndex:    currentLevelIdx,
+				EndAts:               endAts,
+			},
+		},
+	}
+}
+
+func Test_Schedule_NewSchedule(t *testing.T) {
+	testCases := []struct {
+		name               string
+		currentLevelIdx    int
+		endAts             []int64
+		startAts           []int64
+		levelEndAts        []int64
+		isPassed           []bool
+		isProjected        []bool
+		nextBreakAt        int64
+		lateRegCloseAt     int64
+		isLateRegOpen      bool
+		advanceTriggeredAt int64
+	}{
+		{
+			name:               "not started",
+			currentLevelIdx:    UnsetValue,
+			startAts:           []int64{1000, 1600, 2200, 2500, UnsetValue},
+			levelEndAts:        []int64{1600, 2200, 2500, UnsetValue, UnsetValue},
+			isPassed:           []bool{false, false, false, false, false},
+			isProjected:        []bool{true, true, true, true, true},
+			nextBreakAt:        2200,
+			lateRegCloseAt:     2200,
+			isLateRegOpen:      true,
+			advanceTriggeredAt: 2500,
+		},
+		{
+			name:               "first level",
+			currentLevelIdx:    0,
+			endAts:             []int64{1650, 0, 0, 0, 0},
+			startAts:           []int64{1050, 1650, 2250, 2550, UnsetValue},
+			levelEndAts:        []int64{1650, 2250, 2550, UnsetValue, UnsetValue},
+			isPassed:           []bool{false, false, false, false, false},
+			isProjected:        []bool{false, true, true, true, true},
+			nextBreakAt:        2250,
+			lateRegCloseAt:     2250,
+			isLateRegOpen:      true,
+			advanceTriggeredAt: 2550,
+		},
+		{
+			name:               "during break",
+			currentLevelIdx:    2,
+			endAts:             []int64{1600, 2200, 2500, 0, 0},
+			startAts:           []int64{UnsetValue, 1600, 2200, 2500, UnsetValue},
+			levelEndAts:        []int64{1600, 2200, 2500, UnsetValue, UnsetValue},
+			isPassed:           []bool{true, true, false, false, false},
+			isProjected:        []bool{false, false, false, true, true},
+			nextBreakAt:        UnsetValue,
+			lateRegCloseAt:     2200,
+			isLateRegOpen:      false,
+			advanceTriggeredAt: 2500,
+		},
+		{
+			name:               "hand count level",
+			currentLevelIdx:    3,
+			endAts:             []int64{1600, 2200, 2500, 0, 0},
+			startAts:           []int64{UnsetValue, 1600, 2200, 2500, UnsetValue},
+			levelEndAts:        []int64{1600, 2200, 2500, UnsetValue, UnsetValue},
+			isPassed:           []bool{true, true, true, false, false},
+			isProjected:        []bool{false, false, false, false, true},
+			nextBreakAt:        UnsetValue,
+			lateRegCloseAt:     2200,
+			isLateRegOpen:      false,
+			advanceTriggeredAt: 2500,
+		},
+		{
+			name:               "after hand count level",
+			currentLevelIdx:    4,
+			endAts:             []int64{1600, 2200, 2500, 3100, 3700},
+			startAts:           []int64{UnsetValue, 1600, 2200, 2500, 3100},
+			levelEndAts:        []int64{1600, 2200, 2500, 3100, 3700},
+			isPassed:           []bool{true, true, true, true, false},
+			isProjected:        []bool{false, false, false, false, false},
+			nextBreakAt:        UnsetValue,
+			lateRegCloseAt:     2200,
+			isLateRegOpen:      false,
+			advanceTriggeredAt: UnsetValue,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule := NewSchedule(newScheduleTestCompetition(tc.currentLevelIdx, tc.endAts))
+
+			startAts := make([]int64, 0)
+			endAts := make([]int64, 0)
+			isPassed := make([]bool, 0)
+			isProjected := make([]bool, 0)
+			for _, sl := range schedule.Levels {
+				startAts = append(startAts, sl.StartAt)
+				endAts = append(endAts, sl.EndAt)
+				isPassed = append(isPassed, sl.IsPassed)
+				isProjected = append(isProjected, sl.IsProjected)
+			}
+			assert.Equal(t, tc.startAts, startAts, "start at")
+			assert.Equal(t, tc.levelEndAts, endAts, "end at")
+			assert.Equal(t, tc.isPassed, isPassed, "is passed")
+			assert.Equal(t, tc.isProjected, isProjected, "is projected")
+			assert.Equal(t, tc.nextBreakAt, schedule.NextBreakAt, "next break at")
+			assert.Equal(t, tc.lateRegCloseAt, schedule.LateRegistrationCloseAt, "late registration close at")
+			assert.Equal(t, tc.isLateRegOpen, schedule.IsLateRegistrationOpen)
+			assert.Equal(t, tc.advanceTriggeredAt, schedule.Advance.TriggerAt, "advance trigger at")
+			assert.Equal(t, tc.currentLevelIdx, schedule.CurrentLevelIndex)
+		})
+	}
+}
+
+func Test_Schedule_Levels(t *testing.T) {
+	c := newScheduleTestCompetition(UnsetValue, nil)
+	c.Meta.Blind.Levels[1].Duration = UnsetValue
+	schedule := NewSchedule(c)
+
+	if !assert.Len(t, schedule.Levels, 5) {
+		return
+	}
+	assert.Equal(t, ScheduleLevel{Index: 2, Level: -1, IsBreak: true, StartAt: UnsetValue, EndAt: UnsetValue, IsProjected: true}, schedule.Levels[2])
+	assert.Equal(t, ScheduleLevel{Index: 3, Level: 3, SB: 30, BB: 60, Ante: 10, HandCount: 20, StartAt: UnsetValue, EndAt: UnsetValue, IsProjected: true}, schedule.Levels[3])
+
+	// 無時間限制的等級之後無法預估
+	assert.True(t, schedule.Levels[1].IsUnlimited)
+	assert.Equal(t, int64(1600), schedule.Levels[1].StartAt)
+	assert.Equal(t, int64(UnsetValue), schedule.Levels[1].EndAt)
+	assert.Equal(t, int64(UnsetValue), schedule.NextBreakAt)
+	assert.Equal(t, int64(UnsetValue), schedule.LateRegistrationCloseAt)
+
+	// 不可延遲買入時以開賽時間為截止時間
+	c.Meta.Blind.FinalBuyInLevelIndex = UnsetValue
+	c.State.BlindState.FinalBuyInLevelIndex = UnsetValue
+	schedule = NewSchedule(c)
+	assert.Equal(t, int64(1000), schedule.LateRegistrationCloseAt)
+	assert.False(t, schedule.IsLateRegistrationOpen)
+}
@@ -0,0 +1,227 @@
+package pokercompetition
+
+import (
+	"sort"
+	"time"
+
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/pokertable"
+)
+
+type SeatingViolationReason string
+
+const (
+	SeatingViolationReason_Team                   SeatingViolationReason = "team"                     // 同隊玩家同桌
+	SeatingViolationReason_Affiliation            SeatingViolationReason = "affiliation"              // 同單位玩家同桌
+	SeatingViolationReason_DeviceGroup            SeatingViolationReason = "device_group"             // 同裝置群組玩家同桌
+	SeatingViolationReason_PinnedSeatUnavailable  SeatingViolationReason = "pinned_seat_unavailable"  // 指定座位無法使用
+	SeatingViolationReason_PinnedTableUnavailable SeatingViolationReason = "pinned_table_unavailable" // 指定桌號無法使用
+)
+
+type SeatingMetadata struct {
+	Team         string `json:"team"`           // 隊伍 (同隊玩家盡量不同桌)
+	Affiliation  string `json:"affiliation"`    // 所屬單位 (同單位玩家盡量不同桌)
+	DeviceGroup  string `json:"device_group"`   // 裝置群組 (同裝置群組玩家盡量不同桌)
+	IsSeatPinned bool   `json:"is_seat_pinned"` // 是否指定座位 (ex: 無障礙需求)
+	PinnedSeat   int    `json:"pinned_seat"`    // 指定座位編號
+
+	IsTablePinned     bool `json:"is_table_pinned"`     // 是否指定桌號 (ex: 晉級玩家沿用原桌，僅首次入座時使用)
+	PinnedTableNumber int  `json:"pinned_table_number"` // 指定桌號 (從 1 開始)
+}
+
+type SeatingViolation struct {
+	PlayerID          string                 `json:"player_id"`           // 玩家 ID
+	TableID           string                 `json:"table_id"`            // 桌次 ID
+	Reason            SeatingViolationReason `json:"reason"`              // 違反原因
+	ConflictPlayerIDs []string               `json:"conflict_player_ids"` // 衝突玩家 IDs
+	CreatedAt         int64                  `json:"created_at"`          // 發生時間 (Seconds)
+}
+
+/*
+ConflictReasons 取得兩位玩家同桌時違反的座位限制
+  - 欄位皆為空值時不視為衝突
+*/
+func (sm SeatingMetadata) ConflictReasons(other SeatingMetadata) []SeatingViolationReason {
+	reasons := make([]SeatingViolationReason, 0)
+	if sm.Team != "" && sm.Team == other.Team {
+		reasons = append(reasons, SeatingViolationReason_Team)
+	}
+	if sm.Affiliation != "" && sm.Affiliation == other.Affiliation {
+		reasons = append(reasons, SeatingViolationReason_Affiliation)
+	}
+	if sm.DeviceGroup != "" && sm.DeviceGroup == other.DeviceGroup {
+		reasons = append(reasons, SeatingViolationReason_DeviceGroup)
+	}
+	return reasons
+}
+
+func (c Competition) findPlayer(playerID string) *CompetitionPlayer {
+	playerIdx := c.FindPlayerIdx(func(cp *CompetitionPlayer) bool {
+		return cp.PlayerID == playerID
+	})
+	if playerIdx == UnsetValue {
+		return nil
+	}
+	return c.State.Players[playerIdx]
+}
+
+/*
+tableSeatedPlayers 取得某桌次目前入座的玩家 ID 與座位
+  - excludePlayerIDs: 即將離開該桌次的玩家
+*/
+func (ce *competitionEngine) tableSeatedPlayers(tableID string, excludePlayerIDs []string) map[string]int {
+	seats := make(map[string]int)
+	tableIdx := ce.competition.FindTableIdx(func(t *pokertable.Table) bool {
+		return t.ID == tableID
+	})
+	if tableIdx == UnsetValue {
+		return seats
+	}
+
+	for _, ps := range ce.competition.State.Tables[tableIdx].State.PlayerStates {
+		if funk.ContainsString(excludePlayerIDs, ps.PlayerID) {
+			continue
+		}
+		seats[ps.PlayerID] = ps.Seat
+	}
+	return seats
+}
+
+/*
+seatingTableNumber 取得桌次桌號
+  - 尚未建立的桌次 (tableID 為空或不存在) 以下一個桌號計算
+*/
+func (ce *competitionEngine) seatingTableNumber(tableID string) int {
+	if info := ce.competition.FindTableInfo(tableID); info != nil {
+		return info.TableNumber
+	}
+	return ce.competition.nextTableNumber()
+}
+
+/*
+seatingCost 計算玩家入座某桌次的座位限制成本 (供拆併桌監管器挑選玩家)
+  - 每位衝突玩家 +1，指定座位已被佔用 +1，指定桌號不符 +1
+*/
+func (ce *competitionEngine) seatingCost(tableID string, pickedPlayerIDs []string, playerID string) int {
+	player := ce.competition.findPlayer(playerID)
+	if player == nil {
+		return 0
+	}
+
+	cost := 0
+	if player.Seating.IsTablePinned && player.Seating.PinnedTableNumber != ce.seatingTableNumber(tableID) {
+		cost++
+	}
+
+	seatedPlayers := ce.tableSeatedPlayers(tableID, []string{})
+	for seatedPlayerID, seat := range seatedPlayers {
+		if seatedPlayer := ce.competition.findPlayer(seatedPlayerID); seatedPlayer != nil && len(player.Seating.ConflictReasons(seatedPlayer.Seating)) > 0 {
+			cost++
+		}
+		if player.Seating.IsSeatPinned && player.Seating.PinnedSeat == seat {
+			cost++
+		}
+	}
+
+	for _, pickedPlayerID := range pickedPlayerIDs {
+		pickedPlayer := ce.competition.findPlayer(pickedPlayerID)
+		if pickedPlayer == nil {
+			continue
+		}
+		if len(player.Seating.ConflictReasons(pickedPlayer.Seating)) > 0 {
+			cost++
+		}
+		if player.Seating.IsSeatPinned && pickedPlayer.Seating.IsSeatPinned && player.Seating.PinnedSeat == pickedPlayer.Seating.PinnedSeat {
+			cost++
+		}
+	}
+	return cost
+}
+
+/*
+newSeatingJoinPlayers 建立入座資料並檢查座位限制
+  - 指定座位可用時直接入座該座位，否則隨機入座並記錄違反
+  - 指定桌號不符時記錄違反，入座後解除指定桌號 (之後依拆併桌安排)
+  - 與同桌玩家 (含同一批入座玩家) 的衝突無法避免時記錄違反
+*/
+func (ce *competitionEngine) newSeatingJoinPlayers(tableID string, joinPlayers []pokertable.JoinPlayer, leavePlayerIDs []string) []pokertable.JoinPlayer {
+	seatedPlayers := ce.tableSeatedPlayers(tableID, leavePlayerIDs)
+	occupiedSeats := make(map[int]bool)
+	for _, seat := range seatedPlayers {
+		occupiedSeats[seat] = true
+	}
+
+	tablePlayerIDs := make([]string, 0, len(seatedPlayers)+len(joinPlayers))
+	for playerID := range seatedPlayers {
+		tablePlayerIDs = append(tablePlayerIDs, playerID)
+	}
+	sort.Strings(tablePlayerIDs)
+
+	tableNumber := ce.seatingTableNumber(tableID)
+	violations := make([]*SeatingViolation, 0)
+	newViolation := func(playerID string, reason SeatingViolationReason, conflictPlayerIDs []string) {
+		violations = append(violations, &SeatingViolation{
+			PlayerID:          playerID,
+			TableID:           tableID,
+			Reason:            reason,
+			ConflictPlayerIDs: conflictPlayerIDs,
+			CreatedAt:         time.Now().Unix(),
+		})
+	}
+
+	results := make([]pokertable.JoinPlayer, 0, len(joinPlayers))
+	for _, jp := range joinPlayers {
+		player := ce.competition.findPlayer(jp.PlayerID)
+		if player == nil {
+			results = append(results, jp)
+			continue
+		}
+
+		// 指定桌號
+		if player.Seating.IsTablePinned {
+			if player.Seating.PinnedTableNumber != tableNumber {
+				newViolation(jp.PlayerID, SeatingViolationReason_PinnedTableUnavailable, []string{})
+			}
+			player.Seating.IsTablePinned = false
+		}
+
+		// 指定座位
+		if player.Seating.IsSeatPinned {
+			seat := player.Seating.PinnedSeat
+			if seat >= 0 && seat < ce.competition.Meta.TableMaxSeatCount && !occupiedSeats[seat] {
+				jp.Seat = seat
+				occupiedSeats[seat] = true
+			} else {
+				newViolation(jp.PlayerID, SeatingViolationReason_PinnedSeatUnavailable, []string{})
+			}
+		}
+
+		// 同桌衝突
+		conflicts := make(map[SeatingViolationReason][]string)
+		for _, tablePlayerID := range tablePlayerIDs {
+			tablePlayer := ce.competition.findPlayer(tablePlayerID)
+			if tablePlayer == nil {
+				continue
+			}
+			for _, reason := range player.Seating.ConflictReasons(tablePlayer.Seating) {
+				conflicts[reason] = append(conflicts[reason], tablePlayerID)
+			}
+		}
+		for _, reason := range []SeatingViolationReason{SeatingViolationReason_Team, SeatingViolationReason_Affiliation, SeatingViolationReason_DeviceGroup} {
+			if conflictPlayerIDs, ok := conflicts[reason]; ok {
+				newViolation(jp.PlayerID, reason, conflictPlayerIDs)
+			}
+		}
+
+		tablePlayerIDs = append(tablePlayerIDs, jp.PlayerID)
+		results = append(results, jp)
+	}
+
+	if len(violations) > 0 {
+		ce.competition.State.SeatingViolations = append(ce.competition.State.SeatingViolations, violations...)
+		ce.emitEvent("[SeatingViolated]", "")
+		ce.emitCompetitionStateEvent(CompetitionStateEvent_SeatingViolated)
+	}
+
+	return results
+}
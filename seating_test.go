@@ -0,0 +1,27 @@
+package pokercompetition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokertable"
+)
+
+func Test_Seating_PinnedTable(t *testing.T) {
+	pinned := newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_WaitingTableBalancing)
+	pinned.Seating = SeatingMetadata{IsTablePinned: true, PinnedTableNumber: 2}
+	ce := newTestCompetitionEngine(pinned, newTestCompetitionPlayer("p2", 1000, CompetitionPlayerStatus_WaitingTableBalancing))
+	ce.competition.State.TableInfos = []*TableInfo{{TableID: "t1", TableNumber: 1}}
+
+	// 尚未建立的桌次以下一個桌號計算
+	assert.Equal(t, 1, ce.seatingCost("t1", []string{}, "p1"))
+	assert.Equal(t, 0, ce.seatingCost("", []string{}, "p1"))
+	assert.Equal(t, 0, ce.seatingCost("t1", []string{}, "p2"))
+
+	joinPlayers := ce.newSeatingJoinPlayers("t1", []pokertable.JoinPlayer{{PlayerID: "p1", Seat: UnsetValue}}, []string{})
+	assert.Len(t, joinPlayers, 1)
+	if assert.Len(t, ce.competition.State.SeatingViolations, 1) {
+		assert.Equal(t, SeatingViolationReason_PinnedTableUnavailable, ce.competition.State.SeatingViolations[0].Reason)
+	}
+	assert.False(t, pinned.Seating.IsTablePinned, "pinned table should only apply to the first seating")
+}
@@ -0,0 +1,251 @@
+package pokercompetition
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokertable"
+)
+
+/*
+tableGameBackend NewTableManager 建立的桌次所使用的牌局後端
+  - 包裝 pokertable.NativeGameBackend，每張桌次一個，由桌次引擎依序呼叫
+  - 德州撲克規則: 混合賽制於兩手之間設定，下一手建立牌局時套用 (見 CreateGame)
+  - 前注模式: 大盲前注、按鈕前注 (見 PayAnte)
+  - 下注結構: 底池限注、固定限注，接受下注前驗證籌碼量 (見 validateWagerLevel)
+  - 每個回傳牌局狀態的操作都會檢查牌局是否已結算，並套用本手的前注修正
+*/
+type tableGameBackend struct {
+	*pokertable.NativeGameBackend
+	engine           pokerface.PokerFace
+	mu               sync.RWMutex
+	rule             string // 空值表示使用建桌規則
+	bettingStructure CompetitionBettingStructure
+	anteMode         CompetitionAnteMode
+	anteAdjustments  map[int]int64 // key: game player idx, value: 結算後最終籌碼修正量
+}
+
+func newTableGameBackend() *tableGameBackend {
+	return &tableGameBackend{
+		NativeGameBackend: pokertable.NewNativeGameBackend(),
+		engine:            pokerface.NewPokerFace(),
+		bettingStructure:  CompetitionBettingStructure_NoLimit,
+		anteMode:          CompetitionAnteMode_Classic,
+		anteAdjustments:   make(map[int]int64),
+	}
+}
+
+func (tgb *tableGameBackend) SetRule(rule string) {
+	tgb.mu.Lock()
+	defer tgb.mu.Unlock()
+	tgb.rule = rule
+}
+
+func (tgb *tableGameBackend) Rule() string {
+	tgb.mu.RLock()
+	defer tgb.mu.RUnlock()
+	return tgb.rule
+}
+
+func (tgb *tableGameBackend) SetBettingStructure(structure CompetitionBettingStructure) {
+	if structure == "" {
+		structure = CompetitionBettingStructure_NoLimit
+	}
+
+	tgb.mu.Lock()
+	defer tgb.mu.Unlock()
+	tgb.bettingStructure = structure
+}
+
+func (tgb *tableGameBackend) BettingStructure() CompetitionBettingStructure {
+	tgb.mu.RLock()
+	defer tgb.mu.RUnlock()
+	return tgb.bettingStructure
+}
+
+func (tgb *tableGameBackend) SetAnteMode(mode CompetitionAnteMode) {
+	if mode == "" {
+		mode = CompetitionAnteMode_Classic
+	}
+
+	tgb.mu.Lock()
+	defer tgb.mu.Unlock()
+	tgb.anteMode = mode
+}
+
+func (tgb *tableGameBackend) AnteMode() CompetitionAnteMode {
+	tgb.mu.RLock()
+	defer tgb.mu.RUnlock()
+	return tgb.anteMode
+}
+
+func (tgb *tableGameBackend) setAnteAdjustments(adjustments map[int]int64) {
+	tgb.mu.Lock()
+	defer tgb.mu.Unlock()
+	tgb.anteAdjustments = adjustments
+}
+
+/*
+settleAnte 牌局結算後修正最終籌碼
+  - pokerface 以每位玩家記入的前注計算結算，實際只有支付位置支付前注，因此把記入量還給其他玩家，並由支付位置補上差額
+  - 每手只套用一次 (套用後清除修正量)
+*/
+func (tgb *tableGameBackend) settleAnte(gs *pokerface.GameState, err error) (*pokerface.GameState, error) {
+	if err != nil || gs == nil || gs.Result == nil {
+		return gs, err
+	}
+
+	tgb.mu.Lock()
+	defer tgb.mu.Unlock()
+
+	if len(tgb.anteAdjustments) == 0 {
+		return gs, nil
+	}
+
+	for _, p := range gs.Result.Players {
+		if adjustment, ok := tgb.anteAdjustments[p.Idx]; ok {
+			p.Final += adjustment
+			p.Changed += adjustment
+		}
+	}
+	tgb.anteAdjustments = make(map[int]int64)
+	return gs, nil
+}
+
+/*
+CreateGame 建立牌局
+  - 桌次引擎依建桌規則準備牌局設定，已設定規則時改以該規則的牌組、手牌數與牌型大小建立牌局
+*/
+func (tgb *tableGameBackend) CreateGame(opts *pokerface.GameOptions) (*pokerface.GameState, error) {
+	tgb.setAnteAdjustments(make(map[int]int64))
+	if rule := tgb.Rule(); rule != "" {
+		applyGameRule(opts, rule)
+	}
+	return tgb.NativeGameBackend.CreateGame(opts)
+}
+
+/*
+applyGameRule 依德州撲克規則調整牌局設定
+  - 短牌: 短牌牌組與牌型大小，只收 Dealer 盲注 (移除大小盲位置)
+  - 奧瑪哈: 4 張手牌，須使用其中 2 張
+  - 常牌: 標準牌組，2 張手牌
+*/
+func applyGameRule(opts *pokerface.GameOptions, rule string) {
+	standard := pokerface.NewStardardGameOptions()
+	opts.CombinationPowers = standard.CombinationPowers
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.HoleCardsCount = standard.HoleCardsCount
+	opts.RequiredHoleCardsCount = standard.RequiredHoleCardsCount
+
+	switch rule {
+	case pokertable.CompetitionRule_ShortDeck:
+		opts.CombinationPowers = pokerface.NewShortDeckGameOptions().CombinationPowers
+		opts.Deck = pokerface.NewShortDeckCards()
+		opts.Blind.SB, opts.Blind.BB = 0, 0
+		for _, p := range opts.Players {
+			positions := make([]string, 0, len(p.Positions))
+			for _, position := range p.Positions {
+				if position != pokertable.Position_SB && position != pokertable.Position_BB {
+					positions = append(positions, position)
+				}
+			}
+			p.Positions = positions
+		}
+	case pokertable.CompetitionRule_Omaha:
+		opts.HoleCardsCount = 4
+		opts.RequiredHoleCardsCount = 2
+	}
+}
+
+func (tgb *tableGameBackend) ReadyForAll(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return tgb.settleAnte(tgb.NativeGameBackend.ReadyForAll(gs))
+}
+
+func (tgb *tableGameBackend) PayBlinds(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return tgb.settleAnte(tgb.NativeGameBackend.PayBlinds(gs))
+}
+
+func (tgb *tableGameBackend) Next(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return tgb.settleAnte(tgb.NativeGameBackend.Next(gs))
+}
+
+func (tgb *tableGameBackend) Pay(gs *pokerface.GameState, chips int64) (*pokerface.GameState, error) {
+	// 盲注、前注由 PayBlinds、PayAnte 收取，只驗證行動回合的支付
+	if gs.Status.CurrentEvent == pokerface.GameEventSymbols[pokerface.GameEvent_RoundStarted] {
+		if err := tgb.validateWager(gs, tgb.currentWager(gs)+chips); err != nil {
+			return nil, err
+		}
+	}
+	return tgb.settleAnte(tgb.NativeGameBackend.Pay(gs, chips))
+}
+
+func (tgb *tableGameBackend) Fold(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return tgb.settleAnte(tgb.NativeGameBackend.Fold(gs))
+}
+
+func (tgb *tableGameBackend) Check(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return tgb.settleAnte(tgb.NativeGameBackend.Check(gs))
+}
+
+func (tgb *tableGameBackend) Call(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return tgb.settleAnte(tgb.NativeGameBackend.Call(gs))
+}
+
+func (tgb *tableGameBackend) Allin(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	if p := gs.GetPlayer(gs.Status.CurrentPlayer); p != nil {
+		if err := tgb.validateWager(gs, p.InitialStackSize); err != nil {
+			return nil, err
+		}
+	}
+	return tgb.settleAnte(tgb.NativeGameBackend.Allin(gs))
+}
+
+func (tgb *tableGameBackend) Bet(gs *pokerface.GameState, chips int64) (*pokerface.GameState, error) {
+	if err := tgb.validateWager(gs, tgb.currentWager(gs)+chips); err != nil {
+		return nil, err
+	}
+	return tgb.settleAnte(tgb.NativeGameBackend.Bet(gs, chips))
+}
+
+func (tgb *tableGameBackend) Raise(gs *pokerface.GameState, chipLevel int64) (*pokerface.GameState, error) {
+	// pokerface 將超過玩家籌碼量的加注視為全下
+	level := chipLevel
+	if p := gs.GetPlayer(gs.Status.CurrentPlayer); p != nil && level > p.InitialStackSize {
+		level = p.InitialStackSize
+	}
+	if err := tgb.validateWager(gs, level); err != nil {
+		return nil, err
+	}
+	return tgb.settleAnte(tgb.NativeGameBackend.Raise(gs, chipLevel))
+}
+
+func (tgb *tableGameBackend) Pass(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return tgb.settleAnte(tgb.NativeGameBackend.Pass(gs))
+}
+
+// validateWager 驗證當前玩家下注後本輪總下注量是否符合下注結構
+func (tgb *tableGameBackend) validateWager(gs *pokerface.GameState, level int64) error {
+	return validateWagerLevel(tgb.BettingStructure(), gs, gs.Status.CurrentPlayer, level)
+}
+
+// currentWager 當前玩家本輪已下注量
+func (tgb *tableGameBackend) currentWager(gs *pokerface.GameState) int64 {
+	if p := gs.GetPlayer(gs.Status.CurrentPlayer); p != nil {
+		return p.Wager
+	}
+	return 0
+}
+
+func cloneGameState(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	data, err := json.Marshal(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	var state pokerface.GameState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
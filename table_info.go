@@ -0,0 +1,181 @@
+package pokercompetition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/pokertable"
+)
+
+type TableInfo struct {
+	TableID          string                      `json:"table_id"`          // 桌次 ID
+	TableNumber      int                         `json:"table_number"`      // 桌號 (從 1 開始，桌次關閉後依序重複使用)
+	IsFeatured       bool                        `json:"is_featured"`       // 是否為焦點桌 (不拆桌、最後補人)
+	Rule             CompetitionRule             `json:"rule"`              // 桌次下一手使用的德州撲克規則 (混合賽制輪替，桌次 Meta.Rule 為建桌規則)
+	BettingStructure CompetitionBettingStructure `json:"betting_structure"` // 桌次下一手使用的下注結構
+}
+
+func (c Competition) FindTableInfo(tableID string) *TableInfo {
+	for _, info := range c.State.TableInfos {
+		if info.TableID == tableID {
+			return info
+		}
+	}
+	return nil
+}
+
+// nextTableNumber 取得最小的未使用桌號
+func (c Competition) nextTableNumber() int {
+	usedNumbers := make(map[int]bool)
+	for _, info := range c.State.TableInfos {
+		usedNumbers[info.TableNumber] = true
+	}
+
+	tableNumber := 1
+	for usedNumbers[tableNumber] {
+		tableNumber++
+	}
+	return tableNumber
+}
+
+func (ce *competitionEngine) addTableInfo(tableID string, rule CompetitionRule) {
+	if ce.competition.FindTableInfo(tableID) != nil {
+		return
+	}
+
+	ce.competition.State.TableInfos = append(ce.competition.State.TableInfos, &TableInfo{
+		TableID:     tableID,
+		TableNumber: ce.competition.nextTableNumber(),
+		IsFeatured:  false,
+		Rule:        rule,
+	})
+}
+
+func (ce *competitionEngine) deleteTableInfo(tableID string) {
+	for idx, info := range ce.competition.State.TableInfos {
+		if info.TableID == tableID {
+			ce.competition.State.TableInfos = append(ce.competition.State.TableInfos[:idx], ce.competition.State.TableInfos[idx+1:]...)
+			return
+		}
+	}
+}
+
+// regulatorTableInfo 提供拆併桌監管器桌號與焦點桌資訊
+func (ce *competitionEngine) regulatorTableInfo(tableID string) (int, bool) {
+	info := ce.competition.FindTableInfo(tableID)
+	if info == nil {
+		return 0, false
+	}
+	return info.TableNumber, info.IsFeatured
+}
+
+/*
+handlePlayerMoves 將指定玩家由本桌移至目標桌次 (ex: 移至焦點桌、裁判指定換桌)
+  - 適用時機: MTT 本桌結算後、下一手開始前
+  - movablePlayerIDs: 本桌有籌碼且沒有被拆併桌移出的玩家
+  - 加入目標桌次失敗時玩家回到本桌原座位，無法回到本桌時進等待區由拆併桌監管器重新安排
+  - @return 移出本桌的人數
+*/
+func (ce *competitionEngine) handlePlayerMoves(ctx context.Context, table pokertable.Table, movablePlayerIDs []string) int {
+	ce.mu.Lock()
+	moves := make(map[string]playerMove)
+	for playerID, move := range ce.playerMoves {
+		if funk.ContainsString(movablePlayerIDs, playerID) {
+			moves[playerID] = move
+			delete(ce.playerMoves, playerID)
+		}
+	}
+	ce.mu.Unlock()
+
+	movedCount := 0
+	for playerID, move := range moves {
+		toTableID := move.tableID
+		if toTableID == table.ID {
+			continue
+		}
+
+		// 目標桌次需存在且有空位 (指定座位時該座位需為空位)
+		seatedPlayers := ce.tableSeatedPlayers(toTableID, []string{})
+		isSeatTaken := move.seat != UnsetValue && funk.ContainsInt(funk.Values(seatedPlayers).([]int), move.seat)
+		if !ce.competition.IsTableExist(toTableID) || len(seatedPlayers) >= ce.competition.Meta.TableMaxSeatCount || isSeatTaken {
+			ce.emitErrorEvent(fmt.Sprintf("[%s][%d] Move Player To Table (%s)", table.ID, table.State.GameCount, toTableID), playerID, ErrCompetitionPlayerMoveRejected)
+			continue
+		}
+
+		chips := int64(0)
+		fromSeat := UnsetValue
+		for _, ps := range table.State.PlayerStates {
+			if ps.PlayerID == playerID {
+				chips = ps.Bankroll
+				fromSeat = ps.Seat
+				break
+			}
+		}
+
+		if _, err := ce.backend(ctx).UpdateTablePlayers(table.ID, []pokertable.JoinPlayer{}, []string{playerID}); err != nil {
+			ce.emitErrorEvent(fmt.Sprintf("[%s][%d] Move Player -> Leave Table", table.ID, table.State.GameCount), playerID, err)
+			continue
+		}
+
+		joinPlayers := []pokertable.JoinPlayer{
+			{
+				PlayerID:    playerID,
+				RedeemChips: chips,
+				Seat:        move.seat,
+			},
+		}
+		if move.seat == UnsetValue {
+			joinPlayers = ce.newSeatingJoinPlayers(toTableID, joinPlayers, []string{})
+		}
+		tablePlayerSeatMap, err := ce.backend(ctx).UpdateTablePlayers(toTableID, joinPlayers, []string{})
+		if err != nil {
+			ce.emitErrorEvent(fmt.Sprintf("[%s] Move Player -> Join Table", toTableID), playerID, err)
+
+			// 加入目標桌次失敗: 以原座位、原籌碼回到本桌 (拆併桌尚未移動)
+			rollbackPlayers := []pokertable.JoinPlayer{
+				{
+					PlayerID:    playerID,
+					RedeemChips: chips,
+					Seat:        fromSeat,
+				},
+			}
+			if _, err := ce.backend(ctx).UpdateTablePlayers(table.ID, rollbackPlayers, []string{}); err != nil {
+				// 無法回到本桌: 玩家進等待區，由拆併桌監管器重新安排座位
+				ce.emitErrorEvent(fmt.Sprintf("[%s][%d] Move Player -> Rollback Join Table", table.ID, table.State.GameCount), playerID, err)
+				if player := ce.competition.findPlayer(playerID); player != nil {
+					ce.startPlayerMove(player, move.reason)
+					player.CurrentTableID = ""
+					player.CurrentSeat = UnsetValue
+					player.Status = CompetitionPlayerStatus_WaitingTableBalancing
+					ce.emitPlayerEvent(fmt.Sprintf("[MovePlayer] player (%s) is moving to the waiting room", playerID), player)
+				}
+				// 監管器的本桌人數需先扣除，否則玩家重新入座後會被重複計算
+				if err := ce.regulate(ctx, func() error {
+					return ce.regulator.RequeuePlayers(table.ID, []string{playerID})
+				}); err != nil {
+					ce.emitErrorEvent(fmt.Sprintf("[%s][%d] MTT Regulator Requeue Players", table.ID, table.State.GameCount), playerID, err)
+				}
+				movedCount++
+			}
+			continue
+		}
+		movedCount++
+
+		if err := ce.regulate(ctx, func() error {
+			return ce.regulator.MovePlayers(table.ID, toTableID, 1)
+		}); err != nil {
+			ce.emitErrorEvent(fmt.Sprintf("[%s][%d] MTT Regulator Move Players", table.ID, table.State.GameCount), playerID, err)
+		}
+
+		if player := ce.competition.findPlayer(playerID); player != nil {
+			ce.startPlayerMove(player, move.reason)
+			player.CurrentTableID = toTableID
+			player.CurrentSeat = tablePlayerSeatMap[playerID]
+			player.Status = CompetitionPlayerStatus_Playing
+			ce.emitPlayerEvent(fmt.Sprintf("[MovePlayer] player (%s) is moved from table (%s) to table (%s)", playerID, table.ID, toTableID), player)
+			ce.commitPlayerMove(player)
+		}
+	}
+	return movedCount
+}
@@ -0,0 +1,155 @@
+package pokercompetition
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	pokerbalancing "github.com/weedbox/pokercompetition/balancing"
+	"github.com/weedbox/pokerface/regulator"
+	"github.com/weedbox/pokertable"
+)
+
+// playerMoveTestBackend 依序回傳 UpdateTablePlayers 的結果，其他操作不應被呼叫
+type playerMoveTestBackend struct {
+	TableManagerBackend
+	errs  []error
+	calls []string
+}
+
+func (b *playerMoveTestBackend) UpdateTablePlayers(tableID string, joinPlayers []pokertable.JoinPlayer, leavePlayerIDs []string) (map[string]int, error) {
+	b.calls = append(b.calls, tableID)
+	err := b.errs[0]
+	b.errs = b.errs[1:]
+
+	seats := make(map[string]int)
+	for _, jp := range joinPlayers {
+		seats[jp.PlayerID] = jp.Seat
+	}
+	return seats, err
+}
+
+/*
+newPlayerMoveTestEngine 建立 MTT 換桌測試用賽事
+  - 本桌 t1 有 p1~p3 三位玩家 (座位 0~2)，目標桌次 t2 沒有玩家
+  - 監管器只管理 t1，assigned 紀錄監管器安排入座的玩家
+*/
+func newPlayerMoveTestEngine(errs ...error) (*competitionEngine, *playerMoveTestBackend, map[string][]string) {
+	assigned := make(map[string][]string)
+	ce := NewCompetitionEngine().(*competitionEngine)
+	ce.regulator = pokerbalancing.NewRegulator(
+		pokerbalancing.NewTDAStrategy(),
+		pokerbalancing.MinInitialPlayers(2),
+		pokerbalancing.MaxPlayersPerTable(9),
+		pokerbalancing.WithRequestTableFn(func(players []string) (string, error) {
+			assigned["t1"] = players
+			return "t1", nil
+		}),
+		pokerbalancing.WithAssignPlayersFn(func(tableID string, players []string) error {
+			assigned[tableID] = append(assigned[tableID], players...)
+			return nil
+		}),
+	)
+
+	playerIDs := []string{"p1", "p2", "p3"}
+	players := make([]*CompetitionPlayer, 0, len(playerIDs))
+	playerStates := make([]*pokertable.TablePlayerState, 0, len(playerIDs))
+	for seat, playerID := range playerIDs {
+		players = append(players, &CompetitionPlayer{PlayerID: playerID, CurrentTableID: "t1", CurrentSeat: seat, Chips: 1000, Status: CompetitionPlayerStatus_Playing})
+		playerStates = append(playerStates, &pokertable.TablePlayerState{PlayerID: playerID, Seat: seat, Bankroll: 1000})
+	}
+	ce.competition = &Competition{
+		ID:   "c1",
+		Meta: CompetitionMeta{Mode: CompetitionMode_MTT, TableMaxSeatCount: 9},
+		State: &CompetitionState{
+			Players: players,
+			Tables: []*pokertable.Table{
+				{ID: "t1", State: &pokertable.TableState{PlayerStates: playerStates}},
+				{ID: "t2", State: &pokertable.TableState{PlayerStates: []*pokertable.TablePlayerState{}}},
+			},
+			TableInfos: []*TableInfo{{TableID: "t1", TableNumber: 1}, {TableID: "t2", TableNumber: 2}},
+		},
+	}
+	_ = ce.regulator.AddPlayers(playerIDs)
+	ce.regulator.SetStatus(regulator.CompetitionStatus_Normal)
+
+	backend := &playerMoveTestBackend{errs: errs}
+	ce.tableManagerBackend = backend
+	return ce, backend, assigned
+}
+
+func Test_TableInfo_PlayerMoved(t *testing.T) {
+	ce, backend, _ := newPlayerMoveTestEngine(nil, nil)
+	movedEvents := make([]PlayerMoved, 0)
+	ce.OnCompetitionPlayerMoved(func(moved PlayerMoved) {
+		movedEvents = append(movedEvents, moved)
+	})
+	events := make([]Event, 0)
+	ce.OnEvent(func(event Event) {
+		if event.Type == EventType_PlayerMoved {
+			events = append(events, event)
+		}
+	})
+	ce.playerMoves["p1"] = playerMove{tableID: "t2", seat: 3, reason: PlayerMoveReason_Director}
+
+	movedCount := ce.handlePlayerMoves(context.Background(), *ce.competition.State.Tables[0], []string{"p1", "p2", "p3"})
+	assert.Equal(t, 1, movedCount)
+	assert.Equal(t, []string{"t1", "t2"}, backend.calls)
+
+	player := ce.competition.findPlayer("p1")
+	assert.Equal(t, "t2", player.CurrentTableID)
+	assert.Equal(t, 3, player.CurrentSeat)
+
+	if assert.Len(t, movedEvents, 1) {
+		moved := movedEvents[0]
+		assert.Equal(t, "c1", moved.CompetitionID)
+		assert.Equal(t, "p1", moved.PlayerID)
+		assert.Equal(t, []interface{}{"t1", 0, "t2", 3}, []interface{}{moved.FromTable, moved.FromSeat, moved.ToTable, moved.ToSeat})
+		assert.Equal(t, PlayerMoveReason_Director, moved.Reason)
+		assert.NotZero(t, moved.MovedAt)
+	}
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, movedEvents[0], events[0].Payload)
+	}
+
+	// 移動完成後不再重複發出事件
+	ce.commitPlayerMove(player)
+	assert.Len(t, movedEvents, 1)
+}
+
+func Test_TableInfo_PlayerMoveRolledBack(t *testing.T) {
+	// 加入目標桌次失敗、回到本桌原座位成功: 玩家沒有換桌，不發出 PlayerMoved
+	ce, backend, _ := newPlayerMoveTestEngine(nil, errors.New("join failed"), nil)
+	movedEvents := make([]PlayerMoved, 0)
+	ce.OnCompetitionPlayerMoved(func(moved PlayerMoved) {
+		movedEvents = append(movedEvents, moved)
+	})
+	ce.playerMoves["p1"] = playerMove{tableID: "t2", seat: 3, reason: PlayerMoveReason_Director}
+
+	movedCount := ce.handlePlayerMoves(context.Background(), *ce.competition.State.Tables[0], []string{"p1", "p2", "p3"})
+	assert.Equal(t, 0, movedCount)
+	assert.Equal(t, []string{"t1", "t2", "t1"}, backend.calls)
+	assert.Equal(t, "t1", ce.competition.findPlayer("p1").CurrentTableID)
+	assert.Empty(t, movedEvents)
+	assert.Equal(t, 3, ce.regulator.GetTable("t1").PlayerCount)
+}
+
+func Test_TableInfo_PlayerMoveRollbackFailed(t *testing.T) {
+	// 離開本桌成功、加入目標桌次失敗、回到本桌失敗
+	ce, backend, assigned := newPlayerMoveTestEngine(nil, errors.New("join failed"), errors.New("rollback failed"))
+	assert.Equal(t, 3, ce.regulator.GetTable("t1").PlayerCount)
+	ce.playerMoves["p1"] = playerMove{tableID: "t2", seat: 3, reason: PlayerMoveReason_Director}
+
+	movedCount := ce.handlePlayerMoves(context.Background(), *ce.competition.State.Tables[0], []string{"p1", "p2", "p3"})
+	assert.Equal(t, 1, movedCount)
+	assert.Equal(t, []string{"t1", "t2", "t1"}, backend.calls)
+
+	// 玩家進等待區後由監管器重新安排回本桌，本桌人數不重複計算
+	player := ce.competition.findPlayer("p1")
+	assert.Equal(t, CompetitionPlayerStatus_WaitingTableBalancing, player.Status)
+	assert.Equal(t, "", player.CurrentTableID)
+	assert.Equal(t, []string{"p1", "p2", "p3", "p1"}, assigned["t1"])
+	assert.Equal(t, 3, ce.regulator.GetTable("t1").PlayerCount)
+	assert.Equal(t, 3, ce.regulator.GetPlayerCount())
+}
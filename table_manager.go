@@ -0,0 +1,169 @@
+package pokercompetition
+
+import (
+	"sync"
+
+	"github.com/weedbox/pokertable"
+)
+
+/*
+TableEngineManager 桌次引擎管理
+  - NativeTableManagerBackend 只透過此介面建立、取得與釋放桌次引擎，其餘桌次操作直接呼叫桌次引擎
+  - pokertable.Manager 與 NewTableManager 建立的桌次管理皆實作此介面
+*/
+type TableEngineManager interface {
+	GetTableEngine(tableID string) (pokertable.TableEngine, error)
+	CreateTable(options *pokertable.TableEngineOptions, callbacks *pokertable.TableEngineCallbacks, setting pokertable.TableSetting) (*pokertable.Table, error)
+	CloseTable(tableID string) error
+	ReleaseTable(tableID string) error
+}
+
+var _ TableEngineManager = pokertable.Manager(nil)
+
+type TableManager interface {
+	TableEngineManager
+
+	// SetTableRule 設定桌次德州撲克規則 (下一手開局時生效)
+	SetTableRule(tableID string, rule string) error
+
+	// SetTableAnteMode 設定桌次前注模式 (下一次收取前注時生效)
+	SetTableAnteMode(tableID string, mode CompetitionAnteMode) error
+
+	// SetTableBettingStructure 設定桌次下注結構 (下一次接受下注時生效)
+	SetTableBettingStructure(tableID string, structure CompetitionBettingStructure) error
+}
+
+/*
+NewTableManager 建立桌次管理
+  - 桌次引擎以 pokertable.NewTableEngine 建立，並使用包裝 pokertable.NativeGameBackend 的牌局後端 (tableGameBackend)
+  - pokertable.NewManager 建立的桌次固定使用 NativeGameBackend，只支援一般前注
+*/
+func NewTableManager() TableManager {
+	return &tableManager{}
+}
+
+type tableManager struct {
+	tableEngines sync.Map // key: table id, value: pokertable.TableEngine
+	gameBackends sync.Map // key: table id, value: *tableGameBackend
+}
+
+func (m *tableManager) GetTableEngine(tableID string) (pokertable.TableEngine, error) {
+	tableEngine, exist := m.tableEngines.Load(tableID)
+	if !exist {
+		return nil, pokertable.ErrManagerTableNotFound
+	}
+	return tableEngine.(pokertable.TableEngine), nil
+}
+
+func (m *tableManager) CreateTable(options *pokertable.TableEngineOptions, callbacks *pokertable.TableEngineCallbacks, setting pokertable.TableSetting) (*pokertable.Table, error) {
+	if options == nil {
+		options = pokertable.NewTableEngineOptions()
+	}
+	if callbacks == nil {
+		callbacks = pokertable.NewTableEngineCallbacks()
+	}
+
+	gameBackend := newTableGameBackend()
+	tableEngine := pokertable.NewTableEngine(options, pokertable.WithGameBackend(gameBackend))
+	tableEngine.OnTableUpdated(callbacks.OnTableUpdated)
+	tableEngine.OnTableErrorUpdated(callbacks.OnTableErrorUpdated)
+	tableEngine.OnTableStateUpdated(callbacks.OnTableStateUpdated)
+	tableEngine.OnTablePlayerStateUpdated(callbacks.OnTablePlayerStateUpdated)
+	tableEngine.OnTablePlayerReserved(callbacks.OnTablePlayerReserved)
+	tableEngine.OnGamePlayerActionUpdated(callbacks.OnGamePlayerActionUpdated)
+	tableEngine.OnAutoGameOpenEnd(callbacks.OnAutoGameOpenEnd)
+	tableEngine.OnReadyOpenFirstTableGame(callbacks.OnReadyOpenFirstTableGame)
+	table, err := tableEngine.CreateTable(setting)
+	if err != nil {
+		return nil, err
+	}
+
+	m.tableEngines.Store(table.ID, tableEngine)
+	m.gameBackends.Store(table.ID, gameBackend)
+	return table, nil
+}
+
+func (m *tableManager) CloseTable(tableID string) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+
+	if err := tableEngine.CloseTable(); err != nil {
+		return err
+	}
+
+	m.delete(tableID)
+	return nil
+}
+
+func (m *tableManager) ReleaseTable(tableID string) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+
+	if err := tableEngine.ReleaseTable(); err != nil {
+		return err
+	}
+
+	m.delete(tableID)
+	return nil
+}
+
+/*
+SetTableRule 設定桌次德州撲克規則
+  - 座位管理 (按鈕、盲注位置) 於建桌時依建桌規則決定: 常牌/奧瑪哈座位可進行三種規則，短牌座位沒有大小盲位置，只能進行短牌
+*/
+func (m *tableManager) SetTableRule(tableID string, rule string) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return err
+	}
+
+	gameBackend, err := m.gameBackend(tableID)
+	if err != nil {
+		return err
+	}
+
+	// Meta.Rule 為建桌規則，建桌後不再變更
+	if !isRuleSeatingSupported(tableEngine.GetTable().Meta.Rule, rule) {
+		return ErrTableRuleSeatingMismatch
+	}
+
+	gameBackend.SetRule(rule)
+	return nil
+}
+
+func (m *tableManager) SetTableAnteMode(tableID string, mode CompetitionAnteMode) error {
+	gameBackend, err := m.gameBackend(tableID)
+	if err != nil {
+		return err
+	}
+
+	gameBackend.SetAnteMode(mode)
+	return nil
+}
+
+func (m *tableManager) SetTableBettingStructure(tableID string, structure CompetitionBettingStructure) error {
+	gameBackend, err := m.gameBackend(tableID)
+	if err != nil {
+		return err
+	}
+
+	gameBackend.SetBettingStructure(structure)
+	return nil
+}
+
+func (m *tableManager) gameBackend(tableID string) (*tableGameBackend, error) {
+	gameBackend, exist := m.gameBackends.Load(tableID)
+	if !exist {
+		return nil, pokertable.ErrManagerTableNotFound
+	}
+	return gameBackend.(*tableGameBackend), nil
+}
+
+func (m *tableManager) delete(tableID string) {
+	m.tableEngines.Delete(tableID)
+	m.gameBackends.Delete(tableID)
+}
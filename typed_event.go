@@ -0,0 +1,158 @@
+package pokercompetition
+
+import (
+	"time"
+)
+
+type EventType string
+
+const (
+	EventType_CompetitionCreated     EventType = "CompetitionCreated"     // 賽事建立
+	EventType_CompetitionStarted     EventType = "CompetitionStarted"     // 賽事開賽
+	EventType_CompetitionEnded       EventType = "CompetitionEnded"       // 賽事結束
+	EventType_PlayerRegistered       EventType = "PlayerRegistered"       // 玩家報名 (首次買入)
+	EventType_PlayerReBought         EventType = "PlayerReBought"         // 玩家補碼
+	EventType_PlayerAddedOn          EventType = "PlayerAddedOn"          // 玩家增購
+	EventType_PlayerRefunded         EventType = "PlayerRefunded"         // 玩家退賽
+	EventType_PlayerCashedOut        EventType = "PlayerCashedOut"        // 玩家離桌 (Cash)
+	EventType_PlayerKnockedOut       EventType = "PlayerKnockedOut"       // 玩家淘汰
+	EventType_PlayerFinalRanked      EventType = "PlayerFinalRanked"      // 賽事結束時存活玩家的最終排名
+	EventType_PlayerMoved            EventType = "PlayerMoved"            // 玩家換桌
+	EventType_BlindLevelChanged      EventType = "BlindLevelChanged"      // 盲注等級變更
+	EventType_TableCreated           EventType = "TableCreated"           // 桌次建立
+	EventType_TableClosed            EventType = "TableClosed"            // 桌次關閉
+	EventType_AddonOffered           EventType = "AddonOffered"           // 發送增購邀請
+	EventType_DirectorActionRecorded EventType = "DirectorActionRecorded" // 裁判操作
+)
+
+/*
+Event 賽事事件
+  - Seq: 同一賽事內遞增的事件序號
+  - Payload: 依 Type 對應的事件內容 (ex: EventType_PlayerReBought -> PlayerReBoughtPayload)
+*/
+type Event struct {
+	Seq           int64       `json:"seq"`            // 事件序號
+	Type          EventType   `json:"type"`           // 事件類型
+	CompetitionID string      `json:"competition_id"` // 賽事 ID
+	UpdateSerial  int64       `json:"update_serial"`  // 事件發生時的賽事更新序列號
+	Timestamp     int64       `json:"timestamp"`      // 事件時間 (Milliseconds)
+	Payload       interface{} `json:"payload"`        // 事件內容
+}
+
+type CompetitionCreatedPayload struct {
+	Mode CompetitionMode `json:"mode"` // 賽事模式
+}
+
+type CompetitionStartedPayload struct {
+	StartAt int64 `json:"start_at"` // 開賽時間 (Seconds)
+}
+
+type CompetitionEndedPayload struct {
+	Status CompetitionStateStatus `json:"status"` // 結束狀態
+	EndAt  int64                  `json:"end_at"` // 結束時間 (Seconds)
+}
+
+type PlayerRegisteredPayload struct {
+	PlayerID    string `json:"player_id"`    // 玩家 ID
+	TableID     string `json:"table_id"`     // 桌次 ID (MTT 等待拆併桌時為空字串)
+	RedeemChips int64  `json:"redeem_chips"` // 兌換籌碼
+	Units       int    `json:"units"`        // 買入發數
+}
+
+type PlayerReBoughtPayload struct {
+	PlayerID    string `json:"player_id"`    // 玩家 ID
+	RedeemChips int64  `json:"redeem_chips"` // 兌換籌碼
+	Units       int    `json:"units"`        // 補碼發數
+	ReBuyTimes  int    `json:"re_buy_times"` // 補碼後累計補碼次數
+	Chips       int64  `json:"chips"`        // 補碼後籌碼
+}
+
+type PlayerAddedOnPayload struct {
+	PlayerID    string `json:"player_id"`    // 玩家 ID
+	TableID     string `json:"table_id"`     // 桌次 ID
+	Package     string `json:"package"`      // 增購方案名稱 (沒有設定方案時為空字串)
+	RedeemChips int64  `json:"redeem_chips"` // 兌換籌碼
+	AddonTimes  int    `json:"addon_times"`  // 增購後累計增購次數
+	Chips       int64  `json:"chips"`        // 增購後籌碼
+}
+
+type PlayerRefundedPayload struct {
+	Record RefundRecord `json:"record"` // 退賽紀錄
+}
+
+type PlayerCashedOutPayload struct {
+	PlayerID string `json:"player_id"` // 玩家 ID
+	TableID  string `json:"table_id"`  // 桌次 ID
+	Chips    int64  `json:"chips"`     // 離桌籌碼
+}
+
+type PlayerKnockedOutPayload struct {
+	PlayerID       string `json:"player_id"`       // 玩家 ID
+	Rank           int    `json:"rank"`            // 淘汰名次
+	IsForfeited    bool   `json:"is_forfeited"`    // 是否為棄賽
+	IsDisqualified bool   `json:"is_disqualified"` // 是否被取消資格
+	KnockoutAt     int64  `json:"knockout_at"`     // 淘汰時間 (Seconds)
+}
+
+type PlayerFinalRankedPayload struct {
+	PlayerID string `json:"player_id"` // 玩家 ID
+	Rank     int    `json:"rank"`      // 最終名次
+	Chips    int64  `json:"chips"`     // 最終籌碼
+}
+
+type BlindLevelChangedPayload struct {
+	LevelIndex int        `json:"level_index"` // 盲注等級索引值
+	Level      BlindLevel `json:"level"`       // 盲注等級
+	EndAt      int64      `json:"end_at"`      // 等級結束時間 (Seconds, 依手數計算時為 UnsetValue)
+}
+
+type TableCreatedPayload struct {
+	TableID string `json:"table_id"` // 桌次 ID
+}
+
+type TableClosedPayload struct {
+	TableID string `json:"table_id"` // 桌次 ID
+}
+
+/*
+emitTypedEvent 發送賽事事件
+  - 序號指派、OnEvent 與發布在同一個鎖內完成，OnEvent 與訂閱者收到的序號皆依序遞增
+  - 舊的個別 callback 由 OnEvent 之後的 adaptEventCallbacks 轉接，不另外發送
+  - OnEvent 與 OverflowPolicy_Block 的訂閱者處理事件時不可同步觸發新的賽事事件 (會互相等待)
+*/
+func (ce *competitionEngine) emitTypedEvent(eventType EventType, payload interface{}) {
+	ce.eventMu.Lock()
+	defer ce.eventMu.Unlock()
+
+	ce.eventSeq++
+	event := Event{
+		Seq:           ce.eventSeq,
+		Type:          eventType,
+		CompetitionID: ce.competition.ID,
+		UpdateSerial:  ce.competition.UpdateSerial,
+		Timestamp:     time.Now().UnixMilli(),
+		Payload:       payload,
+	}
+	ce.onEvent(event)
+	ce.adaptEventCallbacks(event)
+	ce.eventBus.Publish(event)
+}
+
+// adaptEventCallbacks 將賽事事件轉接給舊的個別 callback
+func (ce *competitionEngine) adaptEventCallbacks(event Event) {
+	switch payload := event.Payload.(type) {
+	case PlayerKnockedOutPayload:
+		ce.onCompetitionFinalPlayerRankUpdated(event.CompetitionID, payload.PlayerID, payload.Rank)
+	case PlayerFinalRankedPayload:
+		ce.onCompetitionFinalPlayerRankUpdated(event.CompetitionID, payload.PlayerID, payload.Rank)
+	case PlayerCashedOutPayload:
+		// 離桌結算時玩家尚未自賽事移除
+		if cp := ce.competition.findPlayer(payload.PlayerID); cp != nil {
+			ce.onCompetitionPlayerCashOut(event.CompetitionID, cp)
+		}
+	case PlayerMoved:
+		ce.onCompetitionPlayerMoved(payload)
+	case AddonOffer:
+		ce.onCompetitionAddonOffered(payload)
+	}
+}
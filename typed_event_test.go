@@ -0,0 +1,353 @@
+package pokercompetition
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	pokerblind "github.com/weedbox/pokercompetition/blind"
+)
+
+// recordTypedEvents 記錄 OnEvent 收到的賽事事件
+func recordTypedEvents(ce *competitionEngine) func() []Event {
+	var mu sync.Mutex
+	events := make([]Event, 0)
+	ce.OnEvent(func(event Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	})
+	return func() []Event {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]Event{}, events...)
+	}
+}
+
+// clearEventTime 清除事件內容中的時間欄位 (比對用)
+func clearEventTime(payload interface{}) interface{} {
+	switch p := payload.(type) {
+	case PlayerKnockedOutPayload:
+		p.KnockoutAt = 0
+		return p
+	case PlayerMoved:
+		p.MovedAt = 0
+		return p
+	case PlayerRefundedPayload:
+		p.Record.CreatedAt = 0
+		return p
+	case DirectorAuditRecord:
+		p.ID = ""
+		p.CreatedAt = 0
+		return p
+	}
+	return payload
+}
+
+func Test_TypedEvent_Payloads(t *testing.T) {
+	testCases := []struct {
+		name      string
+		newEngine func() *competitionEngine
+		action    func(t *testing.T, ce *competitionEngine)
+		eventType EventType
+		payload   interface{}
+	}{
+		{
+			name:      "player registered",
+			newEngine: newReBuyTestEngine,
+			action: func(t *testing.T, ce *competitionEngine) {
+				ce.competition.State.Status = CompetitionStateStatus_Registering
+				assert.NoError(t, ce.PlayerBuyIn(JoinPlayer{PlayerID: "p4", RedeemChips: 1000, Unit: 2}))
+			},
+			eventType: EventType_PlayerRegistered,
+			payload:   PlayerRegisteredPayload{PlayerID: "p4", TableID: "", RedeemChips: 1000, Units: 2},
+		},
+		{
+			name:      "player re-bought",
+			newEngine: newReBuyTestEngine,
+			action: func(t *testing.T, ce *competitionEngine) {
+				ce.competition.Meta.ReBuySetting = ReBuySetting{MaxTime: 1, MaxUnitsPerReBuy: 1}
+				ce.competition.findPlayer("p2").ReBuyEndAt = time.Now().Add(time.Minute).Unix()
+				assert.NoError(t, ce.PlayerBuyIn(JoinPlayer{PlayerID: "p2", RedeemChips: 1000}))
+			},
+			eventType: EventType_PlayerReBought,
+			payload:   PlayerReBoughtPayload{PlayerID: "p2", RedeemChips: 1000, Units: 1, ReBuyTimes: 1, Chips: 1000},
+		},
+		{
+			name: "addon offered",
+			newEngine: func() *competitionEngine {
+				ce, _ := newAddonTestEngine()
+				return ce
+			},
+			action: func(t *testing.T, ce *competitionEngine) {
+				ce.offerAddons()
+			},
+			eventType: EventType_AddonOffered,
+			payload: AddonOffer{
+				CompetitionID:   "c1",
+				PlayerID:        "p1",
+				BlindLevelIndex: 1,
+				Packages: []AddonPackage{
+					{Name: "single", RedeemChips: 1000, Unit: 1, Eligibility: AddonEligibility{MaxChips: 2000}},
+					{Name: "double", RedeemChips: 2000, Unit: 2, Eligibility: AddonEligibility{NeverReBought: true}},
+				},
+				Deadline: 200,
+			},
+		},
+		{
+			name: "player added on",
+			newEngine: func() *competitionEngine {
+				ce, _ := newAddonTestEngine()
+				return ce
+			},
+			action: func(t *testing.T, ce *competitionEngine) {
+				assert.NoError(t, ce.PlayerAddon("t1", JoinPlayer{PlayerID: "p1", RedeemChips: 1000, AddonPackage: "single"}))
+			},
+			eventType: EventType_PlayerAddedOn,
+			payload:   PlayerAddedOnPayload{PlayerID: "p1", TableID: "t1", Package: "single", RedeemChips: 1000, AddonTimes: 1, Chips: 2000},
+		},
+		{
+			name: "player refunded",
+			newEngine: func() *competitionEngine {
+				ce := newTestCompetitionEngine(newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_WaitingTableBalancing))
+				ce.blind.ApplyOptions(&pokerblind.BlindOptions{})
+				return ce
+			},
+			action: func(t *testing.T, ce *competitionEngine) {
+				ce.competition.findPlayer("p1").TotalBuyInUnits = 1
+				assert.NoError(t, ce.PlayerRefund("p1"))
+			},
+			eventType: EventType_PlayerRefunded,
+			payload:   PlayerRefundedPayload{Record: RefundRecord{PlayerID: "p1", Units: 1, Chips: 1000, RefundPercent: 100}},
+		},
+		{
+			name: "player knocked out",
+			newEngine: func() *competitionEngine {
+				ce, _ := newForfeitTestEngine(ForfeitPolicy_RemoveChips)
+				return ce
+			},
+			action: func(t *testing.T, ce *competitionEngine) {
+				assert.NoError(t, ce.playerForfeit(context.Background(), "p3"))
+			},
+			eventType: EventType_PlayerKnockedOut,
+			payload:   PlayerKnockedOutPayload{PlayerID: "p3", Rank: 3, IsForfeited: true},
+		},
+		{
+			name: "player final ranked",
+			newEngine: func() *competitionEngine {
+				return newTestCompetitionEngine(newTestCompetitionPlayer("p1", 3000, CompetitionPlayerStatus_Playing))
+			},
+			action: func(t *testing.T, ce *competitionEngine) {
+				ce.emitCompetitionStateFinalPlayerRankEvent("p1", 1)
+			},
+			eventType: EventType_PlayerFinalRanked,
+			payload:   PlayerFinalRankedPayload{PlayerID: "p1", Rank: 1, Chips: 3000},
+		},
+		{
+			name: "player cashed out",
+			newEngine: func() *competitionEngine {
+				ce, _ := newForfeitTestEngine(ForfeitPolicy_BlindOff)
+				ce.competition.Meta.Mode = CompetitionMode_Cash
+				ce.competition.State.Status = CompetitionStateStatus_Registering
+				return ce
+			},
+			action: func(t *testing.T, ce *competitionEngine) {
+				assert.NoError(t, ce.PlayerQuit("t1", "p1"))
+			},
+			eventType: EventType_PlayerCashedOut,
+			payload:   PlayerCashedOutPayload{PlayerID: "p1", TableID: "t1", Chips: 1000},
+		},
+		{
+			name: "player moved",
+			newEngine: func() *competitionEngine {
+				ce, _ := newDirectorTestEngine()
+				return ce
+			},
+			action: func(t *testing.T, ce *competitionEngine) {
+				cp := ce.competition.findPlayer("p1")
+				ce.startPlayerMove(cp, PlayerMoveReason_Balancing)
+				cp.CurrentTableID = "t2"
+				cp.CurrentSeat = 3
+				ce.commitPlayerMove(cp)
+			},
+			eventType: EventType_PlayerMoved,
+			payload: PlayerMoved{
+				CompetitionID: "c1",
+				PlayerID:      "p1",
+				FromTable:     "t1",
+				FromSeat:      0,
+				ToTable:       "t2",
+				ToSeat:        3,
+				Reason:        PlayerMoveReason_Balancing,
+			},
+		},
+		{
+			name: "blind level changed",
+			newEngine: func() *competitionEngine {
+				ce := newTestCompetitionEngine()
+				ce.competition.Meta.Blind.Levels = []BlindLevel{
+					{Level: 1, SB: 10, BB: 20, Duration: 60},
+					{Level: 2, SB: 20, BB: 40, Duration: 60},
+				}
+				ce.competition.State.BlindState = &BlindState{CurrentLevelIndex: 1, EndAts: []int64{100, 200}}
+				return ce
+			},
+			action: func(t *testing.T, ce *competitionEngine) {
+				ce.emitBlindLevelChangedEvent()
+			},
+			eventType: EventType_BlindLevelChanged,
+			payload:   BlindLevelChangedPayload{LevelIndex: 1, Level: BlindLevel{Level: 2, SB: 20, BB: 40, Duration: 60}, EndAt: 200},
+		},
+		{
+			name: "director action recorded",
+			newEngine: func() *competitionEngine {
+				ce, _ := newDirectorTestEngine()
+				return ce
+			},
+			action: func(t *testing.T, ce *competitionEngine) {
+				assert.NoError(t, ce.PenalizePlayer("d1", "p1", 1, 0, "slow roll"))
+			},
+			eventType: EventType_DirectorActionRecorded,
+			payload: DirectorAuditRecord{
+				CompetitionID: "c1",
+				DirectorID:    "d1",
+				Action:        DirectorAction_Penalize,
+				PlayerID:      "p1",
+				Reason:        "slow roll",
+				Seat:          UnsetValue,
+				PenaltyOrbits: 1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ce := tc.newEngine()
+			events := recordTypedEvents(ce)
+
+			tc.action(t, ce)
+
+			matched := make([]Event, 0)
+			for idx, event := range events() {
+				assert.Equal(t, int64(idx+1), event.Seq, "event seq should increase by one")
+				assert.Equal(t, "c1", event.CompetitionID)
+				assert.NotZero(t, event.Timestamp)
+				if event.Type == tc.eventType {
+					matched = append(matched, event)
+				}
+			}
+			if assert.Len(t, matched, 1, "event should be emitted once") {
+				assert.Equal(t, tc.payload, clearEventTime(matched[0].Payload))
+			}
+		})
+	}
+}
+
+func Test_TypedEvent_CompetitionLifecycle(t *testing.T) {
+	ce := NewCompetitionEngine(WithTableManagerBackend(NewNativeTableManagerBackend(NewTableManager()))).(*competitionEngine)
+	events := recordTypedEvents(ce)
+
+	setting := newValidCompetitionSetting()
+	setting.CompetitionID = "c1"
+	setting.Meta.Mode = CompetitionMode_CT
+	setting.Meta.MaxDuration = 60
+	setting.TableSettings = []TableSetting{{TableID: "t1"}}
+	competition, err := ce.CreateCompetition(setting)
+	if !assert.NoError(t, err) {
+		return
+	}
+	tableID := competition.State.Tables[0].ID
+	startAt, err := ce.StartCompetition()
+	assert.NoError(t, err)
+	assert.NoError(t, ce.CloseCompetition(CompetitionStateStatus_End))
+
+	types := make([]EventType, 0)
+	for idx, event := range events() {
+		assert.Equal(t, int64(idx+1), event.Seq)
+		types = append(types, event.Type)
+		switch event.Type {
+		case EventType_CompetitionCreated:
+			assert.Equal(t, CompetitionCreatedPayload{Mode: CompetitionMode_CT}, event.Payload)
+		case EventType_TableCreated:
+			assert.Equal(t, TableCreatedPayload{TableID: tableID}, event.Payload)
+		case EventType_CompetitionStarted:
+			assert.Equal(t, CompetitionStartedPayload{StartAt: startAt}, event.Payload)
+		case EventType_TableClosed:
+			assert.Equal(t, TableClosedPayload{TableID: tableID}, event.Payload)
+		case EventType_CompetitionEnded:
+			payload := event.Payload.(CompetitionEndedPayload)
+			assert.Equal(t, CompetitionStateStatus_End, payload.Status)
+			assert.NotZero(t, payload.EndAt)
+		}
+	}
+	assert.Equal(t, []EventType{
+		EventType_TableCreated,
+		EventType_CompetitionCreated,
+		EventType_CompetitionStarted,
+		EventType_CompetitionEnded,
+		EventType_TableClosed,
+	}, types)
+}
+
+func Test_TypedEvent_CallbackAdapters(t *testing.T) {
+	ce := newTestCompetitionEngine(newTestCompetitionPlayer("p1", 1000, CompetitionPlayerStatus_CashLeaving))
+	events := recordTypedEvents(ce)
+
+	ranks := make(map[string]int)
+	ce.OnCompetitionFinalPlayerRankUpdated(func(competitionID, playerID string, rank int) {
+		ranks[playerID] = rank
+	})
+	cashOutPlayers := make([]*CompetitionPlayer, 0)
+	ce.OnCompetitionPlayerCashOut(func(competitionID string, cp *CompetitionPlayer) {
+		cashOutPlayers = append(cashOutPlayers, cp)
+	})
+	moves := make([]PlayerMoved, 0)
+	ce.OnCompetitionPlayerMoved(func(moved PlayerMoved) {
+		moves = append(moves, moved)
+	})
+	offers := make([]AddonOffer, 0)
+	ce.OnCompetitionAddonOffered(func(offer AddonOffer) {
+		offers = append(offers, offer)
+	})
+
+	// 舊的 callback 只由賽事事件轉接
+	ce.emitTypedEvent(EventType_PlayerKnockedOut, PlayerKnockedOutPayload{PlayerID: "p2", Rank: 2})
+	ce.emitTypedEvent(EventType_PlayerFinalRanked, PlayerFinalRankedPayload{PlayerID: "p3", Rank: 1})
+	ce.emitTypedEvent(EventType_PlayerCashedOut, PlayerCashedOutPayload{PlayerID: "p1", TableID: "t1", Chips: 1000})
+	ce.emitTypedEvent(EventType_PlayerMoved, PlayerMoved{PlayerID: "p1", ToTable: "t2"})
+	ce.emitTypedEvent(EventType_AddonOffered, AddonOffer{PlayerID: "p1"})
+	ce.emitTypedEvent(EventType_TableCreated, TableCreatedPayload{TableID: "t1"})
+
+	assert.Len(t, events(), 6)
+	assert.Equal(t, map[string]int{"p2": 2, "p3": 1}, ranks)
+	if assert.Len(t, cashOutPlayers, 1) {
+		assert.Equal(t, "p1", cashOutPlayers[0].PlayerID)
+	}
+	assert.Equal(t, []PlayerMoved{{PlayerID: "p1", ToTable: "t2"}}, moves)
+	assert.Equal(t, []AddonOffer{{PlayerID: "p1"}}, offers)
+
+	// 已離開賽事的玩家不轉接離桌結算
+	ce.emitTypedEvent(EventType_PlayerCashedOut, PlayerCashedOutPayload{PlayerID: "p9", TableID: "t1"})
+	assert.Len(t, cashOutPlayers, 1)
+}
+
+func Test_TypedEvent_SubscriberSeq(t *testing.T) {
+	ce, _ := newDirectorTestEngine()
+	received := make(chan Event, 10)
+	ce.SubscribeEvents(func(event Event) { received <- event }, SubscribeOptions{QueueSize: 10})
+
+	assert.NoError(t, ce.PenalizePlayer("d1", "p1", 1, 0, ""))
+	assert.NoError(t, ce.AdjustPlayerChips("d1", "p1", 500, ""))
+
+	for expected := int64(1); expected <= 2; expected++ {
+		select {
+		case event := <-received:
+			assert.Equal(t, expected, event.Seq)
+			assert.Equal(t, EventType_DirectorActionRecorded, event.Type)
+		case <-time.After(time.Second):
+			t.Fatalf("missing event seq %d", expected)
+		}
+	}
+}
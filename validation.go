@@ -0,0 +1,294 @@
+package pokercompetition
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pokerblind "github.com/weedbox/pokercompetition/blind"
+)
+
+type SettingViolation struct {
+	Field   string `json:"field"`   // 欄位路徑 (ex: meta.blind.levels[3].duration)
+	Message string `json:"message"` // 錯誤說明
+}
+
+type SettingViolations []SettingViolation
+
+func (vs SettingViolations) Error() string {
+	messages := make([]string, 0, len(vs))
+	for _, v := range vs {
+		messages = append(messages, fmt.Sprintf("%s: %s", v.Field, v.Message))
+	}
+	return fmt.Sprintf("%s (%s)", ErrCompetitionInvalidCreateSetting.Error(), strings.Join(messages, "; "))
+}
+
+// Is 讓 errors.Is(err, ErrCompetitionInvalidCreateSetting) 可判斷設定錯誤
+func (vs SettingViolations) Is(target error) bool {
+	return target == ErrCompetitionInvalidCreateSetting
+}
+
+func (vs *SettingViolations) add(field, format string, args ...interface{}) {
+	*vs = append(*vs, SettingViolation{
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+/*
+ValidateCompetitionSetting 驗證建立賽事設定
+  - @return 所有不合法欄位 (無錯誤時為 nil)
+*/
+func ValidateCompetitionSetting(setting CompetitionSetting) SettingViolations {
+	violations := SettingViolations{}
+	now := time.Now().Unix()
+
+	if setting.StartAt != UnsetValue && setting.StartAt < now {
+		violations.add("start_game_at", "must be unset (-1) or in the future")
+	}
+
+	if setting.DisableAt < now {
+		violations.add("disable_game_at", "must be in the future")
+	}
+
+	meta := setting.Meta
+	if meta.TableMaxSeatCount <= 0 {
+		violations.add("meta.table_max_seat_count", "must be greater than 0")
+	}
+
+	if meta.TableMinPlayerCount > meta.TableMaxSeatCount {
+		violations.add("meta.table_min_player_count", "must not exceed table_max_seat_count (%d)", meta.TableMaxSeatCount)
+	}
+
+	if meta.MaxPlayerCount > 0 && meta.MaxPlayerCount < meta.MinPlayerCount {
+		violations.add("meta.max_player_count", "must not be less than min_player_count (%d)", meta.MinPlayerCount)
+	}
+
+	for idx, tableSetting := range setting.TableSettings {
+		if len(tableSetting.JoinPlayers) > meta.TableMaxSeatCount {
+			violations.add(fmt.Sprintf("table_settings[%d].join_players", idx), "exceeds table_max_seat_count (%d)", meta.TableMaxSeatCount)
+		}
+	}
+
+	if meta.ReBuySetting.MTTWaitingTime < 0 {
+		violations.add("meta.rebuy_setting.mtt_waiting_time", "must not be negative")
+	}
+
+	switch meta.ReBuySetting.Rule {
+	case "", ReBuyRule_Bust:
+	case ReBuyRule_AtOrBelowStack:
+		if meta.ReBuySetting.StackThreshold <= 0 {
+			violations.add("meta.rebuy_setting.stack_threshold", "must be greater than 0 for rule %q", meta.ReBuySetting.Rule)
+		}
+	default:
+		violations.add("meta.rebuy_setting.rule", "unknown re-buy rule %q", meta.ReBuySetting.Rule)
+	}
+
+	addonPackageNames := make(map[string]bool)
+	for idx, p := range meta.AddonSetting.Packages {
+		field := fmt.Sprintf("meta.addon_setting.packages[%d]", idx)
+		if p.Name == "" {
+			violations.add(field+".name", "must not be empty")
+		} else if addonPackageNames[p.Name] {
+			violations.add(field+".name", "duplicate package name %q", p.Name)
+		}
+		addonPackageNames[p.Name] = true
+
+		if p.RedeemChips <= 0 {
+			violations.add(field+".redeem_chips", "must be greater than 0")
+		}
+		if p.Price < 0 {
+			violations.add(field+".price", "must not be negative")
+		}
+	}
+
+	if meta.ReBuySetting.MaxUnitsPerReBuy < 0 {
+		violations.add("meta.rebuy_setting.max_units_per_rebuy", "must not be negative")
+	}
+
+	if meta.AdvanceSetting.HandForHandPlayerCount < 0 {
+		violations.add("meta.advance_setting.hand_for_hand_player_count", "must not be negative")
+	}
+
+	if meta.RefundPolicy.MaxBlindLevel < 0 {
+		violations.add("meta.refund_policy.max_blind_level", "must not be negative")
+	}
+
+	if meta.RefundPolicy.RefundPercent < 0 || meta.RefundPolicy.RefundPercent > 100 {
+		violations.add("meta.refund_policy.refund_percent", "must be between 0 and 100")
+	}
+
+	switch meta.ForfeitPolicy {
+	case "", ForfeitPolicy_RemoveChips, ForfeitPolicy_BlindOff:
+	default:
+		violations.add("meta.forfeit_policy", "unknown forfeit policy %q", meta.ForfeitPolicy)
+	}
+
+	if !isValidBettingStructure(meta.BettingStructure) {
+		violations.add("meta.betting_structure", "unknown betting structure %q", meta.BettingStructure)
+	}
+
+	switch meta.BalancingStrategy {
+	case "", CompetitionBalancingStrategy_TDA, CompetitionBalancingStrategy_BreakHighestTable, CompetitionBalancingStrategy_MinimizeMoves:
+	default:
+		violations.add("meta.balancing_strategy", "unknown balancing strategy %q", meta.BalancingStrategy)
+	}
+
+	if rotation := meta.RuleRotation; len(rotation.Games) > 0 {
+		switch rotation.Trigger {
+		case CompetitionRuleRotationTrigger_BlindLevel:
+		case CompetitionRuleRotationTrigger_HandCount:
+			if rotation.HandCount <= 0 {
+				violations.add("meta.rule_rotation.hand_count", "must be greater than 0")
+			}
+		default:
+			violations.add("meta.rule_rotation.trigger", "unknown trigger %q", rotation.Trigger)
+		}
+
+		for idx, game := range rotation.Games {
+			field := fmt.Sprintf("meta.rule_rotation.games[%d]", idx)
+			switch game.Rule {
+			case CompetitionRule_Default, CompetitionRule_ShortDeck, CompetitionRule_Omaha:
+			default:
+				violations.add(field+".rule", "unknown rule %q", game.Rule)
+			}
+			if !isValidBettingStructure(game.BettingStructure) {
+				violations.add(field+".betting_structure", "unknown betting structure %q", game.BettingStructure)
+			}
+		}
+	}
+
+	previousMinChipUnit := meta.MinChipUnit
+	for idx, colorUp := range meta.ColorUps {
+		field := fmt.Sprintf("meta.color_ups[%d]", idx)
+		if colorUp.BlindLevelIndex < 0 || colorUp.BlindLevelIndex >= len(meta.Blind.Levels) {
+			violations.add(field+".blind_level_idx", "must be an index between 0 and %d", len(meta.Blind.Levels)-1)
+		}
+		if colorUp.MinChipUnit <= previousMinChipUnit {
+			violations.add(field+".min_chip_unit", "must be greater than previous min chip unit (%d)", previousMinChipUnit)
+		} else if previousMinChipUnit > 0 && colorUp.MinChipUnit%previousMinChipUnit != 0 {
+			violations.add(field+".min_chip_unit", "must be a multiple of previous min chip unit (%d)", previousMinChipUnit)
+		}
+		switch colorUp.Method {
+		case "", CompetitionColorUpMethod_ChipRace, CompetitionColorUpMethod_RoundUp:
+		default:
+			violations.add(field+".method", "unknown color up method %q", colorUp.Method)
+		}
+		if idx > 0 && colorUp.BlindLevelIndex <= meta.ColorUps[idx-1].BlindLevelIndex {
+			violations.add(field+".blind_level_idx", "must be greater than previous color up level index (%d)", meta.ColorUps[idx-1].BlindLevelIndex)
+		}
+		previousMinChipUnit = colorUp.MinChipUnit
+	}
+
+	violations = append(violations, validateBlind(meta)...)
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return violations
+}
+
+func validateBlind(meta CompetitionMeta) SettingViolations {
+	violations := SettingViolations{}
+	blind := meta.Blind
+	levelCount := len(blind.Levels)
+
+	if levelCount == 0 {
+		violations.add("meta.blind.levels", "must not be empty")
+		return violations
+	}
+
+	// 起始盲注等級必須存在且不可為中場休息
+	hasInitialLevel := false
+	for _, bl := range blind.Levels {
+		if bl.Level != -1 && bl.Level == blind.InitialLevel {
+			hasInitialLevel = true
+			break
+		}
+	}
+	if !hasInitialLevel {
+		violations.add("meta.blind.initial_level", "level %d is not found in levels", blind.InitialLevel)
+	}
+
+	// 最後買入盲注等級索引值: -1 (不可補碼), -2 (永遠延遲買入), 或是合法索引值
+	finalBuyInIdx := blind.FinalBuyInLevelIndex
+	if finalBuyInIdx != UnsetValue && finalBuyInIdx != NoStopBuyInIndex && (finalBuyInIdx < 0 || finalBuyInIdx >= levelCount) {
+		violations.add("meta.blind.final_buy_in_level_idx", "must be -1, -2 or an index between 0 and %d", levelCount-1)
+	}
+
+	switch blind.AnteMode {
+	case "", CompetitionAnteMode_Classic, CompetitionAnteMode_BigBlind, CompetitionAnteMode_Button:
+	default:
+		violations.add("meta.blind.ante_mode", "unknown ante mode %q", blind.AnteMode)
+	}
+
+	switch blind.HandCountMode {
+	case "", string(pokerblind.HandCountMode_MaxTable), string(pokerblind.HandCountMode_Synchronized):
+	default:
+		violations.add("meta.blind.hand_count_mode", "unknown hand count mode %q", blind.HandCountMode)
+	}
+
+	var prev *BlindLevel
+	for idx, bl := range blind.Levels {
+		field := fmt.Sprintf("meta.blind.levels[%d]", idx)
+		isLast := idx == levelCount-1
+
+		// 以手數計算的等級: 中場休息沒有牌局，不能以手數計算
+		if bl.HandCount < 0 {
+			violations.add(field+".hand_count", "must not be negative")
+		} else if bl.HandCount > 0 {
+			if bl.Level == -1 {
+				violations.add(field+".hand_count", "break level must be time based")
+			}
+		} else if bl.Duration < UnsetValue {
+			// 持續時間: 只有最後一個等級可以是 0 或 -1 (無限)，否則之後的等級永遠不會發生
+			violations.add(field+".duration", "must be -1 (unlimited) or greater than or equal to 0")
+		} else if !isLast && bl.Duration <= 0 {
+			violations.add(field+".duration", "only the last level may have a duration of %d", bl.Duration)
+		}
+
+		if bl.Level == -1 {
+			// 中場休息
+			if bl.SB != 0 || bl.BB != 0 || bl.Ante != 0 {
+				violations.add(field, "break level must not have blinds or ante")
+			}
+			continue
+		}
+
+		if meta.AddonSetting.IsBreakOnly && bl.AllowAddon {
+			violations.add(field+".allow_addon", "addon is break only but level %d allows addon", bl.Level)
+		}
+
+		if bl.SB <= 0 || bl.BB <= 0 {
+			violations.add(field, "sb and bb must be greater than 0")
+		} else if bl.SB > bl.BB {
+			violations.add(field+".sb", "must not be greater than bb")
+		}
+
+		if bl.Ante < 0 {
+			violations.add(field+".ante", "must not be negative")
+		}
+
+		// 盲注必須遞增 (與前一個非中場休息等級比較)
+		if prev != nil {
+			if bl.Level <= prev.Level {
+				violations.add(field+".level", "must be greater than previous level %d", prev.Level)
+			}
+			if bl.SB <= prev.SB || bl.BB <= prev.BB {
+				violations.add(field, "blinds must increase from level %d", prev.Level)
+			}
+		}
+		prev = &blind.Levels[idx]
+	}
+
+	return violations
+}
+
+// isValidBettingStructure 下注結構是否合法 (空值表示使用預設值)
+func isValidBettingStructure(structure CompetitionBettingStructure) bool {
+	switch structure {
+	case "", CompetitionBettingStructure_NoLimit, CompetitionBettingStructure_PotLimit, CompetitionBettingStructure_FixedLimit:
+		return true
+	}
+	return false
+}
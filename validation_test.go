@@ -0,0 +1,121 @@
+package pokercompetition
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newValidCompetitionSetting() CompetitionSetting {
+	return CompetitionSetting{
+		StartAt:   UnsetValue,
+		DisableAt: time.Now().Add(time.Hour).Unix(),
+		Meta: CompetitionMeta{
+			Blind: Blind{
+				InitialLevel:         1,
+				FinalBuyInLevelIndex: 1,
+				Levels: []BlindLevel{
+					{Level: 1, SB: 10, BB: 20, Duration: 60},
+					{Level: 2, SB: 20, BB: 40, Duration: 60},
+					{Level: -1, Duration: 60},
+					{Level: 3, SB: 30, BB: 60, Ante: 10, Duration: 60},
+				},
+			},
+			MinPlayerCount:      2,
+			MaxPlayerCount:      9,
+			TableMaxSeatCount:   9,
+			TableMinPlayerCount: 2,
+			Rule:                CompetitionRule_Default,
+			Mode:                CompetitionMode_MTT,
+			ActionTime:          10,
+			MinChipUnit:         10,
+		},
+	}
+}
+
+func Test_Validation_ValidSetting(t *testing.T) {
+	assert.Nil(t, ValidateCompetitionSetting(newValidCompetitionSetting()))
+
+	// 短牌與常牌/奧瑪哈可混合輪替
+	setting := newValidCompetitionSetting()
+	setting.Meta.RuleRotation = RuleRotation{Trigger: CompetitionRuleRotationTrigger_BlindLevel, Games: []RuleRotationGame{{Rule: CompetitionRule_Default}, {Rule: CompetitionRule_ShortDeck}, {Rule: CompetitionRule_Omaha}}}
+	assert.Nil(t, ValidateCompetitionSetting(setting))
+}
+
+func Test_Validation_Violations(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(s *CompetitionSetting)
+		field  string
+	}{
+		{name: "start at in the past", field: "start_game_at", modify: func(s *CompetitionSetting) { s.StartAt = 1 }},
+		{name: "disable at in the past", field: "disable_game_at", modify: func(s *CompetitionSetting) { s.DisableAt = 1 }},
+		{name: "no seats", field: "meta.table_max_seat_count", modify: func(s *CompetitionSetting) { s.Meta.TableMaxSeatCount = 0 }},
+		{name: "min player count over seats", field: "meta.table_min_player_count", modify: func(s *CompetitionSetting) { s.Meta.TableMinPlayerCount = 10 }},
+		{name: "max player count under min", field: "meta.max_player_count", modify: func(s *CompetitionSetting) { s.Meta.MaxPlayerCount = 1 }},
+		{name: "unknown rotation rule", field: "meta.rule_rotation.games[0].rule", modify: func(s *CompetitionSetting) {
+			s.Meta.RuleRotation = RuleRotation{Trigger: CompetitionRuleRotationTrigger_BlindLevel, Games: []RuleRotationGame{{Rule: "stud"}}}
+		}},
+		{name: "unknown betting structure", field: "meta.betting_structure", modify: func(s *CompetitionSetting) { s.Meta.BettingStructure = "spread_limit" }},
+		{name: "unknown rotation betting structure", field: "meta.rule_rotation.games[0].betting_structure", modify: func(s *CompetitionSetting) {
+			s.Meta.RuleRotation = RuleRotation{Trigger: CompetitionRuleRotationTrigger_BlindLevel, Games: []RuleRotationGame{{Rule: CompetitionRule_Default, BettingStructure: "spread_limit"}}}
+		}},
+		{name: "rotation without hand count", field: "meta.rule_rotation.hand_count", modify: func(s *CompetitionSetting) {
+			s.Meta.RuleRotation = RuleRotation{Trigger: CompetitionRuleRotationTrigger_HandCount, Games: []RuleRotationGame{{Rule: CompetitionRule_Default}}}
+		}},
+		{name: "color up not increasing", field: "meta.color_ups[0].min_chip_unit", modify: func(s *CompetitionSetting) {
+			s.Meta.ColorUps = []ColorUp{{BlindLevelIndex: 1, MinChipUnit: 10}}
+		}},
+		{name: "color up level out of range", field: "meta.color_ups[0].blind_level_idx", modify: func(s *CompetitionSetting) {
+			s.Meta.ColorUps = []ColorUp{{BlindLevelIndex: 4, MinChipUnit: 20}}
+		}},
+		{name: "empty levels", field: "meta.blind.levels", modify: func(s *CompetitionSetting) { s.Meta.Blind.Levels = nil }},
+		{name: "initial level not found", field: "meta.blind.initial_level", modify: func(s *CompetitionSetting) { s.Meta.Blind.InitialLevel = 5 }},
+		{name: "initial level is a break", field: "meta.blind.initial_level", modify: func(s *CompetitionSetting) { s.Meta.Blind.InitialLevel = -1 }},
+		{name: "final buy in index out of range", field: "meta.blind.final_buy_in_level_idx", modify: func(s *CompetitionSetting) { s.Meta.Blind.FinalBuyInLevelIndex = 4 }},
+		{name: "unknown ante mode", field: "meta.blind.ante_mode", modify: func(s *CompetitionSetting) { s.Meta.Blind.AnteMode = "straddle" }},
+		{name: "unknown hand count mode", field: "meta.blind.hand_count_mode", modify: func(s *CompetitionSetting) { s.Meta.Blind.HandCountMode = "min_table" }},
+		{name: "zero duration in the middle", field: "meta.blind.levels[1].duration", modify: func(s *CompetitionSetting) { s.Meta.Blind.Levels[1].Duration = 0 }},
+		{name: "negative hand count", field: "meta.blind.levels[0].hand_count", modify: func(s *CompetitionSetting) { s.Meta.Blind.Levels[0].HandCount = -1 }},
+		{name: "hand count on break", field: "meta.blind.levels[2].hand_count", modify: func(s *CompetitionSetting) { s.Meta.Blind.Levels[2].HandCount = 10 }},
+		{name: "break with blinds", field: "meta.blind.levels[2]", modify: func(s *CompetitionSetting) { s.Meta.Blind.Levels[2].BB = 20 }},
+		{name: "addon outside break", field: "meta.blind.levels[0].allow_addon", modify: func(s *CompetitionSetting) {
+			s.Meta.AddonSetting.IsBreakOnly = true
+			s.Meta.Blind.Levels[0].AllowAddon = true
+		}},
+		{name: "zero blinds", field: "meta.blind.levels[0]", modify: func(s *CompetitionSetting) { s.Meta.Blind.Levels[0].SB = 0 }},
+		{name: "sb over bb", field: "meta.blind.levels[3].sb", modify: func(s *CompetitionSetting) { s.Meta.Blind.Levels[3].SB = 70 }},
+		{name: "negative ante", field: "meta.blind.levels[0].ante", modify: func(s *CompetitionSetting) { s.Meta.Blind.Levels[0].Ante = -1 }},
+		{name: "level not increasing", field: "meta.blind.levels[3].level", modify: func(s *CompetitionSetting) { s.Meta.Blind.Levels[3].Level = 2 }},
+		{name: "equal blinds", field: "meta.blind.levels[1]", modify: func(s *CompetitionSetting) {
+			s.Meta.Blind.Levels[1].SB, s.Meta.Blind.Levels[1].BB = 10, 20
+		}},
+		{name: "equal sb", field: "meta.blind.levels[1]", modify: func(s *CompetitionSetting) { s.Meta.Blind.Levels[1].SB = 10 }},
+		{name: "decreasing blinds across break", field: "meta.blind.levels[3]", modify: func(s *CompetitionSetting) {
+			s.Meta.Blind.Levels[3].SB, s.Meta.Blind.Levels[3].BB = 10, 20
+		}},
+		{name: "unknown re-buy rule", field: "meta.rebuy_setting.rule", modify: func(s *CompetitionSetting) { s.Meta.ReBuySetting.Rule = "always" }},
+		{name: "duplicate addon package", field: "meta.addon_setting.packages[1].name", modify: func(s *CompetitionSetting) {
+			s.Meta.AddonSetting.Packages = []AddonPackage{{Name: "a", RedeemChips: 100}, {Name: "a", RedeemChips: 200}}
+		}},
+		{name: "refund percent over 100", field: "meta.refund_policy.refund_percent", modify: func(s *CompetitionSetting) { s.Meta.RefundPolicy.RefundPercent = 101 }},
+		{name: "unknown forfeit policy", field: "meta.forfeit_policy", modify: func(s *CompetitionSetting) { s.Meta.ForfeitPolicy = "keep" }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setting := newValidCompetitionSetting()
+			tc.modify(&setting)
+
+			violations := ValidateCompetitionSetting(setting)
+			fields := make([]string, 0, len(violations))
+			for _, v := range violations {
+				fields = append(fields, v.Field)
+			}
+			assert.Contains(t, fields, tc.field)
+			assert.True(t, errors.Is(violations, ErrCompetitionInvalidCreateSetting))
+		})
+	}
+}